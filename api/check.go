@@ -0,0 +1,293 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/anthropics/anthropic-sdk-go"
+)
+
+// AIDEV-NOTE: Credential checks only use free or read-only endpoints. The one
+// exception is a single One Call request, which counts against the daily free calls
+// but is the only way to tell whether the 3.0 subscription is active.
+
+// CheckStatus is the outcome of a credential check
+type CheckStatus string
+
+// Check outcomes
+const (
+	CheckPass CheckStatus = "PASS"
+	CheckWarn CheckStatus = "WARN"
+	CheckFail CheckStatus = "FAIL"
+	CheckSkip CheckStatus = "SKIP" // Not run because an earlier check failed
+)
+
+// lowQuotaCharacters is the remaining ElevenLabs quota that triggers a warning,
+// roughly a handful of 20-second reports
+const lowQuotaCharacters = 2000
+
+// CheckResult reports one credential, subscription or ID check
+type CheckResult struct {
+	Service string      // OpenWeather, Claude or ElevenLabs
+	Check   string      // What was checked
+	Status  CheckStatus // Outcome
+	Detail  string      // What was found
+	Hint    string      // How to fix it (empty when passing)
+}
+
+// CheckCredentials confirms the OpenWeather key on the free current weather
+// endpoint, then that the account has an active One Call 3.0 subscription
+func (w *WeatherClient) CheckCredentials(ctx context.Context, params ForecastParams) []CheckResult {
+	_, keyErr := w.GetCurrentWeather(ctx, params)
+	var oneCallErr error
+	if keyErr == nil {
+		_, oneCallErr = w.GetOneCallWeather(ctx, params)
+	}
+	return openWeatherCheckResults(keyErr, oneCallErr)
+}
+
+// openWeatherCheckResults turns the key and One Call errors into check rows
+func openWeatherCheckResults(keyErr, oneCallErr error) []CheckResult {
+	key := CheckResult{Service: "OpenWeather", Check: "API key", Status: CheckPass, Detail: "accepted"}
+	oneCall := CheckResult{Service: "OpenWeather", Check: "One Call 3.0 subscription", Status: CheckPass, Detail: "active"}
+
+	if keyErr != nil {
+		key.Status = CheckFail
+		key.Detail = checkErrorDetail(keyErr)
+		switch openWeatherStatus(keyErr) {
+		case http.StatusUnauthorized:
+			key.Hint = "Check [apis] openweather. New keys can take up to two hours to activate."
+		case http.StatusTooManyRequests:
+			key.Status = CheckWarn
+			key.Hint = "The key works but is rate limited; try again in a minute."
+		default:
+			key.Hint = "Check network access to api.openweathermap.org."
+		}
+		oneCall.Status = CheckSkip
+		oneCall.Detail = "needs a working API key"
+		return []CheckResult{key, oneCall}
+	}
+
+	if oneCallErr != nil {
+		oneCall.Status = CheckFail
+		oneCall.Detail = checkErrorDetail(oneCallErr)
+		switch openWeatherStatus(oneCallErr) {
+		case http.StatusUnauthorized:
+			oneCall.Detail = "the key works, but One Call 3.0 is not enabled for this account"
+			oneCall.Hint = "Subscribe to \"One Call by Call\" at https://openweathermap.org/api/one-call-3 (1,000 free calls/day); activation can take a few minutes."
+		case http.StatusTooManyRequests:
+			oneCall.Status = CheckWarn
+			oneCall.Hint = "Daily or per-minute call limit reached; raise the limit in your OpenWeather billing plan."
+		default:
+			oneCall.Hint = "Check network access to api.openweathermap.org."
+		}
+	}
+	return []CheckResult{key, oneCall}
+}
+
+// checkErrorDetail shortens an error for the check table. Transport errors drop
+// the request URL, which carries the OpenWeather key as a query parameter.
+func checkErrorDetail(err error) string {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err.Error()
+	}
+	var elevenLabsErr *ElevenLabsAPIError
+	if errors.As(err, &elevenLabsErr) {
+		if elevenLabsErr.StatusCode == 0 {
+			return elevenLabsErr.Message
+		}
+		return fmt.Sprintf("status %d: %s", elevenLabsErr.StatusCode, elevenLabsErr.Message)
+	}
+	return err.Error()
+}
+
+// openWeatherStatus returns the HTTP status of an OpenWeather API error, or 0
+func openWeatherStatus(err error) int {
+	var apiErr *OpenWeatherAPIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// CheckModel confirms the Claude API key and that the configured model ID exists
+// using the models endpoint, which does not generate tokens
+func (c *ClaudeClient) CheckModel(ctx context.Context) []CheckResult {
+	key := CheckResult{Service: "Claude", Check: "API key", Status: CheckPass, Detail: "accepted"}
+	model := CheckResult{Service: "Claude", Check: "Model " + c.config.Model, Status: CheckPass}
+
+	info, err := c.client.Models.Get(ctx, c.config.Model, anthropic.ModelGetParams{})
+	if err == nil {
+		model.Detail = info.DisplayName
+		return []CheckResult{key, model}
+	}
+
+	var apiErr *anthropic.Error
+	status := 0
+	if errors.As(err, &apiErr) {
+		status = apiErr.StatusCode
+	}
+
+	switch status {
+	case http.StatusNotFound:
+		model.Status = CheckFail
+		model.Detail = "model not found"
+		model.Hint = "Set [claude] model to an available model ID"
+		if available := c.availableModels(ctx); len(available) > 0 {
+			model.Hint += ": " + strings.Join(available, ", ")
+		}
+	case http.StatusUnauthorized, http.StatusForbidden:
+		key.Status = CheckFail
+		key.Detail = fmt.Sprintf("API key rejected (status %d)", status)
+		key.Hint = "Check [apis] anthropic; create a key at https://console.anthropic.com/settings/keys."
+		model.Status = CheckSkip
+		model.Detail = "needs a working API key"
+	default:
+		key.Status = CheckFail
+		key.Detail = checkErrorDetail(err)
+		key.Hint = "Check network access to api.anthropic.com."
+		model.Status = CheckSkip
+		model.Detail = "needs a working API key"
+	}
+	return []CheckResult{key, model}
+}
+
+// availableModels lists model IDs for remediation hints (best effort)
+func (c *ClaudeClient) availableModels(ctx context.Context) []string {
+	page, err := c.client.Models.List(ctx, anthropic.ModelListParams{})
+	if err != nil {
+		return nil
+	}
+	ids := make([]string, 0, len(page.Data))
+	for _, model := range page.Data {
+		ids = append(ids, model.ID)
+	}
+	return ids
+}
+
+// elevenLabsSubscription is the quota part of the ElevenLabs subscription response
+type elevenLabsSubscription struct {
+	Tier                        string `json:"tier"`
+	CharacterCount              int    `json:"character_count"`
+	CharacterLimit              int    `json:"character_limit"`
+	NextCharacterCountResetUnix int64  `json:"next_character_count_reset_unix"`
+}
+
+// elevenLabsModel is an entry of the ElevenLabs models response
+type elevenLabsModel struct {
+	ModelID           string `json:"model_id"`
+	CanDoTextToSpeech bool   `json:"can_do_text_to_speech"`
+}
+
+// CheckCredentials confirms the ElevenLabs key, remaining character quota, and
+// that the configured voice and model exist
+func (c *ElevenLabsClient) CheckCredentials(ctx context.Context) []CheckResult {
+	key := CheckResult{Service: "ElevenLabs", Check: "API key", Status: CheckPass, Detail: "accepted"}
+	quota := CheckResult{Service: "ElevenLabs", Check: "Character quota", Status: CheckPass}
+	voice := CheckResult{Service: "ElevenLabs", Check: "Voice " + c.config.VoiceID, Status: CheckPass}
+	model := CheckResult{Service: "ElevenLabs", Check: "Model " + c.config.Model, Status: CheckPass}
+
+	var subscription elevenLabsSubscription
+	err := c.getJSON(ctx, "/v1/user/subscription", &subscription)
+	switch {
+	case err == nil:
+		remaining := subscription.CharacterLimit - subscription.CharacterCount
+		quota.Detail = fmt.Sprintf("%d of %d characters left", remaining, subscription.CharacterLimit)
+		if subscription.NextCharacterCountResetUnix > 0 {
+			quota.Detail += fmt.Sprintf(" (resets %s)", time.Unix(subscription.NextCharacterCountResetUnix, 0).Format("Jan 2"))
+		}
+		if remaining <= 0 {
+			quota.Status = CheckFail
+			quota.Hint = "Quota used up; upgrade the ElevenLabs plan or wait for the reset."
+		} else if remaining < lowQuotaCharacters {
+			quota.Status = CheckWarn
+			quota.Hint = "Only a few reports left this period; consider upgrading the ElevenLabs plan."
+		}
+	case isMissingPermission(err):
+		// Keys scoped without user_read still synthesize speech
+		quota.Status = CheckWarn
+		quota.Detail = "key lacks the user_read permission"
+		quota.Hint = "Enable \"User: Read\" on the API key to see remaining quota."
+	case elevenLabsStatus(err) == http.StatusUnauthorized:
+		key.Status = CheckFail
+		key.Detail = "API key rejected"
+		key.Hint = "Check [apis] elevenlabs; keys are listed at https://elevenlabs.io/app/settings/api-keys."
+		for _, skipped := range []*CheckResult{&quota, &voice, &model} {
+			skipped.Status = CheckSkip
+			skipped.Detail = "needs a working API key"
+		}
+		return []CheckResult{key, quota, voice, model}
+	default:
+		key.Status = CheckFail
+		key.Detail = checkErrorDetail(err)
+		key.Hint = "Check network access to api.elevenlabs.io."
+		for _, skipped := range []*CheckResult{&quota, &voice, &model} {
+			skipped.Status = CheckSkip
+			skipped.Detail = "needs a working API key"
+		}
+		return []CheckResult{key, quota, voice, model}
+	}
+
+	var voiceInfo Voice
+	if err := c.getJSON(ctx, "/v1/voices/"+c.config.VoiceID, &voiceInfo); err != nil {
+		voice.Status = CheckFail
+		voice.Detail = "voice not found"
+		if status := elevenLabsStatus(err); status != http.StatusNotFound && status != http.StatusBadRequest {
+			voice.Detail = checkErrorDetail(err)
+		}
+		voice.Hint = "Set [elevenlabs] voice_id to an ID from 'myrcast voices list'."
+	} else {
+		voice.Detail = voiceInfo.Name
+	}
+
+	var models []elevenLabsModel
+	if err := c.getJSON(ctx, "/v1/models", &models); err != nil {
+		model.Status = CheckWarn
+		model.Detail = checkErrorDetail(err)
+		model.Hint = "Could not list models; the model ID was not verified."
+	} else {
+		model.Status = CheckFail
+		model.Detail = "model not found"
+		var ttsModels []string
+		for _, m := range models {
+			if m.CanDoTextToSpeech {
+				ttsModels = append(ttsModels, m.ModelID)
+			}
+			if m.ModelID == c.config.Model {
+				model.Status = CheckPass
+				model.Detail = "available"
+				if !m.CanDoTextToSpeech {
+					model.Status = CheckFail
+					model.Detail = "model does not support text-to-speech"
+				}
+			}
+		}
+		if model.Status == CheckFail {
+			model.Hint = "Set [elevenlabs] model to one of: " + strings.Join(ttsModels, ", ")
+		}
+	}
+
+	return []CheckResult{key, quota, voice, model}
+}
+
+// elevenLabsStatus returns the HTTP status of an ElevenLabs API error, or 0
+func elevenLabsStatus(err error) int {
+	var apiErr *ElevenLabsAPIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// isMissingPermission reports whether ElevenLabs refused a request because the
+// key is scoped without the needed permission (as opposed to being invalid)
+func isMissingPermission(err error) bool {
+	var apiErr *ElevenLabsAPIError
+	return errors.As(err, &apiErr) && strings.Contains(apiErr.Message, "missing_permissions")
+}
@@ -0,0 +1,263 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestOpenWeatherCheckResults tests classification of OpenWeather key and One Call errors
+func TestOpenWeatherCheckResults(t *testing.T) {
+	unauthorized := &OpenWeatherAPIError{StatusCode: 401, Code: 401, Message: "Invalid API key"}
+	limited := &OpenWeatherAPIError{StatusCode: 429, Code: 429, Message: "rate limited"}
+
+	tests := []struct {
+		name        string
+		keyErr      error
+		oneCallErr  error
+		wantKey     CheckStatus
+		wantOneCall CheckStatus
+		wantHint    string
+	}{
+		{"all good", nil, nil, CheckPass, CheckPass, ""},
+		{"bad key", unauthorized, nil, CheckFail, CheckSkip, "two hours"},
+		{"key rate limited", limited, nil, CheckWarn, CheckSkip, "rate limited"},
+		{"network error", errors.New("dial tcp: timeout"), nil, CheckFail, CheckSkip, "network"},
+		{"no One Call subscription", nil, unauthorized, CheckPass, CheckFail, "One Call by Call"},
+		{"One Call limit reached", nil, limited, CheckPass, CheckWarn, "call limit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := openWeatherCheckResults(tt.keyErr, tt.oneCallErr)
+			if len(results) != 2 {
+				t.Fatalf("Expected 2 results, got %d", len(results))
+			}
+			if results[0].Status != tt.wantKey {
+				t.Errorf("Expected key status %s, got %s", tt.wantKey, results[0].Status)
+			}
+			if results[1].Status != tt.wantOneCall {
+				t.Errorf("Expected One Call status %s, got %s", tt.wantOneCall, results[1].Status)
+			}
+			hints := results[0].Hint + results[1].Hint
+			if tt.wantHint == "" && hints != "" {
+				t.Errorf("Expected no hints, got %q", hints)
+			}
+			if !strings.Contains(hints, tt.wantHint) {
+				t.Errorf("Expected hint containing %q, got %q", tt.wantHint, hints)
+			}
+		})
+	}
+}
+
+// TestElevenLabsCheckCredentials tests the ElevenLabs key, quota, voice and model checks
+func TestElevenLabsCheckCredentials(t *testing.T) {
+	tests := []struct {
+		name         string
+		subscription func(w http.ResponseWriter)
+		voiceFound   bool
+		model        string
+		want         []CheckStatus
+		wantHint     string
+	}{
+		{
+			name: "all good",
+			subscription: func(w http.ResponseWriter) {
+				fmt.Fprint(w, `{"tier":"starter","character_count":1000,"character_limit":30000}`)
+			},
+			voiceFound: true,
+			model:      "eleven_multilingual_v2",
+			want:       []CheckStatus{CheckPass, CheckPass, CheckPass, CheckPass},
+		},
+		{
+			name: "quota nearly used",
+			subscription: func(w http.ResponseWriter) {
+				fmt.Fprint(w, `{"tier":"free","character_count":9500,"character_limit":10000}`)
+			},
+			voiceFound: true,
+			model:      "eleven_multilingual_v2",
+			want:       []CheckStatus{CheckPass, CheckWarn, CheckPass, CheckPass},
+			wantHint:   "upgrading",
+		},
+		{
+			name: "quota used up",
+			subscription: func(w http.ResponseWriter) {
+				fmt.Fprint(w, `{"tier":"free","character_count":10000,"character_limit":10000}`)
+			},
+			voiceFound: true,
+			model:      "eleven_multilingual_v2",
+			want:       []CheckStatus{CheckPass, CheckFail, CheckPass, CheckPass},
+			wantHint:   "used up",
+		},
+		{
+			name: "key without user_read",
+			subscription: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusUnauthorized)
+				fmt.Fprint(w, `{"detail":{"status":"missing_permissions","message":"missing user_read"}}`)
+			},
+			voiceFound: true,
+			model:      "eleven_multilingual_v2",
+			want:       []CheckStatus{CheckPass, CheckWarn, CheckPass, CheckPass},
+			wantHint:   "User: Read",
+		},
+		{
+			name: "invalid key",
+			subscription: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusUnauthorized)
+				fmt.Fprint(w, `{"detail":{"status":"invalid_api_key","message":"Invalid API key"}}`)
+			},
+			want:     []CheckStatus{CheckFail, CheckSkip, CheckSkip, CheckSkip},
+			wantHint: "[apis] elevenlabs",
+		},
+		{
+			name: "unknown voice",
+			subscription: func(w http.ResponseWriter) {
+				fmt.Fprint(w, `{"character_count":0,"character_limit":10000}`)
+			},
+			voiceFound: false,
+			model:      "eleven_multilingual_v2",
+			want:       []CheckStatus{CheckPass, CheckPass, CheckFail, CheckPass},
+			wantHint:   "myrcast voices list",
+		},
+		{
+			name: "unknown model",
+			subscription: func(w http.ResponseWriter) {
+				fmt.Fprint(w, `{"character_count":0,"character_limit":10000}`)
+			},
+			voiceFound: true,
+			model:      "eleven_typo_v9",
+			want:       []CheckStatus{CheckPass, CheckPass, CheckPass, CheckFail},
+			wantHint:   "eleven_multilingual_v2",
+		},
+		{
+			name: "model without text-to-speech",
+			subscription: func(w http.ResponseWriter) {
+				fmt.Fprint(w, `{"character_count":0,"character_limit":10000}`)
+			},
+			voiceFound: true,
+			model:      "eleven_english_sts_v2",
+			want:       []CheckStatus{CheckPass, CheckPass, CheckPass, CheckFail},
+			wantHint:   "eleven_multilingual_v2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("xi-api-key") != "test-api-key" {
+					t.Errorf("Expected xi-api-key header on %s", r.URL.Path)
+				}
+				switch r.URL.Path {
+				case "/v1/user/subscription":
+					tt.subscription(w)
+				case "/v1/voices/voice123":
+					if !tt.voiceFound {
+						w.WriteHeader(http.StatusNotFound)
+						fmt.Fprint(w, `{"detail":{"status":"voice_not_found"}}`)
+						return
+					}
+					fmt.Fprint(w, `{"voice_id":"voice123","name":"Adam"}`)
+				case "/v1/models":
+					fmt.Fprint(w, `[{"model_id":"eleven_multilingual_v2","can_do_text_to_speech":true},`+
+						`{"model_id":"eleven_english_sts_v2","can_do_text_to_speech":false}]`)
+				default:
+					t.Errorf("Unexpected request to %s", r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			client, err := NewElevenLabsClient(ElevenLabsConfig{
+				APIKey:  "test-api-key",
+				VoiceID: "voice123",
+				Model:   tt.model,
+				BaseURL: server.URL,
+			})
+			if err != nil {
+				t.Fatalf("Failed to create ElevenLabs client: %v", err)
+			}
+
+			results := client.CheckCredentials(context.Background())
+			if len(results) != len(tt.want) {
+				t.Fatalf("Expected %d results, got %d", len(tt.want), len(results))
+			}
+			var hints []string
+			for i, result := range results {
+				if result.Status != tt.want[i] {
+					t.Errorf("%s: expected %s, got %s (%s)", result.Check, tt.want[i], result.Status, result.Detail)
+				}
+				hints = append(hints, result.Hint)
+			}
+			if !strings.Contains(strings.Join(hints, "\n"), tt.wantHint) {
+				t.Errorf("Expected a hint containing %q, got %q", tt.wantHint, hints)
+			}
+		})
+	}
+}
+
+// TestClaudeCheckModel tests the Claude key and model checks against the models endpoint
+func TestClaudeCheckModel(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		want     []CheckStatus
+		wantHint string
+	}{
+		{"model exists", http.StatusOK, []CheckStatus{CheckPass, CheckPass}, ""},
+		{"unknown model", http.StatusNotFound, []CheckStatus{CheckPass, CheckFail}, "claude-sonnet-4-0"},
+		{"invalid key", http.StatusUnauthorized, []CheckStatus{CheckFail, CheckSkip}, "[apis] anthropic"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch r.URL.Path {
+				case "/v1/models/claude-typo":
+					w.WriteHeader(tt.status)
+					switch tt.status {
+					case http.StatusOK:
+						fmt.Fprint(w, `{"id":"claude-typo","display_name":"Claude Typo","type":"model","created_at":"2025-01-01T00:00:00Z"}`)
+					case http.StatusNotFound:
+						fmt.Fprint(w, `{"type":"error","error":{"type":"not_found_error","message":"model: claude-typo"}}`)
+					default:
+						fmt.Fprint(w, `{"type":"error","error":{"type":"authentication_error","message":"invalid x-api-key"}}`)
+					}
+				case "/v1/models":
+					fmt.Fprint(w, `{"data":[{"id":"claude-sonnet-4-0","display_name":"Claude Sonnet 4","type":"model","created_at":"2025-05-22T00:00:00Z"}],"has_more":false}`)
+				default:
+					t.Errorf("Unexpected request to %s", r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			client, err := NewClaudeClient(ClaudeConfig{
+				APIKey:  "test-api-key",
+				Model:   "claude-typo",
+				BaseURL: server.URL,
+			})
+			if err != nil {
+				t.Fatalf("Failed to create Claude client: %v", err)
+			}
+
+			results := client.CheckModel(context.Background())
+			if len(results) != len(tt.want) {
+				t.Fatalf("Expected %d results, got %d", len(tt.want), len(results))
+			}
+			for i, result := range results {
+				if result.Status != tt.want[i] {
+					t.Errorf("%s: expected %s, got %s (%s)", result.Check, tt.want[i], result.Status, result.Detail)
+				}
+			}
+			hints := results[0].Hint + results[1].Hint
+			if !strings.Contains(hints, tt.wantHint) {
+				t.Errorf("Expected a hint containing %q, got %q", tt.wantHint, hints)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
@@ -14,6 +15,7 @@ import (
 	"github.com/anthropics/anthropic-sdk-go"
 	"github.com/anthropics/anthropic-sdk-go/option"
 	"myrcast/internal/logger"
+	"myrcast/internal/runstats"
 )
 
 const (
@@ -50,7 +52,11 @@ type ClaudeConfig struct {
 	MaxRetries  int
 	BaseDelay   time.Duration
 	MaxDelay    time.Duration
-	RateLimit   int // requests per minute
+	RateLimit   int           // requests per minute
+	NotesRules  *NotesRuleSet // Broadcast notes rules (nil uses the built-in rules)
+	Notes       NotesOptions  // Calendar, latitude and season mode for broadcast notes
+	Clock       Clock         // Time source for date context (nil uses the system clock)
+	BaseURL     string        // API root override (empty uses the SDK default)
 }
 
 // ClaudeRateLimiter handles rate limiting for Claude API requests
@@ -166,9 +172,14 @@ func NewClaudeClient(config ClaudeConfig) (*ClaudeClient, error) {
 	}
 
 	// Create Anthropic client with API key
-	client := anthropic.NewClient(
+	options := []option.RequestOption{
 		option.WithAPIKey(config.APIKey),
-	)
+		option.WithHeader(logger.RunIDHeader, logger.RunID()),
+	}
+	if config.BaseURL != "" {
+		options = append(options, option.WithBaseURL(config.BaseURL))
+	}
+	client := anthropic.NewClient(options...)
 
 	// Create rate limiter
 	rateLimiter := NewClaudeRateLimiter(config.RateLimit)
@@ -277,6 +288,7 @@ func (c *ClaudeClient) GenerateWeatherReport(ctx context.Context, request Weathe
 	}
 
 	complete(nil)
+	runstats.AddTokens(int(resp.Usage.InputTokens), int(resp.Usage.OutputTokens))
 
 	// Log the generated script to results.log
 	if err := c.appendScriptToLog(request, script); err != nil {
@@ -318,6 +330,10 @@ func (c *ClaudeClient) executeWithRetry(ctx context.Context, messageReq anthropi
 		cancel() // Clean up timeout context
 
 		if err != nil {
+			var apiErr *anthropic.Error
+			if errors.As(err, &apiErr) {
+				runstats.HTTPStatus(runstats.ProviderClaude, apiErr.StatusCode)
+			}
 			lastErr = err
 			claudeErr := c.parseClaudeError(err)
 
@@ -335,6 +351,7 @@ func (c *ClaudeClient) executeWithRetry(ctx context.Context, messageReq anthropi
 
 			// Calculate delay for next retry with exponential backoff and jitter
 			delay := c.calculateRetryDelay(attempt)
+			runstats.Retry(runstats.ProviderClaude)
 
 			logger.LogWithFields(logger.WarnLevel, "Claude API request failed, retrying", map[string]any{
 				"error":        err.Error(),
@@ -353,6 +370,7 @@ func (c *ClaudeClient) executeWithRetry(ctx context.Context, messageReq anthropi
 		}
 
 		// Success!
+		runstats.HTTPStatus(runstats.ProviderClaude, http.StatusOK)
 		if attempt > 0 {
 			logger.LogWithFields(logger.DebugLevel, "Claude API request succeeded after retries", map[string]any{
 				"successful_attempt": attempt + 1,
@@ -495,6 +513,9 @@ func (c *ClaudeClient) formatWeatherContextFromExtracted(todayData *TodayWeather
 		return "", fmt.Errorf("today data is nil")
 	}
 
+	// All date context is in the forecast location's zone, not the host's
+	now := todayData.LocalTime(clockOrSystem(c.config.Clock).Now())
+
 	// Build structured context for Claude
 	var context strings.Builder
 
@@ -506,7 +527,7 @@ func (c *ClaudeClient) formatWeatherContextFromExtracted(todayData *TodayWeather
 
 	// Current conditions section
 	context.WriteString("CURRENT CONDITIONS:\n")
-	context.WriteString(fmt.Sprintf("- Today is %s\n", time.Now().Format("Monday, January 2 at 3:04 PM")))
+	context.WriteString(fmt.Sprintf("- Today is %s\n", now.Format("Monday, January 2 at 3:04 PM")))
 	context.WriteString(fmt.Sprintf("- Temperature: %.0f%s\n", todayData.CurrentTemp, getTemperatureUnit(todayData.Units)))
 	context.WriteString(fmt.Sprintf("- Conditions: %s\n", todayData.CurrentConditions))
 	context.WriteString(fmt.Sprintf("- Wind: %s\n", todayData.WindConditions))
@@ -518,11 +539,15 @@ func (c *ClaudeClient) formatWeatherContextFromExtracted(todayData *TodayWeather
 	context.WriteString(fmt.Sprintf("- Low temperature: %.0f%s\n", todayData.TempLow, getTemperatureUnit(todayData.Units)))
 	context.WriteString(fmt.Sprintf("- Precipitation chance: %.0f%%\n", todayData.RainChance*100))
 
-	// Temperature trend analysis
+	// Temperature trend analysis, with the °F thresholds converted to the report's units
+	units := todayData.Units
+	if units == "" {
+		units = "imperial"
+	}
 	tempRange := todayData.TempHigh - todayData.TempLow
-	if tempRange > 20 {
+	if tempRange > ConvertTemperatureDifference(20, "imperial", units) {
 		context.WriteString("- Temperature trend: Wide temperature range expected\n")
-	} else if tempRange < 10 {
+	} else if tempRange < ConvertTemperatureDifference(10, "imperial", units) {
 		context.WriteString("- Temperature trend: Stable temperatures throughout the day\n")
 	} else {
 		context.WriteString("- Temperature trend: Moderate temperature variation\n")
@@ -542,8 +567,10 @@ func (c *ClaudeClient) formatWeatherContextFromExtracted(todayData *TodayWeather
 	context.WriteString("BROADCAST NOTES:\n")
 
 	// Add comprehensive contextual information
-	now := time.Now()
-	contextualNotes := c.generateContextualBroadcastNotes(todayData, now)
+	contextualNotes, err := c.generateContextualBroadcastNotes(todayData, now)
+	if err != nil {
+		return "", fmt.Errorf("failed to evaluate broadcast notes: %w", err)
+	}
 	for _, note := range contextualNotes {
 		context.WriteString(fmt.Sprintf("- %s\n", note))
 	}
@@ -553,326 +580,22 @@ func (c *ClaudeClient) formatWeatherContextFromExtracted(todayData *TodayWeather
 
 // generateContextualBroadcastNotes creates comprehensive contextual broadcast notes
 // based on current time, weather conditions, and seasonal/holiday awareness
-func (c *ClaudeClient) generateContextualBroadcastNotes(todayData *TodayWeatherData, now time.Time) []string {
-	var notes []string
-
-	// Time of day context
-	hour := now.Hour()
-	timeOfDay := c.getTimeOfDay(now)
-	notes = append(notes, fmt.Sprintf("Broadcast time of day: %s", timeOfDay))
-
-	// Radio broadcast guidance
-	notes = append(notes, "Use radio-friendly tone: conversational, clear, fun, and very engaging")
-
-	// Day of week context
-	weekday := now.Weekday()
-	isWeekend := weekday == time.Saturday || weekday == time.Sunday
-	if isWeekend {
-		notes = append(notes, "Weekend broadcast: consider more relaxed, leisure-focused tone")
-	} else {
-		switch {
-		case hour >= 6 && hour < 9:
-			notes = append(notes, "Morning commute time: focus on travel conditions and daily planning")
-		case hour >= 17 && hour < 19:
-			notes = append(notes, "Evening commute time: emphasize evening and tomorrow's outlook")
-		case hour >= 9 && hour < 17:
-			notes = append(notes, "Business hours: consider more brief and informative")
-		default:
-			notes = append(notes, "Off-peak hours: consider more conversational, detailed approach")
-		}
-	}
-
-	// Season and time of year context
-	season := c.getSeason(now)
-	notes = append(notes, fmt.Sprintf("Season: %s - tailor weather discussion for seasonal relevance", season))
-
-	// Seasonal weather emphasis
-	switch season {
-	case "winter":
-		if todayData.CurrentTemp < 32 { // Below freezing
-			notes = append(notes, "Cold weather alert - emphasize warming layers, ice/snow conditions")
-		}
-		notes = append(notes, "Winter season - mention heating costs, winter activity fun, holiday travel if applicable")
-	case "spring":
-		notes = append(notes, "Spring season - focus on changing conditions, outdoor activities starting")
-		if todayData.RainChance > 0.3 {
-			notes = append(notes, "Spring rain - mention gardening, growth, renewal themes")
-		}
-	case "summer":
-		if todayData.TempHigh > 85 || todayData.CurrentTemp > 85 {
-			notes = append(notes, "Hot day - emphasize hydration, cooling, outdoor safety")
-		}
-		notes = append(notes, "Summer season - highlight outdoor events, vacation weather, beach/pool conditions, time-off from work")
-	case "fall":
-		notes = append(notes, "Fall season - mention changing leaves, back-to-school, harvest themes")
-		if todayData.WindConditions != "" && strings.Contains(strings.ToLower(todayData.WindConditions), "wind") {
-			notes = append(notes, "Fall winds - good opportunity for autumn weather imagery")
-		}
-	}
-
-	// Holiday awareness
-	if c.isHoliday(now) {
-		holidayName := c.getHolidayName(now)
-		if holidayName != "" {
-			notes = append(notes, fmt.Sprintf("Holiday broadcast for %s - incorporate festive elements, travel considerations", holidayName))
-		} else {
-			notes = append(notes, "Holiday period - consider festive tone and travel weather impacts where appropriate")
-		}
-	}
-
-	// Weather-specific contextual notes
-	if todayData.RainChance > 0.8 {
-		notes = append(notes, "Rain is very likely - emphasize rain gear, indoor alternatives like listening to music")
-	} else if todayData.RainChance > 0.7 {
-		notes = append(notes, "Rain is likely - emphasize rain gear, indoor alternatives like listening to music")
-	} else if todayData.RainChance > 0.5 {
-		notes = append(notes, "High rain probability - emphasize spinkles, indoor alternatives")
-	} else if todayData.RainChance < 0.3 {
-		notes = append(notes, "Rain is unlikely - good day for outdoor activities in a seasonal context")
-	}
-
-	// Temperature-specific guidance (regardless of season)
-	hotThreshold := 85.0  // Fahrenheit
-	coldThreshold := 32.0 // Fahrenheit
-	coolThreshold := 50.0 // Fahrenheit
-
-	if todayData.Units == "metric" {
-		hotThreshold = 29.0  // ~85°F in Celsius
-		coldThreshold = 0.0  // Freezing in Celsius
-		coolThreshold = 10.0 // ~50°F in Celsius
-	}
-
-	if todayData.TempHigh > hotThreshold || todayData.CurrentTemp > hotThreshold {
-		notes = append(notes, "Hot day - emphasize hydration, cooling, outdoor safety")
-	} else if todayData.TempHigh < coldThreshold || todayData.CurrentTemp < coldThreshold {
-		notes = append(notes, "Freezing temperatures - mention cold weather precautions")
-	} else if todayData.TempHigh < coolThreshold {
-		notes = append(notes, "Cool day - suggest layered clothing")
-	}
-
-	if todayData.CurrentTemp != 0 && todayData.TempHigh != 0 {
-		tempRange := todayData.TempHigh - todayData.TempLow
-		if tempRange > 25 {
-			notes = append(notes, "Large temperature swing - mention layering clothes, changing conditions throughout day")
-		}
-	}
-
-	// Wind conditions context
-	if todayData.WindConditions != "" {
-		windLower := strings.ToLower(todayData.WindConditions)
-		if strings.Contains(windLower, "strong") || strings.Contains(windLower, "high") {
-			notes = append(notes, "Strong winds - mention outdoor activity impacts, potential power/tree concerns")
-		}
-	}
-
-	// Special weather alerts context
-	if len(todayData.WeatherAlerts) > 0 {
-		notes = append(notes, "Weather alerts active - maintain serious, informative tone while being reassuring")
-	}
-
-	// Location-specific notes (Seattle context from config)
-	cityLocation := strings.ToLower(todayData.Location)
-	if strings.Contains(cityLocation, "seattle") || strings.Contains(cityLocation, "47.6") {
-		notes = append(notes, "Seattle area - reference local landmarks, ferry conditions, mountain visibility if clear")
-		if strings.Contains(strings.ToLower(todayData.CurrentConditions), "clear") ||
-			strings.Contains(strings.ToLower(todayData.CurrentConditions), "sunny") {
-			notes = append(notes, "Clear Seattle weather - rare treat! Mention mountain views, outdoor opportunities")
-		}
-	}
-
-	// Broadcast timing urgency
-	if c.isUrgentTime(now) {
-		notes = append(notes, "Peak listening time! Prioritize essential information, keep engaging and fun but concise")
-	}
-
-	return notes
-}
-
-// Helper functions for contextual broadcast notes
-
-func (c *ClaudeClient) getTimeOfDay(t time.Time) string {
-	hour := t.Hour()
-	switch {
-	case hour >= 5 && hour < 12:
-		return "morning"
-	case hour >= 12 && hour < 17:
-		return "afternoon"
-	case hour >= 17 && hour < 22:
-		return "evening"
-	default:
-		return "night"
-	}
-}
-
-func (c *ClaudeClient) getSeason(t time.Time) string {
-	month := t.Month()
-	switch {
-	case month >= 3 && month <= 5:
-		return "spring"
-	case month >= 6 && month <= 8:
-		return "summer"
-	case month >= 9 && month <= 11:
-		return "fall"
-	default:
-		return "winter"
-	}
-}
-
-func (c *ClaudeClient) isHoliday(t time.Time) bool {
-	return c.checkUSHolidays(t) || c.checkInternationalHolidays(t)
-}
-
-func (c *ClaudeClient) getHolidayName(t time.Time) string {
-	month := t.Month()
-	day := t.Day()
-
-	// Major fixed holidays
-	switch {
-	case month == time.January && day == 1:
-		return "New Year's Day"
-	case month == time.February && day == 14:
-		return "Valentine's Day"
-	case month == time.March && day == 17:
-		return "St. Patrick's Day"
-	case month == time.July && day == 4:
-		return "Independence Day"
-	case month == time.October && day == 31:
-		return "Halloween"
-	case month == time.November && day == 11:
-		return "Veterans Day"
-	case month == time.December && day == 25:
-		return "Christmas Day"
-	case month == time.December && day == 31:
-		return "New Year's Eve"
-	}
-
-	// Variable holidays (simplified check)
-	if month == time.November && c.isNthWeekdayOfMonth(t, 4, time.Thursday) {
-		return "Thanksgiving"
-	}
-	if month == time.May && c.isLastWeekdayOfMonth(t, time.Monday) {
-		return "Memorial Day"
-	}
-	if month == time.September && c.isNthWeekdayOfMonth(t, 1, time.Monday) {
-		return "Labor Day"
-	}
-
-	return ""
-}
-
-func (c *ClaudeClient) checkUSHolidays(t time.Time) bool {
-	month := t.Month()
-	day := t.Day()
-
-	// Fixed holidays
-	fixedHolidays := map[time.Month][]int{
-		time.January:  {1},      // New Year's Day
-		time.February: {14},     // Valentine's Day
-		time.March:    {17},     // St. Patrick's Day
-		time.July:     {4},      // Independence Day
-		time.October:  {31},     // Halloween
-		time.November: {11},     // Veterans Day
-		time.December: {25, 31}, // Christmas Day, New Year's Eve
-	}
-
-	if days, exists := fixedHolidays[month]; exists {
-		for _, holidayDay := range days {
-			if day == holidayDay {
-				return true
-			}
-		}
-	}
-
-	// Variable holidays
-	switch month {
-	case time.January:
-		return c.isNthWeekdayOfMonth(t, 3, time.Monday) // MLK Day
-	case time.February:
-		return c.isNthWeekdayOfMonth(t, 3, time.Monday) // Presidents' Day
-	case time.May:
-		return c.isLastWeekdayOfMonth(t, time.Monday) || c.isNthWeekdayOfMonth(t, 2, time.Sunday) // Memorial Day, Mother's Day
-	case time.June:
-		return c.isNthWeekdayOfMonth(t, 3, time.Sunday) // Father's Day
-	case time.September:
-		return c.isNthWeekdayOfMonth(t, 1, time.Monday) // Labor Day
-	case time.October:
-		return c.isNthWeekdayOfMonth(t, 2, time.Monday) // Columbus Day
-	case time.November:
-		return c.isNthWeekdayOfMonth(t, 4, time.Thursday) // Thanksgiving
-	}
-
-	return false
-}
-
-func (c *ClaudeClient) checkInternationalHolidays(t time.Time) bool {
-	month := t.Month()
-	day := t.Day()
-
-	internationalHolidays := map[time.Month][]int{
-		time.January:  {1},  // New Year's Day
-		time.February: {14}, // Valentine's Day
-		time.March:    {8},  // International Women's Day
-		time.April:    {22}, // Earth Day
-		time.May:      {1},  // International Workers' Day
-		time.October:  {31}, // Halloween
-		time.December: {25}, // Christmas Day
-	}
-
-	if days, exists := internationalHolidays[month]; exists {
-		for _, holidayDay := range days {
-			if day == holidayDay {
-				return true
-			}
+func (c *ClaudeClient) generateContextualBroadcastNotes(todayData *TodayWeatherData, now time.Time) ([]string, error) {
+	ruleSet := c.config.NotesRules
+	if ruleSet == nil {
+		var err error
+		ruleSet, err = DefaultNotesRuleSet()
+		if err != nil {
+			return nil, err
 		}
 	}
 
-	return false
-}
-
-func (c *ClaudeClient) isNthWeekdayOfMonth(t time.Time, n int, weekday time.Weekday) bool {
-	if t.Weekday() != weekday {
-		return false
-	}
-
-	firstOfMonth := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
-	firstWeekday := firstOfMonth
-	for firstWeekday.Weekday() != weekday {
-		firstWeekday = firstWeekday.AddDate(0, 0, 1)
-	}
-
-	nthWeekday := firstWeekday.AddDate(0, 0, (n-1)*7)
-	return t.Day() == nthWeekday.Day() && t.Month() == nthWeekday.Month()
-}
-
-func (c *ClaudeClient) isLastWeekdayOfMonth(t time.Time, weekday time.Weekday) bool {
-	if t.Weekday() != weekday {
-		return false
-	}
-
-	nextMonth := time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
-	lastOfMonth := nextMonth.AddDate(0, 0, -1)
-
-	lastWeekday := lastOfMonth
-	for lastWeekday.Weekday() != weekday {
-		lastWeekday = lastWeekday.AddDate(0, 0, -1)
-	}
-
-	return t.Day() == lastWeekday.Day()
-}
-
-func (c *ClaudeClient) isUrgentTime(t time.Time) bool {
-	hour := t.Hour()
-	weekday := t.Weekday()
-
-	// Business hours on weekdays are less urgent than off-hours
-	if weekday >= time.Monday && weekday <= time.Friday {
-		// Morning/evening commute times are urgent
-		if (hour >= 6 && hour < 9) || (hour >= 17 && hour < 19) {
-			return true
-		}
+	notesContext, err := NewNotesContext(todayData, now, c.config.Notes)
+	if err != nil {
+		return nil, err
 	}
 
-	return false
+	return ruleSet.Evaluate(notesContext), nil
 }
 
 // extractWeatherVariables converts weather forecast data into template variables
@@ -953,6 +676,7 @@ func (c *ClaudeClient) logPromptToFile(request WeatherReportRequest, weatherCont
 	logEntry := fmt.Sprintf(`
 === CLAUDE WEATHER REPORT GENERATION ===
 Timestamp: %s
+Run ID: %s
 Location: %s
 
 === WEATHER DATA (System Context) ===
@@ -968,7 +692,7 @@ Temperature: %.2f
 
 === END LOG ENTRY ===
 
-`, timestamp, request.Location, weatherContext, request.PromptTemplate,
+`, timestamp, logger.RunID(), request.Location, weatherContext, request.PromptTemplate,
 		string(messageReq.Model), messageReq.MaxTokens, messageReq.Temperature.Value)
 
 	// Overwrite log file each time
@@ -978,7 +702,7 @@ Temperature: %.2f
 	}
 	defer file.Close()
 
-	if _, err := file.WriteString(logEntry); err != nil {
+	if _, err := file.WriteString(logger.Redact(logEntry)); err != nil {
 		return fmt.Errorf("failed to write to results.log: %w", err)
 	}
 
@@ -1022,7 +746,7 @@ func (c *ClaudeClient) appendScriptToLog(request WeatherReportRequest, script st
 `, script)
 
 	// Insert the script section before the end marker
-	modifiedContent := strings.Replace(existingContent, endMarker, scriptSection+endMarker, 1)
+	modifiedContent := strings.Replace(existingContent, endMarker, logger.Redact(scriptSection)+endMarker, 1)
 
 	// Write the modified content back to the file
 	if err := os.WriteFile(logFilePath, []byte(modifiedContent), 0644); err != nil {
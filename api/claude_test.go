@@ -235,6 +235,47 @@ func TestFormatWeatherContextWithAlerts(t *testing.T) {
 	}
 }
 
+// TestFormatWeatherContextTemperatureTrend tests that the trend thresholds
+// follow the report's units
+func TestFormatWeatherContextTemperatureTrend(t *testing.T) {
+	tests := []struct {
+		name     string
+		units    string
+		high     float64
+		low      float64
+		expected string
+	}{
+		{name: "Imperial moderate", units: "imperial", high: 78, low: 65, expected: "Moderate temperature variation"},
+		{name: "Imperial wide", units: "imperial", high: 85, low: 60, expected: "Wide temperature range"},
+		{name: "Metric wide", units: "metric", high: 20, low: 8, expected: "Wide temperature range"},
+		{name: "Metric moderate", units: "metric", high: 20, low: 12, expected: "Moderate temperature variation"},
+		{name: "Metric stable", units: "metric", high: 20, low: 15, expected: "Stable temperatures"},
+		{name: "Kelvin wide", units: "kelvin", high: 293, low: 281, expected: "Wide temperature range"},
+	}
+
+	client := &ClaudeClient{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			todayData := &TodayWeatherData{
+				TempHigh:          tt.high,
+				TempLow:           tt.low,
+				CurrentTemp:       tt.high,
+				CurrentConditions: "clear sky",
+				WindConditions:    "Calm",
+				Units:             tt.units,
+				Location:          testLocation,
+			}
+			context, err := client.formatWeatherContextFromExtracted(todayData)
+			if err != nil {
+				t.Fatalf("Failed to format weather context: %v", err)
+			}
+			if !strings.Contains(context, "Temperature trend: "+tt.expected) {
+				t.Errorf("Expected trend %q in context:\n%s", tt.expected, context)
+			}
+		})
+	}
+}
+
 func TestFormatWeatherContextNilData(t *testing.T) {
 	client := &ClaudeClient{}
 	_, err := client.formatWeatherContextFromExtracted(nil)
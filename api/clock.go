@@ -0,0 +1,68 @@
+package api
+
+import (
+	"time"
+)
+
+// AIDEV-NOTE: All date reasoning (day part, season, holidays, cache freshness) happens
+// in the forecast location's zone, never the host's. Use a Clock so tests can pin time.
+
+// Clock supplies the current instant
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock reads the host clock
+type SystemClock struct{}
+
+// Now returns the current time
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// FixedClock always returns the same instant
+type FixedClock struct {
+	Time time.Time
+}
+
+// Now returns the pinned time
+func (c FixedClock) Now() time.Time {
+	return c.Time
+}
+
+// clockOrSystem returns clock, or the system clock when nil
+func clockOrSystem(clock Clock) Clock {
+	if clock == nil {
+		return SystemClock{}
+	}
+	return clock
+}
+
+// LoadTimezone resolves an IANA zone name, falling back to a fixed UTC offset
+// when the host has no zone database entry for it. With neither a name nor an
+// offset (data from older caches) the host zone is used.
+func LoadTimezone(name string, offsetSeconds int) *time.Location {
+	if name != "" {
+		if loc, err := time.LoadLocation(name); err == nil {
+			return loc
+		}
+		return time.FixedZone(name, offsetSeconds)
+	}
+	if offsetSeconds != 0 {
+		return time.FixedZone("", offsetSeconds)
+	}
+	return time.Local
+}
+
+// TimeLocation returns the forecast location's zone, or the host zone when unknown
+func (d *TodayWeatherData) TimeLocation() *time.Location {
+	if d == nil {
+		return time.Local
+	}
+	return LoadTimezone(d.Timezone, d.TimezoneOffset)
+}
+
+// LocalTime converts an instant to the forecast location's wall clock
+func (d *TodayWeatherData) LocalTime(t time.Time) time.Time {
+	return t.In(d.TimeLocation())
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadTimezone(t *testing.T) {
+	tests := []struct {
+		name       string
+		zone       string
+		offset     int
+		wantOffset int
+	}{
+		{"IANA zone", "Pacific/Honolulu", -36000, -36000},
+		{"unknown zone falls back to offset", "Mars/Olympus_Mons", 19800, 19800},
+		{"offset only", "", -18000, -18000},
+	}
+
+	instant := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, offset := instant.In(LoadTimezone(tt.zone, tt.offset)).Zone()
+			if offset != tt.wantOffset {
+				t.Errorf("Expected offset %d, got %d", tt.wantOffset, offset)
+			}
+		})
+	}
+
+	if LoadTimezone("", 0) != time.Local {
+		t.Error("Expected host zone when no timezone information is available")
+	}
+}
+
+func TestWeatherContextUsesLocationTime(t *testing.T) {
+	// 6:30 AM Monday in Honolulu is 4:30 PM Monday UTC
+	instant := time.Date(2025, 10, 13, 16, 30, 0, 0, time.UTC)
+	client := &ClaudeClient{config: ClaudeConfig{Clock: FixedClock{Time: instant}}}
+
+	todayData := &TodayWeatherData{
+		TempHigh:          84,
+		TempLow:           72,
+		CurrentTemp:       75,
+		CurrentConditions: "few clouds",
+		RainChance:        0.2,
+		WindConditions:    "Light trade winds",
+		Units:             "imperial",
+		Location:          "Honolulu, US",
+		Timezone:          "Pacific/Honolulu",
+		TimezoneOffset:    -36000,
+	}
+
+	context, err := client.formatWeatherContextFromExtracted(todayData)
+	if err != nil {
+		t.Fatalf("Failed to format context: %v", err)
+	}
+
+	if !strings.Contains(context, "Today is Monday, October 13 at 6:30 AM") {
+		t.Errorf("Expected Honolulu local time in header, got:\n%s", context)
+	}
+	if !strings.Contains(context, "Broadcast time of day: morning") || !strings.Contains(context, "Morning commute time") {
+		t.Errorf("Expected morning day part for Honolulu, got:\n%s", context)
+	}
+}
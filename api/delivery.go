@@ -0,0 +1,276 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"myrcast/internal/logger"
+)
+
+// AIDEV-NOTE: Automation systems watch the import folder and can grab a file while it
+// is still being written. Audio is written to a hidden temp file in the same directory,
+// fsynced, checked and then renamed over the destination, so the watcher only ever sees
+// a complete file. The previous version is kept as <name>.bak. Renders that fail
+// verification never reach the import folder, so yesterday's good file stays in place.
+
+// Render verification limits
+const (
+	minAudioBytes         = 1024                   // Anything smaller is an error body or a truncated download
+	minAudioDuration      = 300 * time.Millisecond // Shortest audio worth delivering
+	speechWordsPerSecond  = 2.5                    // Typical ElevenLabs speaking rate at speed 1.0
+	minSpeechDurationRate = 0.3                    // Reject audio shorter than this share of the expected length
+	defaultAudioByteRate  = 128 * 1000 / 8         // mp3_44100_128, the default output format
+	backupSuffix          = ".bak"                 // Previous delivered version
+	deliveryTempPattern   = ".%s.*.tmp"            // Hidden temp file next to the destination
+	staleTempAge          = 10 * time.Minute       // Temp files older than this are from interrupted runs
+)
+
+// ErrRenderRejected is returned when generated audio fails verification
+var ErrRenderRejected = errors.New("rendered audio failed verification")
+
+// audioByteRate returns the data rate in bytes per second of an ElevenLabs output
+// format such as "mp3_44100_128", "pcm_22050" or "ulaw_8000"
+func audioByteRate(format string) int {
+	parts := strings.Split(format, "_")
+	number := func(i int) int {
+		if i >= len(parts) {
+			return 0
+		}
+		n, err := strconv.Atoi(parts[i])
+		if err != nil {
+			return 0
+		}
+		return n
+	}
+
+	switch parts[0] {
+	case "mp3", "opus":
+		if kbps := number(2); kbps > 0 {
+			return kbps * 1000 / 8
+		}
+	case "pcm":
+		if rate := number(1); rate > 0 {
+			return rate * 2 // 16-bit mono
+		}
+	case "ulaw", "alaw":
+		if rate := number(1); rate > 0 {
+			return rate // 8-bit mono
+		}
+	}
+	return defaultAudioByteRate
+}
+
+// estimateAudioDuration estimates playing time from the audio size and output format
+func estimateAudioDuration(size int64, format string) time.Duration {
+	return time.Duration(size) * time.Second / time.Duration(audioByteRate(format))
+}
+
+// expectedSpeechDuration estimates how long the text takes to speak at the given speed
+func expectedSpeechDuration(text string, speed float64) time.Duration {
+	if speed <= 0 {
+		speed = 1.0
+	}
+	words := len(strings.Fields(text))
+	return time.Duration(float64(words) / (speechWordsPerSecond * speed) * float64(time.Second))
+}
+
+// verifyRender checks that generated audio is complete enough to deliver
+func verifyRender(audioData []byte, format, text string, speed float64) error {
+	if len(audioData) < minAudioBytes {
+		return fmt.Errorf("%w: only %d bytes of audio", ErrRenderRejected, len(audioData))
+	}
+
+	if format == "" || strings.HasPrefix(format, "mp3") {
+		hasID3 := len(audioData) >= 3 && string(audioData[:3]) == "ID3"
+		hasFrameSync := audioData[0] == 0xFF && audioData[1]&0xE0 == 0xE0
+		if !hasID3 && !hasFrameSync {
+			return fmt.Errorf("%w: data does not start with an MP3 header", ErrRenderRejected)
+		}
+	}
+
+	duration := estimateAudioDuration(int64(len(audioData)), format)
+	if duration < minAudioDuration {
+		return fmt.Errorf("%w: audio is only %.1fs long", ErrRenderRejected, duration.Seconds())
+	}
+
+	expected := expectedSpeechDuration(text, speed)
+	if minimum := time.Duration(float64(expected) * minSpeechDurationRate); duration < minimum {
+		return fmt.Errorf("%w: audio is %.1fs long but the text should take about %.0fs (truncated render?)",
+			ErrRenderRejected, duration.Seconds(), expected.Seconds())
+	}
+
+	return nil
+}
+
+// deliverAudio atomically replaces path with data, keeping the previous version as
+// path.bak. It returns the backup path, or "" when there was no previous version.
+func deliverAudio(data []byte, path string) (string, error) {
+	dir := filepath.Dir(path)
+	tempPattern := fmt.Sprintf(deliveryTempPattern, filepath.Base(path))
+	removeStaleTempFiles(dir, tempPattern)
+
+	tempFile, err := os.CreateTemp(dir, tempPattern)
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file for %s: %w", path, err)
+	}
+	tempPath := tempFile.Name()
+	cleanup := func() { os.Remove(tempPath) }
+
+	if _, err := tempFile.Write(data); err != nil {
+		tempFile.Close()
+		cleanup()
+		return "", fmt.Errorf("failed to write temp file %s: %w", tempPath, err)
+	}
+	if err := tempFile.Sync(); err != nil {
+		tempFile.Close()
+		cleanup()
+		return "", fmt.Errorf("failed to sync temp file %s: %w", tempPath, err)
+	}
+	if err := tempFile.Close(); err != nil {
+		cleanup()
+		return "", fmt.Errorf("failed to close temp file %s: %w", tempPath, err)
+	}
+	if err := os.Chmod(tempPath, 0644); err != nil {
+		logger.Debug("Could not set permissions on %s: %v", tempPath, err)
+	}
+
+	// Verify what actually landed on disk before it replaces anything
+	info, err := os.Stat(tempPath)
+	if err != nil {
+		cleanup()
+		return "", fmt.Errorf("failed to check temp file %s: %w", tempPath, err)
+	}
+	if info.Size() != int64(len(data)) {
+		cleanup()
+		return "", fmt.Errorf("temp file %s has %d bytes, expected %d", tempPath, info.Size(), len(data))
+	}
+
+	backupPath, err := backupAudio(path)
+	if err != nil {
+		// A missing backup should not hold back a good new file
+		logger.Warn("Could not keep previous version of %s: %v", path, err)
+	}
+
+	if err := os.Rename(tempPath, path); err != nil {
+		cleanup()
+		return "", fmt.Errorf("failed to move audio file into place at %s: %w", path, err)
+	}
+	syncDirectory(dir)
+
+	return backupPath, nil
+}
+
+// backupAudio copies the current file at path to path.bak, leaving the original in
+// place so the destination never disappears. It returns "" when path does not exist.
+func backupAudio(path string) (string, error) {
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+
+	backupPath := path + backupSuffix
+	if err := os.Remove(backupPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("failed to remove old backup: %w", err)
+	}
+	if err := os.Link(path, backupPath); err == nil {
+		return backupPath, nil
+	}
+
+	// Hard links are not available on every share; fall back to a copy
+	src, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+	dst, err := os.Create(backupPath)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		os.Remove(backupPath)
+		return "", err
+	}
+	if err := dst.Close(); err != nil {
+		os.Remove(backupPath)
+		return "", err
+	}
+	return backupPath, nil
+}
+
+// removeStaleTempFiles deletes temp files left behind by an interrupted delivery.
+// Recent ones are left alone in case another run is writing them.
+func removeStaleTempFiles(dir, pattern string) {
+	matches, err := filepath.Glob(filepath.Join(dir, pattern))
+	if err != nil {
+		return
+	}
+	for _, match := range matches {
+		info, err := os.Stat(match)
+		if err != nil || time.Since(info.ModTime()) < staleTempAge {
+			continue
+		}
+		if err := os.Remove(match); err == nil {
+			logger.Debug("Removed stale temp file %s", match)
+		}
+	}
+}
+
+// syncDirectory flushes the directory entry for the rename. Not all platforms
+// support syncing a directory, so failures are ignored.
+func syncDirectory(dir string) {
+	d, err := os.Open(dir)
+	if err != nil {
+		return
+	}
+	d.Sync()
+	d.Close()
+}
+
+// UpdateLatest makes latestPath hold the same audio as audioPath, as a copy or as a
+// symlink. The copy is delivered like a report, so a watcher never sees it half
+// written. Links fall back to a copy where the file system doesn't allow them.
+func UpdateLatest(audioPath, latestPath string, link bool) error {
+	if link {
+		err := replaceWithSymlink(audioPath, latestPath)
+		if err == nil {
+			return nil
+		}
+		logger.Warn("Could not link %s to %s, copying instead: %v", latestPath, audioPath, err)
+	}
+
+	data, err := os.ReadFile(audioPath)
+	if err != nil {
+		return fmt.Errorf("failed to read audio file for latest copy: %w", err)
+	}
+	if _, err := deliverAudio(data, latestPath); err != nil {
+		return fmt.Errorf("failed to update latest file: %w", err)
+	}
+	return nil
+}
+
+// replaceWithSymlink atomically points linkPath at target by renaming a new link over it
+func replaceWithSymlink(target, linkPath string) error {
+	// Relative targets keep working if the import folder is moved or mounted elsewhere
+	if filepath.Dir(target) == filepath.Dir(linkPath) {
+		target = filepath.Base(target)
+	}
+
+	tempPath := filepath.Join(filepath.Dir(linkPath), "."+filepath.Base(linkPath)+".link.tmp")
+	os.Remove(tempPath)
+	if err := os.Symlink(target, tempPath); err != nil {
+		return err
+	}
+	if err := os.Rename(tempPath, linkPath); err != nil {
+		os.Remove(tempPath)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// mp3Bytes returns n bytes of data that starts with an MP3 frame header
+func mp3Bytes(n int) []byte {
+	data := make([]byte, n)
+	copy(data, []byte{0xFF, 0xFB, 0x90, 0x00})
+	return data
+}
+
+// TestAudioByteRate tests data rates for ElevenLabs output formats
+func TestAudioByteRate(t *testing.T) {
+	tests := []struct {
+		format string
+		want   int
+	}{
+		{"mp3_44100_128", 16000},
+		{"mp3_22050_32", 4000},
+		{"pcm_44100", 88200},
+		{"ulaw_8000", 8000},
+		{"", 16000},
+		{"mystery_format", 16000},
+	}
+	for _, tt := range tests {
+		if got := audioByteRate(tt.format); got != tt.want {
+			t.Errorf("audioByteRate(%q) = %d, want %d", tt.format, got, tt.want)
+		}
+	}
+}
+
+// TestVerifyRender tests that empty, non-audio and truncated renders are rejected
+func TestVerifyRender(t *testing.T) {
+	script := strings.Repeat("word ", 50) // About 20 seconds of speech
+
+	tests := []struct {
+		name     string
+		data     []byte
+		format   string
+		text     string
+		wantErr  bool
+		wantText string
+	}{
+		{"complete render", mp3Bytes(20 * 16000), "mp3_44100_128", script, false, ""},
+		{"ID3 tagged render", append([]byte("ID3"), make([]byte, 20*16000)...), "mp3_44100_128", script, false, ""},
+		{"empty body", nil, "mp3_44100_128", script, true, "bytes of audio"},
+		{"JSON error body", append([]byte(`{"detail":"quota"}`), make([]byte, 2000)...), "mp3_44100_128", "Hi", true, "MP3 header"},
+		{"truncated render", mp3Bytes(2 * 16000), "mp3_44100_128", script, true, "truncated"},
+		{"short text short audio", mp3Bytes(16000), "mp3_44100_128", "Testing one two", false, ""},
+		{"pcm is not header checked", make([]byte, 20*88200), "pcm_44100", script, false, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifyRender(tt.data, tt.format, tt.text, 1.0)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("verifyRender() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if !errors.Is(err, ErrRenderRejected) {
+					t.Errorf("Expected ErrRenderRejected, got %v", err)
+				}
+				if !strings.Contains(err.Error(), tt.wantText) {
+					t.Errorf("Expected error containing %q, got %v", tt.wantText, err)
+				}
+			}
+		})
+	}
+}
+
+// TestDeliverAudio tests atomic replacement with a backup of the previous version
+func TestDeliverAudio(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "weather_report.mp3")
+
+	// First delivery has nothing to back up
+	backup, err := deliverAudio([]byte("first"), path)
+	if err != nil {
+		t.Fatalf("First delivery failed: %v", err)
+	}
+	if backup != "" {
+		t.Errorf("Expected no backup, got %q", backup)
+	}
+
+	// A temp file from a crashed run is cleaned up once it is old enough
+	stale := filepath.Join(dir, ".weather_report.mp3.12345.tmp")
+	if err := os.WriteFile(stale, []byte("partial"), 0644); err != nil {
+		t.Fatalf("Failed to write stale temp file: %v", err)
+	}
+	old := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(stale, old, old); err != nil {
+		t.Fatalf("Failed to age stale temp file: %v", err)
+	}
+
+	backup, err = deliverAudio([]byte("second"), path)
+	if err != nil {
+		t.Fatalf("Second delivery failed: %v", err)
+	}
+	if backup != path+".bak" {
+		t.Errorf("Expected backup %q, got %q", path+".bak", backup)
+	}
+
+	for file, want := range map[string]string{path: "second", path + ".bak": "first"} {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", file, err)
+		}
+		if !bytes.Equal(data, []byte(want)) {
+			t.Errorf("%s contains %q, want %q", filepath.Base(file), data, want)
+		}
+	}
+
+	// Only the delivered file and its backup remain
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("Failed to list directory: %v", err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	if strings.Join(names, ",") != "weather_report.mp3,weather_report.mp3.bak" {
+		t.Errorf("Unexpected files after delivery: %v", names)
+	}
+}
+
+// TestUpdateLatest tests keeping the rolling media_id file in step with a per-slot file
+func TestUpdateLatest(t *testing.T) {
+	for _, link := range []bool{false, true} {
+		name := "copy"
+		if link {
+			name = "link"
+		}
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			latest := filepath.Join(dir, "weather_report.mp3")
+			for _, slot := range []string{"weather_report_morning.mp3", "weather_report_evening.mp3"} {
+				audio := filepath.Join(dir, slot)
+				if err := os.WriteFile(audio, []byte(slot), 0644); err != nil {
+					t.Fatalf("Failed to write %s: %v", slot, err)
+				}
+				if err := UpdateLatest(audio, latest, link); err != nil {
+					t.Fatalf("UpdateLatest failed: %v", err)
+				}
+				data, err := os.ReadFile(latest)
+				if err != nil {
+					t.Fatalf("Failed to read latest file: %v", err)
+				}
+				if string(data) != slot {
+					t.Errorf("Latest file contains %q, want %q", data, slot)
+				}
+			}
+
+			info, err := os.Lstat(latest)
+			if err != nil {
+				t.Fatalf("Failed to stat latest file: %v", err)
+			}
+			if isLink := info.Mode()&os.ModeSymlink != 0; isLink != link {
+				t.Errorf("Expected symlink %v, got mode %v", link, info.Mode())
+			}
+		})
+	}
+}
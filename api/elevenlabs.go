@@ -14,13 +14,16 @@ import (
 	"path/filepath"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/haguro/elevenlabs-go"
 	"myrcast/internal/logger"
+	"myrcast/internal/runstats"
 )
 
 const (
 	// Default ElevenLabs configuration
+	defaultElevenLabsBaseURL = "https://api.elevenlabs.io"
 	defaultElevenLabsTimeout = 30 * time.Second
 	defaultJitterFactorEL    = 0.1
 
@@ -51,7 +54,8 @@ type ElevenLabsConfig struct {
 	MaxRetries int
 	BaseDelay  time.Duration
 	MaxDelay   time.Duration
-	RateLimit  int // requests per minute
+	RateLimit  int    // requests per minute
+	BaseURL    string // API root (default: https://api.elevenlabs.io)
 }
 
 // ElevenLabsRateLimiter handles rate limiting for ElevenLabs API requests
@@ -186,6 +190,10 @@ func NewElevenLabsClient(config ElevenLabsConfig) (*ElevenLabsClient, error) {
 	if config.RateLimit <= 0 {
 		config.RateLimit = 20 // Conservative rate limit
 	}
+	if config.BaseURL == "" {
+		config.BaseURL = defaultElevenLabsBaseURL
+	}
+	config.BaseURL = strings.TrimRight(config.BaseURL, "/")
 
 	// Create ElevenLabs client with context and timeout
 	client := elevenlabs.NewClient(context.Background(), config.APIKey, config.Timeout)
@@ -200,6 +208,9 @@ func NewElevenLabsClient(config ElevenLabsConfig) (*ElevenLabsClient, error) {
 	}, nil
 }
 
+// AudioExtension is the extension of generated audio files
+const AudioExtension = ".mp3"
+
 // TextToSpeechRequest contains the request data for generating speech
 type TextToSpeechRequest struct {
 	Text      string // Text to convert to speech
@@ -257,6 +268,14 @@ func (c *ElevenLabsClient) GenerateTextToSpeech(ctx context.Context, request Tex
 		complete(fmt.Errorf("ElevenLabs API request failed after retries: %w", err))
 		return nil, err
 	}
+	// ElevenLabs bills by character, whether or not the render is usable
+	runstats.AddCharacters(utf8.RuneCountInString(request.Text))
+
+	// Refuse to replace a good file with an empty or truncated render
+	if err := verifyRender(audioData, c.config.Format, request.Text, c.config.Speed); err != nil {
+		complete(err)
+		return nil, fmt.Errorf("ElevenLabs API returned unusable audio, existing file left in place: %w", err)
+	}
 
 	// Save MP3 file (no conversion needed - Myriad supports MP3)
 	mp3FilePath, err := c.saveMP3Audio(audioData, request.OutputDir, request.FileName)
@@ -286,10 +305,117 @@ func (c *ElevenLabsClient) GenerateTextToSpeech(ctx context.Context, request Tex
 	}, nil
 }
 
+// Voice describes an ElevenLabs voice available to the account
+type Voice struct {
+	ID                string            `json:"voice_id"`
+	Name              string            `json:"name"`
+	Category          string            `json:"category"`
+	Description       string            `json:"description,omitempty"`
+	Labels            map[string]string `json:"labels"`
+	PreviewURL        string            `json:"preview_url"`
+	VerifiedLanguages []VoiceLanguage   `json:"verified_languages,omitempty"`
+}
+
+// VoiceLanguage is a language a voice has been verified to speak
+type VoiceLanguage struct {
+	Language   string `json:"language"`
+	Accent     string `json:"accent,omitempty"`
+	Locale     string `json:"locale,omitempty"`
+	ModelID    string `json:"model_id,omitempty"`
+	PreviewURL string `json:"preview_url,omitempty"`
+}
+
+// voiceLabelOrder lists the descriptive voice labels in display order
+var voiceLabelOrder = []string{"gender", "age", "accent", "descriptive", "description", "use_case", "use case"}
+
+// LabelSummary joins the voice's descriptive labels ("male, middle aged, american, narration")
+func (v Voice) LabelSummary() string {
+	var parts []string
+	for _, key := range voiceLabelOrder {
+		if value := strings.TrimSpace(v.Labels[key]); value != "" {
+			parts = append(parts, strings.ReplaceAll(value, "_", " "))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Languages lists the distinct languages the voice is verified for, falling back
+// to its language label
+func (v Voice) Languages() []string {
+	var languages []string
+	seen := make(map[string]bool)
+	for _, verified := range v.VerifiedLanguages {
+		language := strings.TrimSpace(verified.Language)
+		if language != "" && !seen[language] {
+			seen[language] = true
+			languages = append(languages, language)
+		}
+	}
+	if len(languages) == 0 && v.Labels["language"] != "" {
+		languages = append(languages, v.Labels["language"])
+	}
+	return languages
+}
+
+// ListVoices returns the voices available to the configured API key
+func (c *ElevenLabsClient) ListVoices(ctx context.Context) ([]Voice, error) {
+	complete := logger.LogOperationStart("elevenlabs_list_voices", nil)
+
+	var response struct {
+		Voices []Voice `json:"voices"`
+	}
+	if err := c.getJSON(ctx, "/v1/voices", &response); err != nil {
+		complete(err)
+		return nil, fmt.Errorf("failed to list ElevenLabs voices: %w", err)
+	}
+
+	complete(nil)
+	return response.Voices, nil
+}
+
+// getJSON performs a rate-limited GET against the ElevenLabs API and decodes the response
+func (c *ElevenLabsClient) getJSON(ctx context.Context, path string, out any) error {
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return fmt.Errorf("rate limiter cancelled: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.config.BaseURL+path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("xi-api-key", c.config.APIKey)
+	req.Header.Set(logger.RunIDHeader, logger.RunID())
+
+	client := &http.Client{Timeout: c.config.Timeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return c.parseElevenLabsError(err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return &ElevenLabsAPIError{
+			Type:       "api_error",
+			Message:    string(body),
+			StatusCode: resp.StatusCode,
+			Retryable:  resp.StatusCode >= 500 || resp.StatusCode == 429,
+		}
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to parse ElevenLabs response: %w", err)
+	}
+	return nil
+}
+
 // executeCustomTextToSpeechWithRetry executes a custom TTS request with speed support
 func (c *ElevenLabsClient) executeCustomTextToSpeechWithRetry(ctx context.Context, voiceID string, ttsReq CustomTextToSpeechRequest) ([]byte, error) {
 	var lastErr error
-	baseURL := "https://api.elevenlabs.io/v1/text-to-speech/" + voiceID + "?output_format=" + c.config.Format
+	baseURL := c.config.BaseURL + "/v1/text-to-speech/" + voiceID + "?output_format=" + c.config.Format
 
 	for attempt := 0; attempt <= c.config.MaxRetries; attempt++ {
 		// Apply rate limiting before each request
@@ -322,6 +448,7 @@ func (c *ElevenLabsClient) executeCustomTextToSpeechWithRetry(ctx context.Contex
 		// Set headers
 		req.Header.Set("Content-Type", "application/json")
 		req.Header.Set("xi-api-key", c.config.APIKey)
+		req.Header.Set(logger.RunIDHeader, logger.RunID())
 
 		// Execute the HTTP request
 		client := &http.Client{Timeout: c.config.Timeout}
@@ -345,6 +472,7 @@ func (c *ElevenLabsClient) executeCustomTextToSpeechWithRetry(ctx context.Contex
 
 			// Calculate delay for next retry
 			delay := c.calculateRetryDelay(attempt)
+			runstats.Retry(runstats.ProviderElevenLabs)
 
 			logger.LogWithFields(logger.WarnLevel, "ElevenLabs custom API request failed, retrying", map[string]any{
 				"error":        err.Error(),
@@ -364,6 +492,7 @@ func (c *ElevenLabsClient) executeCustomTextToSpeechWithRetry(ctx context.Contex
 		}
 
 		defer resp.Body.Close()
+		runstats.HTTPStatus(runstats.ProviderElevenLabs, resp.StatusCode)
 
 		// Check response status
 		if resp.StatusCode != http.StatusOK {
@@ -392,6 +521,7 @@ func (c *ElevenLabsClient) executeCustomTextToSpeechWithRetry(ctx context.Contex
 
 			// Calculate delay for next retry
 			delay := c.calculateRetryDelay(attempt)
+			runstats.Retry(runstats.ProviderElevenLabs)
 
 			logger.LogWithFields(logger.WarnLevel, "ElevenLabs custom API request failed, retrying", map[string]any{
 				"status_code":  resp.StatusCode,
@@ -415,6 +545,7 @@ func (c *ElevenLabsClient) executeCustomTextToSpeechWithRetry(ctx context.Contex
 		audioData, err := io.ReadAll(resp.Body)
 		if err != nil {
 			lastErr = fmt.Errorf("failed to read response body: %w", err)
+			runstats.Retry(runstats.ProviderElevenLabs)
 			continue
 		}
 
@@ -606,16 +737,18 @@ func (c *ElevenLabsClient) saveMP3Audio(audioData []byte, outputDir, fileName st
 	}
 
 	// Create MP3 file path
-	mp3FilePath := filepath.Join(outputDir, fileName+".mp3")
+	mp3FilePath := filepath.Join(outputDir, fileName+AudioExtension)
 
-	// Write MP3 data to file
-	if err := os.WriteFile(mp3FilePath, audioData, 0644); err != nil {
+	// Write MP3 data to a temp file and rename it into place
+	backupPath, err := deliverAudio(audioData, mp3FilePath)
+	if err != nil {
 		return "", fmt.Errorf("failed to write MP3 file: %w", err)
 	}
 
 	logger.LogWithFields(logger.DebugLevel, "MP3 audio file saved", map[string]any{
-		"file_path": mp3FilePath,
-		"file_size": len(audioData),
+		"file_path":   mp3FilePath,
+		"file_size":   len(audioData),
+		"backup_path": backupPath,
 	})
 
 	return mp3FilePath, nil
@@ -630,15 +763,13 @@ func (c *ElevenLabsClient) calculateMP3Duration(mp3FilePath string) (int, error)
 		return 0, fmt.Errorf("failed to get MP3 file info: %w", err)
 	}
 
-	// Simple estimation: MP3 at 128kbps ≈ 16KB per second
-	// This is rough but sufficient for logging purposes
-	fileSizeKB := fileInfo.Size() / 1024
-	estimatedSeconds := fileSizeKB / 16
-	estimatedMs := int(estimatedSeconds * 1000)
+	// Simple estimation from the output format's bit rate (128kbps ≈ 16KB per second)
+	// This is rough but sufficient for logging and render checks
+	estimatedMs := int(estimateAudioDuration(fileInfo.Size(), c.config.Format).Milliseconds())
 
 	logger.LogWithFields(logger.DebugLevel, "MP3 duration estimated", map[string]any{
 		"file_path":    mp3FilePath,
-		"file_size_kb": fileSizeKB,
+		"file_size_kb": fileInfo.Size() / 1024,
 		"duration_ms":  estimatedMs,
 	})
 
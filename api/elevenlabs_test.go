@@ -3,6 +3,8 @@ package api
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
@@ -788,3 +790,56 @@ func TestCustomVoiceSettingsStructure(t *testing.T) {
 		t.Error("Expected speaker boost to be true")
 	}
 }
+
+// TestListVoices tests parsing of voice labels, languages and preview URLs
+func TestListVoices(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/voices" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `{"voices": [
+			{"voice_id": "v1", "name": "Rachel", "category": "premade",
+			 "labels": {"gender": "female", "age": "young", "accent": "american", "use_case": "narration"},
+			 "preview_url": "https://example.com/rachel.mp3",
+			 "verified_languages": [
+				{"language": "en", "model_id": "eleven_multilingual_v2", "accent": "american"},
+				{"language": "en", "model_id": "eleven_turbo_v2_5"},
+				{"language": "es", "model_id": "eleven_multilingual_v2"}
+			 ]},
+			{"voice_id": "v2", "name": "Station ID", "category": "cloned",
+			 "labels": {"language": "de"}}
+		]}`)
+	}))
+	defer server.Close()
+
+	client, err := NewElevenLabsClient(ElevenLabsConfig{APIKey: "test-api-key", BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("Failed to create ElevenLabs client: %v", err)
+	}
+
+	voices, err := client.ListVoices(context.Background())
+	if err != nil {
+		t.Fatalf("ListVoices failed: %v", err)
+	}
+	if len(voices) != 2 {
+		t.Fatalf("Expected 2 voices, got %d", len(voices))
+	}
+
+	rachel := voices[0]
+	if rachel.PreviewURL != "https://example.com/rachel.mp3" {
+		t.Errorf("Expected preview URL, got %q", rachel.PreviewURL)
+	}
+	if got := rachel.LabelSummary(); got != "female, young, american, narration" {
+		t.Errorf("Unexpected label summary %q", got)
+	}
+	if got := strings.Join(rachel.Languages(), ","); got != "en,es" {
+		t.Errorf("Expected languages en,es, got %q", got)
+	}
+	if got := strings.Join(voices[1].Languages(), ","); got != "de" {
+		t.Errorf("Expected language label fallback de, got %q", got)
+	}
+	if got := voices[1].LabelSummary(); got != "" {
+		t.Errorf("Expected empty label summary, got %q", got)
+	}
+}
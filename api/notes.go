@@ -0,0 +1,709 @@
+package api
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"myrcast/internal/calendar"
+)
+
+// AIDEV-NOTE: Broadcast notes are driven by an editable rules file instead of hardcoded
+// heuristics. The built-in rule set below is the default when no rules file is configured.
+
+//go:embed notes_default.toml
+var defaultNotesRules []byte
+
+var (
+	defaultNotesOnce    sync.Once
+	defaultNotesRuleSet *NotesRuleSet
+	defaultNotesErr     error
+)
+
+// notesUnits lists unit systems a rule set or condition may name
+var notesUnits = []string{"metric", "imperial", "kelvin"}
+
+// notePlaceholderPattern matches {placeholder} tokens in rule notes
+var notePlaceholderPattern = regexp.MustCompile(`\{([a-z_]+)\}`)
+
+// NotesRuleSet is an ordered collection of broadcast note rules
+type NotesRuleSet struct {
+	Units      string             `toml:"units"`      // Units thresholds are written in (default imperial)
+	Thresholds map[string]float64 `toml:"thresholds"` // Named temperature thresholds, in Units
+	Rules      []NotesRule        `toml:"rule"`
+	Source     string             `toml:"-"` // File the rules were loaded from ("built-in" for defaults)
+}
+
+// NotesRule pairs a set of conditions with the note added when they all match
+type NotesRule struct {
+	Name  string         `toml:"name"`  // Identifier shown in previews
+	Group string         `toml:"group"` // Only the first matching rule in a group fires
+	Note  string         `toml:"note"`  // Note text, may contain {placeholders}
+	When  NotesCondition `toml:"when"`  // Conditions that must all match
+}
+
+// NotesCondition describes when a rule applies; unset fields are ignored
+type NotesCondition struct {
+	// Temperature thresholds are degrees in the rule set's units or a [thresholds] name
+	TempAbove  any      `toml:"temp_above"`  // Current temperature above
+	TempBelow  any      `toml:"temp_below"`  // Current temperature below
+	HighAbove  any      `toml:"high_above"`  // Today's high above
+	HighBelow  any      `toml:"high_below"`  // Today's high below
+	LowAbove   any      `toml:"low_above"`   // Today's low above
+	LowBelow   any      `toml:"low_below"`   // Today's low below
+	RangeAbove any      `toml:"range_above"` // High minus low above
+	RangeBelow any      `toml:"range_below"` // High minus low below
+	PopAbove   *float64 `toml:"pop_above"`   // Precipitation probability above (0-1)
+	PopBelow   *float64 `toml:"pop_below"`   // Precipitation probability below (0-1)
+	WindAbove  *float64 `toml:"wind_above"`  // Current wind speed above, in the rule set's units
+	WindBelow  *float64 `toml:"wind_below"`  // Current wind speed below, in the rule set's units
+
+	WindContains       []string `toml:"wind_contains"`       // Any word in the wind description
+	ConditionsContains []string `toml:"conditions_contains"` // Any word in the current conditions
+	LocationContains   []string `toml:"location_contains"`   // Any word in the location name
+	Units              []string `toml:"units"`               // Unit systems the rule applies to
+
+	Hours    []int    `toml:"hours"`    // [from, to) local hour window, may wrap midnight
+	Weekdays []string `toml:"weekdays"` // Day names, "weekday" or "weekend"
+	Seasons  []string `toml:"seasons"`  // winter, spring, summer, fall
+	Holiday  *bool    `toml:"holiday"`  // Whether today is a holiday
+	Holidays []string `toml:"holidays"` // Specific holiday names
+	Alerts   *bool    `toml:"alerts"`   // Whether weather alerts are active
+
+	Any []NotesCondition `toml:"any"` // At least one nested condition must match
+}
+
+// NotesContext holds everything a rule can be evaluated against
+type NotesContext struct {
+	Weather   *TodayWeatherData
+	Time      time.Time
+	TimeOfDay string
+	Season    string
+	Holiday   string   // First holiday name, public holidays before observances
+	Holidays  []string // All holidays, observances and station events today
+	IsHoliday bool
+}
+
+// NoteMatch records the outcome of evaluating one rule
+type NoteMatch struct {
+	Rule   NotesRule
+	Note   string // Rendered note text
+	Fired  bool   // Whether the note was added
+	Reason string // Why a matching rule did not fire
+}
+
+// NotesOptions carries station settings that shape the notes context
+type NotesOptions struct {
+	Calendar   *calendar.Calendar // Holiday calendar (nil uses the US calendar)
+	Latitude   float64            // Station latitude; negative flips seasons
+	SeasonMode string             // meteorological (default) or astronomical
+}
+
+// NewNotesContext derives time, season and holiday context for rule evaluation
+func NewNotesContext(todayData *TodayWeatherData, now time.Time, opts NotesOptions) (NotesContext, error) {
+	cal := opts.Calendar
+	if cal == nil {
+		cal = calendar.Default()
+	}
+
+	season, err := SeasonAt(now, opts.Latitude, opts.SeasonMode)
+	if err != nil {
+		return NotesContext{}, err
+	}
+
+	ctx := NotesContext{
+		Weather:   todayData,
+		Time:      now,
+		TimeOfDay: TimeOfDay(now),
+		Season:    season,
+	}
+	for _, holiday := range cal.On(now) {
+		ctx.Holidays = append(ctx.Holidays, holiday.Name)
+	}
+	if len(ctx.Holidays) > 0 {
+		ctx.Holiday = ctx.Holidays[0]
+		ctx.IsHoliday = true
+	}
+
+	return ctx, nil
+}
+
+// DefaultNotesRuleSet returns the built-in broadcast notes rules
+func DefaultNotesRuleSet() (*NotesRuleSet, error) {
+	defaultNotesOnce.Do(func() {
+		defaultNotesRuleSet, defaultNotesErr = ParseNotesRuleSet(defaultNotesRules, "built-in")
+	})
+	return defaultNotesRuleSet, defaultNotesErr
+}
+
+// DefaultNotesRulesTOML returns the built-in rules file so stations can start from it
+func DefaultNotesRulesTOML() []byte {
+	return append([]byte(nil), defaultNotesRules...)
+}
+
+// LoadNotesRuleSet reads a rules file, falling back to the built-in rules for an empty path
+func LoadNotesRuleSet(path string) (*NotesRuleSet, error) {
+	if strings.TrimSpace(path) == "" {
+		return DefaultNotesRuleSet()
+	}
+
+	cleanPath := filepath.Clean(path)
+	data, err := os.ReadFile(cleanPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read notes rules file: %w", err)
+	}
+
+	return ParseNotesRuleSet(data, cleanPath)
+}
+
+// ParseNotesRuleSet decodes and validates a rules file, rejecting unknown keys
+func ParseNotesRuleSet(data []byte, source string) (*NotesRuleSet, error) {
+	var ruleSet NotesRuleSet
+	decoder := toml.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&ruleSet); err != nil {
+		var strictErr *toml.StrictMissingError
+		if errors.As(err, &strictErr) {
+			return nil, fmt.Errorf("invalid notes rules in %s: %s", source, strictErr.String())
+		}
+		return nil, fmt.Errorf("failed to parse notes rules in %s: %w", source, err)
+	}
+	ruleSet.Source = source
+	ruleSet.Units = strings.ToLower(strings.TrimSpace(ruleSet.Units))
+	if ruleSet.Units == "" {
+		ruleSet.Units = "imperial"
+	}
+
+	if err := ruleSet.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid notes rules in %s: %w", source, err)
+	}
+
+	return &ruleSet, nil
+}
+
+// Validate checks units, thresholds and every rule for missing notes and malformed conditions
+func (rs *NotesRuleSet) Validate() error {
+	var problems []string
+
+	if !equalsAnyFold(rs.Units, notesUnits) {
+		problems = append(problems, fmt.Sprintf("units must be one of: %s, got %q", strings.Join(notesUnits, ", "), rs.Units))
+	}
+	for name := range rs.Thresholds {
+		if name != strings.ToLower(name) || strings.TrimSpace(name) == "" {
+			problems = append(problems, fmt.Sprintf("threshold name %q must be lowercase", name))
+		}
+	}
+
+	for i, rule := range rs.Rules {
+		label := rule.label(i)
+		if strings.TrimSpace(rule.Note) == "" {
+			problems = append(problems, fmt.Sprintf("%s: note is required", label))
+		}
+		for _, match := range notePlaceholderPattern.FindAllStringSubmatch(rule.Note, -1) {
+			if !isKnownNotePlaceholder(match[1]) {
+				problems = append(problems, fmt.Sprintf("%s: unknown placeholder {%s}", label, match[1]))
+			}
+		}
+		for _, problem := range rule.When.validate(rs.Thresholds) {
+			problems = append(problems, fmt.Sprintf("%s: %s", label, problem))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// WithClimate returns a copy of the rule set with named thresholds overridden.
+// Override values are given in units and converted to the rule set's units;
+// thresholds used as a high/low range are differences and only rescaled.
+func (rs *NotesRuleSet) WithClimate(units string, overrides map[string]float64) (*NotesRuleSet, error) {
+	if len(overrides) == 0 {
+		return rs, nil
+	}
+	if strings.TrimSpace(units) == "" {
+		units = rs.Units
+	}
+
+	climate := *rs
+	climate.Thresholds = make(map[string]float64, len(rs.Thresholds))
+	for name, value := range rs.Thresholds {
+		climate.Thresholds[name] = value
+	}
+
+	differences := rs.differenceThresholds()
+	var unknown []string
+	for name, value := range overrides {
+		key := strings.ToLower(strings.TrimSpace(name))
+		if _, ok := climate.Thresholds[key]; !ok {
+			unknown = append(unknown, name)
+			continue
+		}
+		converted := ConvertTemperature(value, units, rs.Units)
+		if differences[key] {
+			// A 10°C swing is 18°F, not 50°F: no offset for differences
+			converted -= ConvertTemperature(0, units, rs.Units)
+		}
+		climate.Thresholds[key] = converted
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("unknown climate thresholds %s (defined in %s: %s)",
+			strings.Join(unknown, ", "), rs.Source, strings.Join(rs.ThresholdNames(), ", "))
+	}
+
+	return &climate, nil
+}
+
+// differenceThresholds returns the threshold names used by range conditions
+func (rs *NotesRuleSet) differenceThresholds() map[string]bool {
+	names := make(map[string]bool)
+	var collect func(c NotesCondition)
+	collect = func(c NotesCondition) {
+		for _, value := range []any{c.RangeAbove, c.RangeBelow} {
+			if name, ok := value.(string); ok {
+				names[strings.ToLower(strings.TrimSpace(name))] = true
+			}
+		}
+		for _, nested := range c.Any {
+			collect(nested)
+		}
+	}
+	for _, rule := range rs.Rules {
+		collect(rule.When)
+	}
+	return names
+}
+
+// ThresholdNames returns the named thresholds in sorted order
+func (rs *NotesRuleSet) ThresholdNames() []string {
+	names := make([]string, 0, len(rs.Thresholds))
+	for name := range rs.Thresholds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Evaluate returns the notes that fire for the given context, in rule order
+func (rs *NotesRuleSet) Evaluate(ctx NotesContext) []string {
+	var notes []string
+	for _, match := range rs.Explain(ctx) {
+		if match.Fired {
+			notes = append(notes, match.Note)
+		}
+	}
+	return notes
+}
+
+// Explain evaluates every rule and reports which fired and why others did not
+func (rs *NotesRuleSet) Explain(ctx NotesContext) []NoteMatch {
+	matches := make([]NoteMatch, 0, len(rs.Rules))
+	scale := rs.scaleFor(ctx.Weather)
+	firedGroups := make(map[string]string)
+	seenNotes := make(map[string]bool)
+
+	for i, rule := range rs.Rules {
+		match := NoteMatch{Rule: rule}
+		if match.Rule.Name == "" {
+			match.Rule.Name = rule.label(i)
+		}
+
+		if !rule.When.matches(ctx, scale) {
+			matches = append(matches, match)
+			continue
+		}
+
+		note, ok := renderNote(rule.Note, ctx)
+		match.Note = note
+		switch {
+		case !ok:
+			match.Reason = "placeholder has no value"
+		case rule.Group != "" && firedGroups[rule.Group] != "":
+			match.Reason = fmt.Sprintf("group %q already fired (%s)", rule.Group, firedGroups[rule.Group])
+		case seenNotes[note]:
+			match.Reason = "duplicate note"
+		default:
+			match.Fired = true
+			seenNotes[note] = true
+			if rule.Group != "" {
+				firedGroups[rule.Group] = match.Rule.Name
+			}
+		}
+
+		matches = append(matches, match)
+	}
+
+	return matches
+}
+
+// label returns a human-readable rule identifier for messages
+func (r NotesRule) label(index int) string {
+	if r.Name != "" {
+		return fmt.Sprintf("rule %q", r.Name)
+	}
+	return fmt.Sprintf("rule #%d", index+1)
+}
+
+// matches reports whether every set condition holds for the context
+func (c NotesCondition) matches(ctx NotesContext, scale notesScale) bool {
+	data := ctx.Weather
+	if data == nil {
+		data = &TodayWeatherData{}
+	}
+
+	if !above(scale.temperature(c.TempAbove), data.CurrentTemp) || !below(scale.temperature(c.TempBelow), data.CurrentTemp) {
+		return false
+	}
+	if !above(scale.temperature(c.HighAbove), data.TempHigh) || !below(scale.temperature(c.HighBelow), data.TempHigh) {
+		return false
+	}
+	if !above(scale.temperature(c.LowAbove), data.TempLow) || !below(scale.temperature(c.LowBelow), data.TempLow) {
+		return false
+	}
+	if c.RangeAbove != nil || c.RangeBelow != nil {
+		// AIDEV-NOTE: A zero current temperature or high means the forecast
+		// came back incomplete, and the range would be meaningless
+		if data.CurrentTemp == 0 || data.TempHigh == 0 {
+			return false
+		}
+		tempRange := data.TempHigh - data.TempLow
+		if !above(scale.difference(c.RangeAbove), tempRange) || !below(scale.difference(c.RangeBelow), tempRange) {
+			return false
+		}
+	}
+	if !above(c.PopAbove, data.RainChance) || !below(c.PopBelow, data.RainChance) {
+		return false
+	}
+	if !above(scale.wind(c.WindAbove), data.WindSpeed) || !below(scale.wind(c.WindBelow), data.WindSpeed) {
+		return false
+	}
+
+	if len(c.WindContains) > 0 && !containsAnyFold(data.WindConditions, c.WindContains) {
+		return false
+	}
+	if len(c.ConditionsContains) > 0 && !containsAnyFold(data.CurrentConditions, c.ConditionsContains) {
+		return false
+	}
+	if len(c.LocationContains) > 0 && !containsAnyFold(data.Location, c.LocationContains) {
+		return false
+	}
+	if len(c.Units) > 0 && !equalsAnyFold(data.Units, c.Units) {
+		return false
+	}
+
+	if len(c.Hours) == 2 && !inHourWindow(ctx.Time.Hour(), c.Hours[0], c.Hours[1]) {
+		return false
+	}
+	if len(c.Weekdays) > 0 && !matchesWeekday(ctx.Time.Weekday(), c.Weekdays) {
+		return false
+	}
+	if len(c.Seasons) > 0 && !equalsAnyFold(ctx.Season, c.Seasons) {
+		return false
+	}
+	if c.Holiday != nil && *c.Holiday != ctx.IsHoliday {
+		return false
+	}
+	if len(c.Holidays) > 0 && !anyEqualsAnyFold(ctx.Holidays, c.Holidays) {
+		return false
+	}
+	if c.Alerts != nil && *c.Alerts != (len(data.WeatherAlerts) > 0) {
+		return false
+	}
+
+	if len(c.Any) > 0 {
+		for _, alternative := range c.Any {
+			if alternative.matches(ctx, scale) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return true
+}
+
+// validate reports malformed condition values
+func (c NotesCondition) validate(thresholds map[string]float64) []string {
+	var problems []string
+
+	temperatures := []struct {
+		key   string
+		value any
+	}{
+		{"temp_above", c.TempAbove}, {"temp_below", c.TempBelow},
+		{"high_above", c.HighAbove}, {"high_below", c.HighBelow},
+		{"low_above", c.LowAbove}, {"low_below", c.LowBelow},
+		{"range_above", c.RangeAbove}, {"range_below", c.RangeBelow},
+	}
+	for _, temp := range temperatures {
+		if temp.value == nil {
+			continue
+		}
+		if _, ok := resolveThreshold(temp.value, thresholds); !ok {
+			problems = append(problems, fmt.Sprintf("%s must be a number or a defined threshold name, got %v", temp.key, temp.value))
+		}
+	}
+
+	if c.PopAbove != nil && (*c.PopAbove < 0 || *c.PopAbove > 1) {
+		problems = append(problems, fmt.Sprintf("pop_above must be between 0 and 1, got %.2f", *c.PopAbove))
+	}
+	if c.PopBelow != nil && (*c.PopBelow < 0 || *c.PopBelow > 1) {
+		problems = append(problems, fmt.Sprintf("pop_below must be between 0 and 1, got %.2f", *c.PopBelow))
+	}
+
+	if len(c.Hours) > 0 {
+		if len(c.Hours) != 2 {
+			problems = append(problems, fmt.Sprintf("hours must be [from, to], got %v", c.Hours))
+		} else if c.Hours[0] < 0 || c.Hours[0] > 23 || c.Hours[1] < 0 || c.Hours[1] > 24 {
+			problems = append(problems, fmt.Sprintf("hours must be within 0-24, got %v", c.Hours))
+		}
+	}
+
+	for _, day := range c.Weekdays {
+		if _, ok := parseWeekdaySpec(day); !ok {
+			problems = append(problems, fmt.Sprintf("unknown weekday %q", day))
+		}
+	}
+
+	validSeasons := []string{"winter", "spring", "summer", "fall"}
+	for _, season := range c.Seasons {
+		if !equalsAnyFold(season, validSeasons) {
+			problems = append(problems, fmt.Sprintf("unknown season %q (valid: %s)", season, strings.Join(validSeasons, ", ")))
+		}
+	}
+
+	for _, unit := range c.Units {
+		if !equalsAnyFold(unit, notesUnits) {
+			problems = append(problems, fmt.Sprintf("unknown units %q (valid: %s)", unit, strings.Join(notesUnits, ", ")))
+		}
+	}
+
+	for _, alternative := range c.Any {
+		problems = append(problems, alternative.validate(thresholds)...)
+	}
+
+	return problems
+}
+
+// notesScale converts rule thresholds into the units of the weather data
+type notesScale struct {
+	ruleUnits  string
+	dataUnits  string
+	thresholds map[string]float64
+}
+
+// scaleFor builds the threshold conversion for a weather snapshot
+func (rs *NotesRuleSet) scaleFor(data *TodayWeatherData) notesScale {
+	scale := notesScale{ruleUnits: rs.Units, dataUnits: rs.Units, thresholds: rs.Thresholds}
+	if scale.ruleUnits == "" {
+		scale.ruleUnits = "imperial"
+		scale.dataUnits = "imperial"
+	}
+	if data != nil && strings.TrimSpace(data.Units) != "" {
+		scale.dataUnits = strings.ToLower(data.Units)
+	}
+	return scale
+}
+
+// temperature resolves an absolute temperature threshold in data units
+func (s notesScale) temperature(value any) *float64 {
+	threshold, ok := resolveThreshold(value, s.thresholds)
+	if !ok {
+		return nil
+	}
+	converted := ConvertTemperature(threshold, s.ruleUnits, s.dataUnits)
+	return &converted
+}
+
+// difference resolves a temperature difference, such as a daily range, in data units
+func (s notesScale) difference(value any) *float64 {
+	threshold, ok := resolveThreshold(value, s.thresholds)
+	if !ok {
+		return nil
+	}
+	converted := ConvertTemperatureDifference(threshold, s.ruleUnits, s.dataUnits)
+	return &converted
+}
+
+// wind converts a wind speed threshold into data units
+func (s notesScale) wind(value *float64) *float64 {
+	if value == nil {
+		return nil
+	}
+	converted := ConvertWindSpeed(*value, s.ruleUnits, s.dataUnits)
+	return &converted
+}
+
+// resolveThreshold turns a number or threshold name into degrees
+func resolveThreshold(value any, thresholds map[string]float64) (float64, bool) {
+	switch v := value.(type) {
+	case int64:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case float64:
+		return v, true
+	case string:
+		threshold, ok := thresholds[strings.ToLower(strings.TrimSpace(v))]
+		return threshold, ok
+	}
+	return 0, false
+}
+
+// renderNote substitutes placeholders, reporting false if any has no value
+func renderNote(note string, ctx NotesContext) (string, bool) {
+	values := map[string]string{
+		"time_of_day": ctx.TimeOfDay,
+		"season":      ctx.Season,
+		"weekday":     ctx.Time.Weekday().String(),
+		"holiday":     ctx.Holiday,
+	}
+	if ctx.Weather != nil {
+		values["location"] = ctx.Weather.Location
+	}
+
+	complete := true
+	rendered := notePlaceholderPattern.ReplaceAllStringFunc(note, func(token string) string {
+		value := values[strings.Trim(token, "{}")]
+		if value == "" {
+			complete = false
+		}
+		return value
+	})
+
+	return rendered, complete
+}
+
+// isKnownNotePlaceholder reports whether a placeholder name can be rendered
+func isKnownNotePlaceholder(name string) bool {
+	switch name {
+	case "time_of_day", "season", "weekday", "holiday", "location":
+		return true
+	}
+	return false
+}
+
+// above reports whether value exceeds an optional threshold
+func above(threshold *float64, value float64) bool {
+	return threshold == nil || value > *threshold
+}
+
+// below reports whether value is under an optional threshold
+func below(threshold *float64, value float64) bool {
+	return threshold == nil || value < *threshold
+}
+
+// containsAnyFold reports whether text contains any of the words, ignoring case
+func containsAnyFold(text string, words []string) bool {
+	lower := strings.ToLower(text)
+	for _, word := range words {
+		if word != "" && strings.Contains(lower, strings.ToLower(word)) {
+			return true
+		}
+	}
+	return false
+}
+
+// equalsAnyFold reports whether value equals any of the candidates, ignoring case
+func equalsAnyFold(value string, candidates []string) bool {
+	for _, candidate := range candidates {
+		if strings.EqualFold(strings.TrimSpace(candidate), value) {
+			return true
+		}
+	}
+	return false
+}
+
+// anyEqualsAnyFold reports whether any value equals any candidate, ignoring case
+func anyEqualsAnyFold(values []string, candidates []string) bool {
+	for _, value := range values {
+		if equalsAnyFold(value, candidates) {
+			return true
+		}
+	}
+	return false
+}
+
+// inHourWindow reports whether hour falls in [from, to), wrapping past midnight when from > to
+func inHourWindow(hour, from, to int) bool {
+	if from <= to {
+		return hour >= from && hour < to
+	}
+	return hour >= from || hour < to
+}
+
+// matchesWeekday reports whether day satisfies any of the weekday specs
+func matchesWeekday(day time.Weekday, specs []string) bool {
+	for _, spec := range specs {
+		days, ok := parseWeekdaySpec(spec)
+		if !ok {
+			continue
+		}
+		for _, d := range days {
+			if d == day {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parseWeekdaySpec expands a day name or "weekday"/"weekend" into weekdays
+func parseWeekdaySpec(spec string) ([]time.Weekday, bool) {
+	spec = strings.ToLower(strings.TrimSpace(spec))
+	switch spec {
+	case "weekday", "weekdays":
+		return []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}, true
+	case "weekend", "weekends":
+		return []time.Weekday{time.Saturday, time.Sunday}, true
+	}
+
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		name := strings.ToLower(d.String())
+		if spec == name || spec == name[:3] {
+			return []time.Weekday{d}, true
+		}
+	}
+	return nil, false
+}
+
+// Helper functions for time context
+
+// TimeOfDay names the daypart of t: morning, afternoon, evening or night
+func TimeOfDay(t time.Time) string {
+	hour := t.Hour()
+	switch {
+	case hour >= 5 && hour < 12:
+		return "morning"
+	case hour >= 12 && hour < 17:
+		return "afternoon"
+	case hour >= 17 && hour < 22:
+		return "evening"
+	default:
+		return "night"
+	}
+}
+
+// DaypartEnd returns when the daypart containing t ends, using the same
+// boundaries as TimeOfDay (05:00, 12:00, 17:00 and 22:00)
+func DaypartEnd(t time.Time) time.Time {
+	for _, hour := range []int{5, 12, 17, 22, 24 + 5} {
+		end := time.Date(t.Year(), t.Month(), t.Day(), hour, 0, 0, 0, t.Location())
+		if end.After(t) {
+			return end
+		}
+	}
+	// Unreachable: 05:00 tomorrow is always after t
+	return t
+}
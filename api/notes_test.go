@@ -0,0 +1,698 @@
+package api
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"myrcast/internal/calendar"
+)
+
+// notesTestWeather returns mild weather that triggers few conditional rules
+func notesTestWeather() *TodayWeatherData {
+	return &TodayWeatherData{
+		TempHigh:          65,
+		TempLow:           50,
+		CurrentTemp:       60,
+		CurrentConditions: "scattered clouds",
+		RainChance:        0.4,
+		WindConditions:    "Light breeze",
+		WindSpeed:         5,
+		Units:             "imperial",
+		Location:          "Portland, OR",
+	}
+}
+
+// mustNotesContext builds a notes context or fails the test
+func mustNotesContext(t *testing.T, data *TodayWeatherData, now time.Time, opts NotesOptions) NotesContext {
+	t.Helper()
+	ctx, err := NewNotesContext(data, now, opts)
+	if err != nil {
+		t.Fatalf("Failed to build notes context: %v", err)
+	}
+	return ctx
+}
+
+func containsNote(notes []string, substr string) bool {
+	for _, note := range notes {
+		if strings.Contains(note, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestDefaultNotesRuleSet(t *testing.T) {
+	ruleSet, err := DefaultNotesRuleSet()
+	if err != nil {
+		t.Fatalf("Built-in notes rules failed to load: %v", err)
+	}
+	if ruleSet.Source != "built-in" {
+		t.Errorf("Expected source built-in, got %s", ruleSet.Source)
+	}
+	if len(ruleSet.Rules) == 0 {
+		t.Fatal("Expected built-in rules")
+	}
+
+	// The exported TOML must round-trip into the same rule set
+	reparsed, err := ParseNotesRuleSet(DefaultNotesRulesTOML(), "export")
+	if err != nil {
+		t.Fatalf("Exported default rules failed to parse: %v", err)
+	}
+	if len(reparsed.Rules) != len(ruleSet.Rules) {
+		t.Errorf("Expected %d rules after round-trip, got %d", len(ruleSet.Rules), len(reparsed.Rules))
+	}
+}
+
+func TestDefaultNotesRainBands(t *testing.T) {
+	ruleSet, err := DefaultNotesRuleSet()
+	if err != nil {
+		t.Fatalf("Failed to load rules: %v", err)
+	}
+	now := time.Date(2025, 10, 15, 13, 0, 0, 0, time.UTC) // Wednesday afternoon
+
+	tests := []struct {
+		name     string
+		pop      float64
+		expected string
+		excluded []string
+	}{
+		{"very likely", 0.9, "Rain is very likely", []string{"Rain is likely", "High rain probability"}},
+		{"likely", 0.75, "Rain is likely", []string{"Rain is very likely", "High rain probability"}},
+		{"possible", 0.6, "High rain probability", []string{"Rain is likely", "Rain is unlikely"}},
+		{"unlikely", 0.1, "Rain is unlikely", []string{"Rain is likely", "High rain probability"}},
+		{"middle band", 0.4, "", []string{"Rain is", "High rain probability"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := notesTestWeather()
+			data.RainChance = tt.pop
+			notes := ruleSet.Evaluate(mustNotesContext(t, data, now, NotesOptions{}))
+
+			if tt.expected != "" && !containsNote(notes, tt.expected) {
+				t.Errorf("Expected note containing %q, got %v", tt.expected, notes)
+			}
+			for _, excluded := range tt.excluded {
+				if containsNote(notes, excluded) {
+					t.Errorf("Did not expect note containing %q, got %v", excluded, notes)
+				}
+			}
+		})
+	}
+}
+
+func TestDefaultNotesDaypart(t *testing.T) {
+	ruleSet, err := DefaultNotesRuleSet()
+	if err != nil {
+		t.Fatalf("Failed to load rules: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		expected []string
+		excluded []string
+	}{
+		{
+			name:     "weekday morning commute",
+			now:      time.Date(2025, 10, 15, 7, 30, 0, 0, time.UTC),
+			expected: []string{"Morning commute time", "Peak listening time"},
+			excluded: []string{"Weekend broadcast", "Off-peak hours"},
+		},
+		{
+			name:     "weekend morning",
+			now:      time.Date(2025, 10, 18, 7, 30, 0, 0, time.UTC),
+			expected: []string{"Weekend broadcast"},
+			excluded: []string{"Morning commute time", "Peak listening time"},
+		},
+		{
+			name:     "weekday business hours",
+			now:      time.Date(2025, 10, 15, 11, 0, 0, 0, time.UTC),
+			expected: []string{"Business hours"},
+			excluded: []string{"Peak listening time", "Off-peak hours"},
+		},
+		{
+			name:     "weekday late night",
+			now:      time.Date(2025, 10, 15, 23, 0, 0, 0, time.UTC),
+			expected: []string{"Off-peak hours"},
+			excluded: []string{"Business hours", "Peak listening time"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notes := ruleSet.Evaluate(mustNotesContext(t, notesTestWeather(), tt.now, NotesOptions{}))
+			for _, expected := range tt.expected {
+				if !containsNote(notes, expected) {
+					t.Errorf("Expected note containing %q, got %v", expected, notes)
+				}
+			}
+			for _, excluded := range tt.excluded {
+				if containsNote(notes, excluded) {
+					t.Errorf("Did not expect note containing %q, got %v", excluded, notes)
+				}
+			}
+		})
+	}
+}
+
+func TestNotesHolidayPlaceholder(t *testing.T) {
+	ruleSet, err := DefaultNotesRuleSet()
+	if err != nil {
+		t.Fatalf("Failed to load rules: %v", err)
+	}
+
+	// Named holiday renders {holiday}
+	christmas := time.Date(2025, 12, 25, 10, 0, 0, 0, time.UTC)
+	notes := ruleSet.Evaluate(mustNotesContext(t, notesTestWeather(), christmas, NotesOptions{}))
+	if !containsNote(notes, "Holiday broadcast for Christmas") {
+		t.Errorf("Expected named holiday note, got %v", notes)
+	}
+	if containsNote(notes, "Holiday period") {
+		t.Errorf("Expected only one holiday note, got %v", notes)
+	}
+
+	// A holiday without a name leaves {holiday} empty, so the note is skipped
+	ctx := mustNotesContext(t, notesTestWeather(), christmas, NotesOptions{})
+	ctx.Holiday = ""
+	notes = ruleSet.Evaluate(ctx)
+	if containsNote(notes, "Holiday broadcast for") {
+		t.Errorf("Did not expect named holiday note without a name, got %v", notes)
+	}
+}
+
+// TestNotesRangeNeedsReadings tests that the temperature swing note waits for
+// a current temperature and a high, as a zero reading means missing data
+func TestNotesRangeNeedsReadings(t *testing.T) {
+	ruleSet, err := DefaultNotesRuleSet()
+	if err != nil {
+		t.Fatalf("Failed to load rules: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		current  float64
+		high     float64
+		low      float64
+		expected bool
+	}{
+		{name: "Full readings", current: 60, high: 80, low: 45, expected: true},
+		{name: "Small swing", current: 60, high: 65, low: 50, expected: false},
+		{name: "No current temperature", current: 0, high: 80, low: 45, expected: false},
+		{name: "No high", current: -5, high: 0, low: -30, expected: false},
+	}
+
+	now := time.Date(2025, 10, 15, 12, 0, 0, 0, time.UTC)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := notesTestWeather()
+			data.CurrentTemp, data.TempHigh, data.TempLow = tt.current, tt.high, tt.low
+			notes := ruleSet.Evaluate(mustNotesContext(t, data, now, NotesOptions{}))
+			if got := containsNote(notes, "Large temperature swing"); got != tt.expected {
+				t.Errorf("Swing note = %v, want %v (notes %v)", got, tt.expected, notes)
+			}
+		})
+	}
+}
+
+func TestNotesHolidayCalendar(t *testing.T) {
+	rules := `
+[[rule]]
+name = "boxing_day"
+note = "Boxing Day sales traffic"
+[rule.when]
+holidays = ["Boxing Day"]
+`
+	ruleSet, err := ParseNotesRuleSet([]byte(rules), "test.toml")
+	if err != nil {
+		t.Fatalf("Failed to parse rules: %v", err)
+	}
+
+	ukCalendar, err := calendar.New("UK")
+	if err != nil {
+		t.Fatalf("Failed to create calendar: %v", err)
+	}
+	boxingDay := time.Date(2025, 12, 26, 8, 0, 0, 0, time.UTC)
+
+	ctx := mustNotesContext(t, notesTestWeather(), boxingDay, NotesOptions{Calendar: ukCalendar})
+	if !ctx.IsHoliday || ctx.Holiday != "Boxing Day" {
+		t.Errorf("Expected Boxing Day holiday, got %q (holiday=%v)", ctx.Holiday, ctx.IsHoliday)
+	}
+	if notes := ruleSet.Evaluate(ctx); len(notes) != 1 {
+		t.Errorf("Expected Boxing Day note, got %v", notes)
+	}
+
+	// The default US calendar has no Boxing Day
+	ctx = mustNotesContext(t, notesTestWeather(), boxingDay, NotesOptions{})
+	if ctx.IsHoliday {
+		t.Errorf("Expected no US holiday on December 26, got %v", ctx.Holidays)
+	}
+	if notes := ruleSet.Evaluate(ctx); len(notes) != 0 {
+		t.Errorf("Expected no notes, got %v", notes)
+	}
+}
+
+func TestNotesDuplicateSuppression(t *testing.T) {
+	ruleSet, err := DefaultNotesRuleSet()
+	if err != nil {
+		t.Fatalf("Failed to load rules: %v", err)
+	}
+
+	data := notesTestWeather()
+	data.TempHigh = 95
+	data.CurrentTemp = 92
+	notes := ruleSet.Evaluate(mustNotesContext(t, data, time.Date(2025, 7, 16, 14, 0, 0, 0, time.UTC), NotesOptions{}))
+
+	count := 0
+	for _, note := range notes {
+		if strings.HasPrefix(note, "Hot day") {
+			count++
+		}
+	}
+	if count != 1 {
+		t.Errorf("Expected exactly one hot day note, got %d in %v", count, notes)
+	}
+}
+
+func TestParseNotesRuleSet(t *testing.T) {
+	tests := []struct {
+		name        string
+		rules       string
+		expectError string
+	}{
+		{
+			name: "valid rule",
+			rules: `
+[[rule]]
+name = "windy"
+note = "Windy in {location}"
+[rule.when]
+wind_above = 20
+hours = [22, 4]
+weekdays = ["monday", "weekend"]
+`,
+		},
+		{
+			name: "unknown condition key",
+			rules: `
+[[rule]]
+note = "Typo"
+[rule.when]
+temp_abov = 80
+`,
+			expectError: "temp_abov",
+		},
+		{
+			name: "missing note",
+			rules: `
+[[rule]]
+name = "empty"
+`,
+			expectError: "note is required",
+		},
+		{
+			name: "unknown placeholder",
+			rules: `
+[[rule]]
+note = "Welcome to {city}"
+`,
+			expectError: "unknown placeholder {city}",
+		},
+		{
+			name: "bad hour window",
+			rules: `
+[[rule]]
+note = "Late"
+[rule.when]
+hours = [25]
+`,
+			expectError: "hours",
+		},
+		{
+			name: "bad weekday",
+			rules: `
+[[rule]]
+note = "Funday"
+[rule.when]
+weekdays = ["funday"]
+`,
+			expectError: "funday",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseNotesRuleSet([]byte(tt.rules), "test.toml")
+			if tt.expectError == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Expected error containing %q, got nil", tt.expectError)
+			}
+			if !strings.Contains(err.Error(), tt.expectError) {
+				t.Errorf("Expected error containing %q, got %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestNotesConditions(t *testing.T) {
+	rules := `
+[[rule]]
+name = "overnight"
+note = "Overnight"
+[rule.when]
+hours = [22, 4]
+
+[[rule]]
+name = "stormy"
+note = "Stormy {weekday} in {location}"
+[rule.when]
+alerts = true
+[[rule.when.any]]
+wind_above = 25
+[[rule.when.any]]
+conditions_contains = ["thunder"]
+
+[[rule]]
+name = "metric_only"
+note = "Metric"
+[rule.when]
+units = ["metric"]
+`
+	ruleSet, err := ParseNotesRuleSet([]byte(rules), "test.toml")
+	if err != nil {
+		t.Fatalf("Failed to parse rules: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		modify   func(*TodayWeatherData)
+		expected []string
+	}{
+		{
+			name:     "hour window wraps midnight",
+			now:      time.Date(2025, 10, 15, 2, 0, 0, 0, time.UTC),
+			expected: []string{"Overnight"},
+		},
+		{
+			name:     "outside hour window",
+			now:      time.Date(2025, 10, 15, 12, 0, 0, 0, time.UTC),
+			expected: nil,
+		},
+		{
+			name: "any condition with placeholders",
+			now:  time.Date(2025, 10, 15, 12, 0, 0, 0, time.UTC),
+			modify: func(d *TodayWeatherData) {
+				d.WeatherAlerts = []string{"Thunderstorm"}
+				d.CurrentConditions = "Thunderstorm with heavy rain"
+			},
+			expected: []string{"Stormy Wednesday in Portland, OR"},
+		},
+		{
+			name: "alerts without any match",
+			now:  time.Date(2025, 10, 15, 12, 0, 0, 0, time.UTC),
+			modify: func(d *TodayWeatherData) {
+				d.WeatherAlerts = []string{"Fog"}
+			},
+			expected: nil,
+		},
+		{
+			name: "units filter",
+			now:  time.Date(2025, 10, 15, 12, 0, 0, 0, time.UTC),
+			modify: func(d *TodayWeatherData) {
+				d.Units = "metric"
+			},
+			expected: []string{"Metric"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := notesTestWeather()
+			if tt.modify != nil {
+				tt.modify(data)
+			}
+			notes := ruleSet.Evaluate(mustNotesContext(t, data, tt.now, NotesOptions{}))
+			if strings.Join(notes, "|") != strings.Join(tt.expected, "|") {
+				t.Errorf("Expected notes %v, got %v", tt.expected, notes)
+			}
+		})
+	}
+}
+
+func TestLoadNotesRuleSet(t *testing.T) {
+	// Empty path uses the built-in rules
+	ruleSet, err := LoadNotesRuleSet("")
+	if err != nil {
+		t.Fatalf("Expected built-in rules, got error: %v", err)
+	}
+	if ruleSet.Source != "built-in" {
+		t.Errorf("Expected built-in source, got %s", ruleSet.Source)
+	}
+
+	// Custom file replaces the built-in rules entirely
+	rulesPath := filepath.Join(t.TempDir(), "notes.toml")
+	custom := "[[rule]]\nname = \"station_id\"\nnote = \"Mention the station ID\"\n"
+	if err := os.WriteFile(rulesPath, []byte(custom), 0644); err != nil {
+		t.Fatalf("Failed to write rules file: %v", err)
+	}
+
+	ruleSet, err = LoadNotesRuleSet(rulesPath)
+	if err != nil {
+		t.Fatalf("Failed to load custom rules: %v", err)
+	}
+	notes := ruleSet.Evaluate(mustNotesContext(t, notesTestWeather(), time.Now(), NotesOptions{}))
+	if len(notes) != 1 || notes[0] != "Mention the station ID" {
+		t.Errorf("Expected only the custom note, got %v", notes)
+	}
+
+	// Missing file is an error
+	if _, err := LoadNotesRuleSet(filepath.Join(t.TempDir(), "missing.toml")); err == nil {
+		t.Error("Expected error for missing rules file")
+	}
+}
+
+func TestClaudeClientUsesConfiguredNotesRules(t *testing.T) {
+	ruleSet, err := ParseNotesRuleSet([]byte("[[rule]]\nnote = \"Custom station note\"\n"), "test.toml")
+	if err != nil {
+		t.Fatalf("Failed to parse rules: %v", err)
+	}
+
+	client := &ClaudeClient{config: ClaudeConfig{NotesRules: ruleSet}}
+	notes, err := client.generateContextualBroadcastNotes(notesTestWeather(), time.Now())
+	if err != nil {
+		t.Fatalf("Failed to generate notes: %v", err)
+	}
+	if len(notes) != 1 || notes[0] != "Custom station note" {
+		t.Errorf("Expected configured notes only, got %v", notes)
+	}
+}
+
+func TestNotesThresholdUnits(t *testing.T) {
+	ruleSet, err := DefaultNotesRuleSet()
+	if err != nil {
+		t.Fatalf("Failed to load rules: %v", err)
+	}
+	winterMorning := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	summerAfternoon := time.Date(2025, 7, 16, 14, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		data     TodayWeatherData
+		expected []string
+		excluded []string
+	}{
+		{
+			name:     "metric warm winter day is not freezing",
+			now:      winterMorning,
+			data:     TodayWeatherData{CurrentTemp: 31, TempHigh: 33, TempLow: 25, Units: "metric"},
+			expected: []string{"Hot day"},
+			excluded: []string{"Cold weather alert", "Freezing temperatures"},
+		},
+		{
+			name:     "metric below zero is freezing",
+			now:      winterMorning,
+			data:     TodayWeatherData{CurrentTemp: -2, TempHigh: 1, TempLow: -5, Units: "metric"},
+			expected: []string{"Cold weather alert", "Freezing temperatures"},
+		},
+		{
+			name:     "imperial freezing",
+			now:      winterMorning,
+			data:     TodayWeatherData{CurrentTemp: 28, TempHigh: 30, TempLow: 20, Units: "imperial"},
+			expected: []string{"Cold weather alert", "Freezing temperatures"},
+		},
+		{
+			name:     "metric hot summer",
+			now:      summerAfternoon,
+			data:     TodayWeatherData{CurrentTemp: 30, TempHigh: 31, TempLow: 24, Units: "metric"},
+			expected: []string{"Hot day"},
+		},
+		{
+			name:     "metric range converts as a difference",
+			now:      summerAfternoon,
+			data:     TodayWeatherData{CurrentTemp: 20, TempHigh: 26, TempLow: 10, Units: "metric"},
+			expected: []string{"Large temperature swing"},
+		},
+		{
+			name:     "metric small range",
+			now:      summerAfternoon,
+			data:     TodayWeatherData{CurrentTemp: 20, TempHigh: 24, TempLow: 12, Units: "metric"},
+			excluded: []string{"Large temperature swing"},
+		},
+		{
+			name:     "kelvin freezing",
+			now:      winterMorning,
+			data:     TodayWeatherData{CurrentTemp: 270, TempHigh: 272, TempLow: 265, Units: "kelvin"},
+			expected: []string{"Freezing temperatures"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := tt.data
+			data.RainChance = 0.4
+			notes := ruleSet.Evaluate(mustNotesContext(t, &data, tt.now, NotesOptions{}))
+			for _, expected := range tt.expected {
+				if !containsNote(notes, expected) {
+					t.Errorf("Expected note containing %q, got %v", expected, notes)
+				}
+			}
+			for _, excluded := range tt.excluded {
+				if containsNote(notes, excluded) {
+					t.Errorf("Did not expect note containing %q, got %v", excluded, notes)
+				}
+			}
+		})
+	}
+}
+
+func TestNotesWithClimate(t *testing.T) {
+	ruleSet, err := DefaultNotesRuleSet()
+	if err != nil {
+		t.Fatalf("Failed to load rules: %v", err)
+	}
+
+	// Phoenix: 95°F is an ordinary summer day
+	phoenix, err := ruleSet.WithClimate("imperial", map[string]float64{"hot": 105})
+	if err != nil {
+		t.Fatalf("Failed to apply climate: %v", err)
+	}
+	data := notesTestWeather()
+	data.TempHigh = 95
+	data.CurrentTemp = 90
+	now := time.Date(2025, 7, 16, 14, 0, 0, 0, time.UTC)
+
+	if notes := phoenix.Evaluate(mustNotesContext(t, data, now, NotesOptions{})); containsNote(notes, "Hot day") {
+		t.Errorf("Expected no hot day note with Phoenix climate, got %v", notes)
+	}
+	if notes := ruleSet.Evaluate(mustNotesContext(t, data, now, NotesOptions{})); !containsNote(notes, "Hot day") {
+		t.Errorf("Expected hot day note with default climate, got %v", notes)
+	}
+
+	// Overrides are converted from their own units, and the shared defaults are untouched
+	seattle, err := ruleSet.WithClimate("metric", map[string]float64{"hot": 25})
+	if err != nil {
+		t.Fatalf("Failed to apply climate: %v", err)
+	}
+	if hot := seattle.Thresholds["hot"]; hot < 76.9 || hot > 77.1 {
+		t.Errorf("Expected hot threshold of 77°F, got %.2f", hot)
+	}
+	if ruleSet.Thresholds["hot"] != 85 {
+		t.Errorf("Expected default hot threshold to stay 85, got %.2f", ruleSet.Thresholds["hot"])
+	}
+
+	// Range thresholds are differences: a 10°C swing is 18°F
+	swing, err := ruleSet.WithClimate("metric", map[string]float64{"large_swing": 10, "cool": 10})
+	if err != nil {
+		t.Fatalf("Failed to apply climate: %v", err)
+	}
+	if large := swing.Thresholds["large_swing"]; large < 17.9 || large > 18.1 {
+		t.Errorf("Expected large_swing threshold of 18°F, got %.2f", large)
+	}
+	if cool := swing.Thresholds["cool"]; cool < 49.9 || cool > 50.1 {
+		t.Errorf("Expected cool threshold of 50°F, got %.2f", cool)
+	}
+
+	if _, err := ruleSet.WithClimate("imperial", map[string]float64{"scorching": 110}); err == nil ||
+		!strings.Contains(err.Error(), "scorching") {
+		t.Errorf("Expected unknown threshold error, got %v", err)
+	}
+}
+
+func TestNotesThresholdValidation(t *testing.T) {
+	tests := []struct {
+		name        string
+		rules       string
+		expectError string
+	}{
+		{
+			name:        "undefined threshold name",
+			rules:       "[[rule]]\nnote = \"Hot\"\n[rule.when]\nhigh_above = \"scorching\"\n",
+			expectError: "high_above must be a number or a defined threshold name",
+		},
+		{
+			name:        "unknown rule set units",
+			rules:       "units = \"celsius\"\n[[rule]]\nnote = \"Test\"\n",
+			expectError: "units must be one of",
+		},
+		{
+			name:  "metric rule set with named threshold",
+			rules: "units = \"metric\"\n[thresholds]\nhot = 30\n[[rule]]\nnote = \"Hot\"\n[rule.when]\nhigh_above = \"hot\"\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseNotesRuleSet([]byte(tt.rules), "test.toml")
+			if tt.expectError == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+				t.Errorf("Expected error containing %q, got %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+// TestDaypartEnd tests when each daypart ends, including the overnight wrap
+func TestDaypartEnd(t *testing.T) {
+	zone := time.FixedZone("HST", -10*3600)
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, 3, day, hour, minute, 0, 0, zone)
+	}
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want time.Time
+	}{
+		{"early morning", at(14, 5, 55), at(14, 12, 0)},
+		{"afternoon", at(14, 12, 0), at(14, 17, 0)},
+		{"evening", at(14, 21, 59), at(14, 22, 0)},
+		{"late night", at(14, 23, 30), at(15, 5, 0)},
+		{"after midnight", at(15, 2, 0), at(15, 5, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DaypartEnd(tt.now)
+			if !got.Equal(tt.want) {
+				t.Errorf("DaypartEnd(%v) = %v, want %v", tt.now, got, tt.want)
+			}
+			if TimeOfDay(got.Add(-time.Minute)) != TimeOfDay(tt.now) {
+				t.Errorf("Daypart changed before %v", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Season modes for broadcast notes
+const (
+	SeasonModeMeteorological = "meteorological" // Whole months: Dec-Feb, Mar-May, Jun-Aug, Sep-Nov
+	SeasonModeAstronomical   = "astronomical"   // Solstices and equinoxes
+)
+
+// SeasonModes lists the supported season modes
+var SeasonModes = []string{SeasonModeMeteorological, SeasonModeAstronomical}
+
+// astronomicalSeasonStarts are approximate northern-hemisphere start dates;
+// the exact instant drifts by a day between years, which is fine for radio copy
+var astronomicalSeasonStarts = []struct {
+	month  time.Month
+	day    int
+	season string
+}{
+	{time.March, 20, "spring"},
+	{time.June, 21, "summer"},
+	{time.September, 22, "fall"},
+	{time.December, 21, "winter"},
+}
+
+// oppositeSeason maps northern seasons to their southern-hemisphere equivalent
+var oppositeSeason = map[string]string{
+	"winter": "summer",
+	"spring": "fall",
+	"summer": "winter",
+	"fall":   "spring",
+}
+
+// SeasonAt returns the season for a date at a latitude. Southern latitudes get
+// the opposite season; an empty mode means meteorological seasons.
+func SeasonAt(t time.Time, latitude float64, mode string) (string, error) {
+	var season string
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case "", SeasonModeMeteorological:
+		season = meteorologicalSeason(t)
+	case SeasonModeAstronomical:
+		season = astronomicalSeason(t)
+	default:
+		return "", fmt.Errorf("unknown season mode %q (valid: %s)", mode, strings.Join(SeasonModes, ", "))
+	}
+
+	if latitude < 0 {
+		season = oppositeSeason[season]
+	}
+	return season, nil
+}
+
+// meteorologicalSeason returns the northern-hemisphere season by whole months
+func meteorologicalSeason(t time.Time) string {
+	month := t.Month()
+	switch {
+	case month >= 3 && month <= 5:
+		return "spring"
+	case month >= 6 && month <= 8:
+		return "summer"
+	case month >= 9 && month <= 11:
+		return "fall"
+	default:
+		return "winter"
+	}
+}
+
+// astronomicalSeason returns the northern-hemisphere season by solstice and equinox
+func astronomicalSeason(t time.Time) string {
+	season := "winter" // January 1 through the March equinox
+	for _, start := range astronomicalSeasonStarts {
+		if t.Month() > start.month || (t.Month() == start.month && t.Day() >= start.day) {
+			season = start.season
+		}
+	}
+	return season
+}
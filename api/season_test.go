@@ -0,0 +1,42 @@
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSeasonAt(t *testing.T) {
+	tests := []struct {
+		name     string
+		date     time.Time
+		latitude float64
+		mode     string
+		expected string
+	}{
+		{"northern January", time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC), 47.6, "", "winter"},
+		{"northern July", time.Date(2025, 7, 15, 12, 0, 0, 0, time.UTC), 47.6, SeasonModeMeteorological, "summer"},
+		{"southern January", time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC), -33.9, "", "summer"},
+		{"southern April", time.Date(2025, 4, 15, 12, 0, 0, 0, time.UTC), -33.9, "", "fall"},
+		{"astronomical early March", time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC), 40.7, SeasonModeAstronomical, "winter"},
+		{"astronomical equinox", time.Date(2025, 3, 20, 12, 0, 0, 0, time.UTC), 40.7, SeasonModeAstronomical, "spring"},
+		{"astronomical early December", time.Date(2025, 12, 10, 12, 0, 0, 0, time.UTC), 40.7, SeasonModeAstronomical, "fall"},
+		{"astronomical late December", time.Date(2025, 12, 22, 12, 0, 0, 0, time.UTC), 40.7, SeasonModeAstronomical, "winter"},
+		{"astronomical southern June", time.Date(2025, 6, 25, 12, 0, 0, 0, time.UTC), -37.8, SeasonModeAstronomical, "winter"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			season, err := SeasonAt(tt.date, tt.latitude, tt.mode)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if season != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, season)
+			}
+		})
+	}
+
+	if _, err := SeasonAt(time.Now(), 0, "solar"); err == nil {
+		t.Error("Expected error for unknown season mode")
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/go-resty/resty/v2"
 	"myrcast/internal/logger"
+	"myrcast/internal/runstats"
 )
 
 const (
@@ -20,6 +21,7 @@ const (
 	forecastEndpoint   = "/forecast"
 	weatherEndpoint    = "/weather"
 	reverseGeoEndpoint = "/reverse"
+	directGeoEndpoint  = "/direct"
 
 	// Default timeout for API requests
 	defaultTimeout = 10 * time.Second
@@ -34,16 +36,34 @@ type WeatherClient struct {
 	apiKey string
 }
 
+// restyLogger sends resty's own messages (retry attempts, transport errors)
+// through the application logger, so they share its format and run ID
+type restyLogger struct{}
+
+func (restyLogger) Errorf(format string, v ...interface{}) {
+	logger.Error("%s", strings.TrimSpace(fmt.Sprintf(format, v...)))
+}
+
+func (restyLogger) Warnf(format string, v ...interface{}) {
+	logger.Warn("%s", strings.TrimSpace(fmt.Sprintf(format, v...)))
+}
+
+func (restyLogger) Debugf(format string, v ...interface{}) {
+	logger.Debug("%s", strings.TrimSpace(fmt.Sprintf(format, v...)))
+}
+
 // NewWeatherClient creates a new OpenWeather API client with authentication
 func NewWeatherClient(apiKey string) *WeatherClient {
 	// AIDEV-NOTE: Using go-resty for cleaner HTTP client setup with built-in JSON handling
 	client := resty.New().
 		SetBaseURL(openWeatherBaseURL).
 		SetHeader("User-Agent", userAgent).
+		SetHeader(logger.RunIDHeader, logger.RunID()).
 		SetTimeout(defaultTimeout).
 		SetRetryCount(3).
 		SetRetryWaitTime(1 * time.Second).
-		SetRetryMaxWaitTime(5 * time.Second)
+		SetRetryMaxWaitTime(5 * time.Second).
+		SetLogger(restyLogger{})
 
 	// Add debug logging for development
 	client.OnBeforeRequest(func(c *resty.Client, req *resty.Request) error {
@@ -58,10 +78,19 @@ func NewWeatherClient(apiKey string) *WeatherClient {
 		return nil
 	})
 
+	// Retry hooks also run after the final attempt, which is not followed by a retry
+	client.AddRetryHook(func(resp *resty.Response, err error) {
+		if resp != nil && resp.Request != nil && resp.Request.Attempt > client.RetryCount {
+			return
+		}
+		runstats.Retry(runstats.ProviderOpenWeather)
+	})
+
 	client.OnAfterResponse(func(c *resty.Client, resp *resty.Response) error {
 		duration := resp.Time().String()
 		bodySize := len(resp.Body())
 		logger.LogAPIResponse(resp.Request.Method, resp.Request.URL, resp.StatusCode(), duration, bodySize)
+		runstats.HTTPStatus(runstats.ProviderOpenWeather, resp.StatusCode())
 		return nil
 	})
 
@@ -319,11 +348,14 @@ type TodayWeatherData struct {
 	CurrentConditions string    `json:"current_conditions"` // Current weather description
 	RainChance        float64   `json:"rain_chance"`        // Maximum precipitation probability
 	WindConditions    string    `json:"wind_conditions"`    // Wind speed and direction description
+	WindSpeed         float64   `json:"wind_speed"`         // Current wind speed in the data's units
 	WeatherAlerts     []string  `json:"weather_alerts"`     // Notable weather conditions
 	LastUpdated       time.Time `json:"last_updated"`       // When data was processed
 	Units             string    `json:"units"`              // Unit system used
 	Location          string    `json:"location"`           // Location name
 	Country           string    `json:"country"`            // Country code
+	Timezone          string    `json:"timezone"`           // IANA zone of the location (e.g. Pacific/Honolulu)
+	TimezoneOffset    int       `json:"timezone_offset"`    // UTC offset in seconds, used if the zone is unknown
 }
 
 // isNotableWeatherCondition determines if a weather condition should be included in alerts
@@ -428,6 +460,12 @@ func ConvertTemperature(temp float64, fromUnit, toUnit string) float64 {
 	}
 }
 
+// ConvertTemperatureDifference converts a temperature difference, such as a
+// daily range, between unit systems. Unlike ConvertTemperature it applies no offset.
+func ConvertTemperatureDifference(difference float64, fromUnit, toUnit string) float64 {
+	return ConvertTemperature(difference, fromUnit, toUnit) - ConvertTemperature(0, fromUnit, toUnit)
+}
+
 // ConvertWindSpeed converts wind speed between different unit systems
 func ConvertWindSpeed(speed float64, fromUnit, toUnit string) float64 {
 	// AIDEV-NOTE: Wind speed conversion between m/s, mph, and km/h
@@ -488,6 +526,11 @@ func ConvertPressure(pressure float64, fromUnit, toUnit string) float64 {
 
 // ConvertWeatherData converts all weather measurements to the specified unit system
 func (w *WeatherClient) ConvertWeatherData(data *TodayWeatherData, targetUnits string) *TodayWeatherData {
+	return convertWeatherData(data, targetUnits)
+}
+
+// convertWeatherData returns a copy of data in targetUnits (data itself when already there)
+func convertWeatherData(data *TodayWeatherData, targetUnits string) *TodayWeatherData {
 	if data == nil || data.Units == targetUnits {
 		return data
 	}
@@ -498,11 +541,15 @@ func (w *WeatherClient) ConvertWeatherData(data *TodayWeatherData, targetUnits s
 		TempLow:           ConvertTemperature(data.TempLow, data.Units, targetUnits),
 		CurrentTemp:       ConvertTemperature(data.CurrentTemp, data.Units, targetUnits),
 		CurrentConditions: data.CurrentConditions,
-		RainChance:        data.RainChance,                           // Percentage stays the same
-		WeatherAlerts:     append([]string{}, data.WeatherAlerts...), // Copy slice
+		RainChance:        data.RainChance,                                           // Percentage stays the same
+		WindSpeed:         ConvertWindSpeed(data.WindSpeed, data.Units, targetUnits), // Matches wind description
+		WeatherAlerts:     append([]string{}, data.WeatherAlerts...),                 // Copy slice
 		LastUpdated:       data.LastUpdated,
 		Units:             targetUnits,
 		Location:          data.Location,
+		Country:           data.Country,
+		Timezone:          data.Timezone,
+		TimezoneOffset:    data.TimezoneOffset,
 	}
 
 	// Convert wind conditions description if it contains numerical values
@@ -837,11 +884,14 @@ func (w *WeatherClientWithRateLimit) GetTodayWeatherWithOneCallCache(ctx context
 						CurrentConditions: "",
 						RainChance:        0.0,
 						WindConditions:    "",
+						WindSpeed:         oneCall.Current.WindSpeed,
 						WeatherAlerts:     []string{},
 						LastUpdated:       time.Now(),
 						Units:             params.Units,
 						Location:          cache.Location,
 						Country:           cache.DailyForecast.Country, // Cached country
+						Timezone:          oneCall.Timezone,
+						TimezoneOffset:    oneCall.TimezoneOffset,
 					}
 
 					// Extract fresh current conditions
@@ -874,6 +924,7 @@ func (w *WeatherClientWithRateLimit) GetTodayWeatherWithOneCallCache(ctx context
 					}
 
 					logger.Debug("Successfully used cached daily values with fresh current conditions")
+					runstats.CacheLookup(true)
 					complete(nil)
 					return todayData, oneCall, nil
 				}
@@ -886,6 +937,9 @@ func (w *WeatherClientWithRateLimit) GetTodayWeatherWithOneCallCache(ctx context
 	}
 
 	// Fallback: fetch complete fresh data
+	if cacheManager != nil {
+		runstats.CacheLookup(false)
+	}
 	logger.Debug("Fetching fresh One Call weather data")
 	oneCall, err := w.GetOneCallWeatherWithRateLimit(ctx, params)
 	if err != nil {
@@ -927,6 +981,8 @@ type GeocodingResponse struct {
 	LocalNames map[string]string `json:"local_names,omitempty"`
 	Country    string            `json:"country"`
 	State      string            `json:"state,omitempty"`
+	Lat        float64           `json:"lat"`
+	Lon        float64           `json:"lon"`
 }
 
 // LocationInfo holds geocoded location details
@@ -1002,3 +1058,38 @@ func (w *WeatherClient) GetLocationInfo(ctx context.Context, lat, lon float64) L
 	logger.Debug("Reverse geocoding successful: %s (Country: %s)", info.Display, info.Country)
 	return info
 }
+
+// SearchLocations looks up places by name ("Springfield, IL, US") with direct
+// geocoding. Unlike reverse geocoding, failures are returned so callers can tell
+// a bad API key from a place that does not exist.
+func (w *WeatherClient) SearchLocations(ctx context.Context, query string, limit int) ([]GeocodingResponse, error) {
+	complete := logger.LogOperationStart("weather_api_geocode", map[string]any{
+		"endpoint": "direct",
+		"query":    query,
+	})
+
+	var results []GeocodingResponse
+	resp, err := w.client.R().
+		SetContext(ctx).
+		SetQueryParams(map[string]string{
+			"q":     query,
+			"limit": fmt.Sprintf("%d", limit),
+			"appid": w.apiKey,
+		}).
+		SetHeader("User-Agent", userAgent).
+		SetResult(&results).
+		Get(geocodingBaseURL + directGeoEndpoint)
+	if err != nil {
+		complete(fmt.Errorf("HTTP request failed: %w", err))
+		return nil, fmt.Errorf("failed to search locations: %w", err)
+	}
+
+	if !resp.IsSuccess() {
+		apiErr := parseOpenWeatherError(resp)
+		complete(apiErr)
+		return nil, apiErr
+	}
+
+	complete(nil)
+	return results, nil
+}
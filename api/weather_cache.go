@@ -12,8 +12,9 @@ import (
 // WeatherCache represents the cached weather data structure
 type WeatherCache struct {
 	// Metadata
-	CreatedOn string  `toml:"created_on"` // Date in YYYY-MM-DD format (local time)
+	CreatedOn string  `toml:"created_on"` // Date in YYYY-MM-DD format (location's time zone)
 	CreatedAt int64   `toml:"created_at"` // Unix timestamp for debugging
+	Timezone  string  `toml:"timezone"`   // IANA zone CreatedOn is expressed in (empty: host zone)
 	Location  string  `toml:"location"`   // Location name (city, country)
 	Latitude  float64 `toml:"latitude"`   // Location latitude
 	Longitude float64 `toml:"longitude"`  // Location longitude
@@ -52,12 +53,19 @@ type CurrentWeatherData struct {
 // CacheManager handles weather cache operations
 type CacheManager struct {
 	filePath string
+	clock    Clock
 }
 
 // NewCacheManager creates a new cache manager instance
 func NewCacheManager(filePath string) *CacheManager {
+	return NewCacheManagerWithClock(filePath, SystemClock{})
+}
+
+// NewCacheManagerWithClock creates a cache manager that reads time from clock
+func NewCacheManagerWithClock(filePath string, clock Clock) *CacheManager {
 	return &CacheManager{
 		filePath: filePath,
+		clock:    clockOrSystem(clock),
 	}
 }
 
@@ -70,15 +78,19 @@ func (cm *CacheManager) IsValidForToday() bool {
 		return false
 	}
 
-	// Get current date in local time
-	now := time.Now()
-	today := now.Format("2006-01-02")
+	// Compare dates in the zone the cache was written in, so a UTC host does not
+	// expire a Honolulu cache at UTC midnight. Older caches without a zone used host time.
+	zone := time.Local
+	if cache.Timezone != "" {
+		zone = LoadTimezone(cache.Timezone, cache.DailyForecast.Timezone)
+	}
+	today := cm.clock.Now().In(zone).Format("2006-01-02")
 
 	// Check if cache was created today
 	isValid := cache.CreatedOn == today
 
-	logger.Debug("Cache validity check: created=%s, today=%s, valid=%v",
-		cache.CreatedOn, today, isValid)
+	logger.Debug("Cache validity check: created=%s, today=%s, zone=%s, valid=%v",
+		cache.CreatedOn, today, zone, isValid)
 
 	return isValid
 }
@@ -147,10 +159,11 @@ func (cm *CacheManager) WriteOneCall(oneCall *OneCallResponse, todayData *TodayW
 	todayDaily := oneCall.Daily[0]
 
 	// Create cache structure with proper daily min/max from One Call API
-	now := time.Now()
+	now := cm.clock.Now().In(LoadTimezone(oneCall.Timezone, oneCall.TimezoneOffset))
 	cache := WeatherCache{
-		CreatedOn:     now.Format("2006-01-02"), // Local date
+		CreatedOn:     now.Format("2006-01-02"), // Location's date
 		CreatedAt:     now.Unix(),
+		Timezone:      oneCall.Timezone,
 		Location:      todayData.Location,
 		Latitude:      oneCall.Lat,
 		Longitude:     oneCall.Lon,
@@ -124,8 +124,8 @@ func TestCacheManager_ReadWrite(t *testing.T) {
 		t.Errorf("SchemaVersion mismatch: got %d, want 1", cache.SchemaVersion)
 	}
 
-	// Verify today's date
-	today := time.Now().Format("2006-01-02")
+	// Verify today's date in the location's time zone
+	today := time.Now().In(LoadTimezone(oneCall.Timezone, oneCall.TimezoneOffset)).Format("2006-01-02")
 	if cache.CreatedOn != today {
 		t.Errorf("CreatedOn mismatch: got %s, want %s", cache.CreatedOn, today)
 	}
@@ -244,3 +244,51 @@ func TestCacheManager_Delete(t *testing.T) {
 		t.Error("Delete non-existent file should not return error")
 	}
 }
+
+func TestCacheManager_LocationTimezone(t *testing.T) {
+	cacheFile := filepath.Join(t.TempDir(), "test-cache.toml")
+	honolulu := LoadTimezone("Pacific/Honolulu", -36000)
+
+	// 6 PM in Honolulu on March 3 is already March 4 in UTC
+	written := time.Date(2025, 3, 3, 18, 0, 0, 0, honolulu)
+	cm := NewCacheManagerWithClock(cacheFile, FixedClock{Time: written.UTC()})
+
+	oneCall := &OneCallResponse{
+		Lat:            21.3069,
+		Lon:            -157.8583,
+		Timezone:       "Pacific/Honolulu",
+		TimezoneOffset: -36000,
+		Daily:          []DailyData{{Temp: DailyTemperature{Max: 82, Min: 70}}},
+	}
+	todayData := &TodayWeatherData{Location: "Honolulu, US", Units: "imperial"}
+	if err := cm.WriteOneCall(oneCall, todayData); err != nil {
+		t.Fatalf("Failed to write cache: %v", err)
+	}
+
+	cache, err := cm.Read()
+	if err != nil {
+		t.Fatalf("Failed to read cache: %v", err)
+	}
+	if cache.CreatedOn != "2025-03-03" || cache.Timezone != "Pacific/Honolulu" {
+		t.Errorf("Expected cache dated 2025-03-03 in Pacific/Honolulu, got %s in %s", cache.CreatedOn, cache.Timezone)
+	}
+
+	tests := []struct {
+		name  string
+		now   time.Time
+		valid bool
+	}{
+		{"same evening", written.Add(2 * time.Hour), true},
+		{"after UTC midnight but same local day", time.Date(2025, 3, 4, 9, 0, 0, 0, time.UTC), true},
+		{"after local midnight", time.Date(2025, 3, 4, 0, 30, 0, 0, honolulu), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := NewCacheManagerWithClock(cacheFile, FixedClock{Time: tt.now})
+			if got := checker.IsValidForToday(); got != tt.valid {
+				t.Errorf("IsValidForToday() at %s = %v, want %v", tt.now.UTC(), got, tt.valid)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// AIDEV-NOTE: Fixtures stand in for the live One Call fetch so prompts can be
+// rehearsed offline. Raw One Call fixtures skip reverse geocoding; they may carry
+// "location", "country" and "units" keys that the real API does not send.
+
+//go:embed fixtures/*.json
+var weatherFixtures embed.FS
+
+// oneCallFixture is a raw One Call response with optional fixture-only metadata
+type oneCallFixture struct {
+	OneCallResponse
+	Location string `json:"location"` // Display name (default: the time zone)
+	Country  string `json:"country"`  // Country code
+	Units    string `json:"units"`    // Units the fixture values are in (default: target units)
+}
+
+// WeatherFixtureNames lists the built-in scenario fixtures
+func WeatherFixtureNames() []string {
+	entries, err := fs.ReadDir(weatherFixtures, "fixtures")
+	if err != nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), ".json"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LoadWeatherFixture loads a fixture file, or a built-in scenario by name when
+// no such file exists, and converts it to units
+func LoadWeatherFixture(ref, units string) (*TodayWeatherData, error) {
+	data, err := os.ReadFile(ref)
+	if errors.Is(err, os.ErrNotExist) {
+		name := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(ref)), ".json")
+		data, err = weatherFixtures.ReadFile(path.Join("fixtures", name+".json"))
+		if err != nil {
+			return nil, fmt.Errorf("weather fixture %q is neither a file nor a built-in scenario (built-in: %s)",
+				ref, strings.Join(WeatherFixtureNames(), ", "))
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read weather fixture file: %w", err)
+	}
+
+	todayData, err := ParseWeatherFixture(data, units)
+	if err != nil {
+		return nil, fmt.Errorf("invalid weather fixture %s: %w", ref, err)
+	}
+	return todayData, nil
+}
+
+// ParseWeatherFixture decodes either a raw One Call response or normalized
+// TodayWeatherData JSON and converts it to units (empty keeps the fixture's units)
+func ParseWeatherFixture(data []byte, units string) (*TodayWeatherData, error) {
+	var keys map[string]json.RawMessage
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("failed to parse fixture JSON: %w", err)
+	}
+
+	var todayData *TodayWeatherData
+	switch {
+	case hasAnyKey(keys, "current", "daily"):
+		var fixture oneCallFixture
+		if err := json.Unmarshal(data, &fixture); err != nil {
+			return nil, fmt.Errorf("failed to parse One Call fixture: %w", err)
+		}
+		if len(fixture.Daily) == 0 {
+			return nil, fmt.Errorf("One Call fixture has no daily forecast")
+		}
+		todayData = extractTodayWeather(&fixture.OneCallResponse, LocationInfo{
+			Display: fixture.Location,
+			Country: fixture.Country,
+		})
+		todayData.Units = fixture.Units
+	case hasAnyKey(keys, "temp_high", "current_temp"):
+		todayData = &TodayWeatherData{}
+		if err := json.Unmarshal(data, todayData); err != nil {
+			return nil, fmt.Errorf("failed to parse weather data fixture: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unrecognized fixture: expected a One Call response (current, daily) or normalized weather data (temp_high, current_temp)")
+	}
+
+	// Fixtures describe "now", whenever they are replayed
+	todayData.LastUpdated = time.Now()
+	if todayData.Units == "" {
+		todayData.Units = units
+	}
+	if units != "" && todayData.Units != units {
+		todayData = convertWeatherData(todayData, units)
+	}
+	return todayData, nil
+}
+
+// hasAnyKey reports whether a decoded JSON object has any of the keys
+func hasAnyKey(object map[string]json.RawMessage, keys ...string) bool {
+	for _, key := range keys {
+		if _, ok := object[key]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,156 @@
+package api
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBuiltinWeatherFixtures(t *testing.T) {
+	names := WeatherFixtureNames()
+	expected := []string{"blizzard", "calm-day", "heat-wave", "severe-storm"}
+	if strings.Join(names, ",") != strings.Join(expected, ",") {
+		t.Fatalf("Expected fixtures %v, got %v", expected, names)
+	}
+
+	tests := []struct {
+		name     string
+		location string
+		alert    string
+	}{
+		{"heat-wave", "Phoenix, Arizona", "Extreme Heat Warning"},
+		{"blizzard", "Winnipeg, Manitoba", "Blizzard Warning"},
+		{"severe-storm", "Oklahoma City, Oklahoma", "Tornado Watch"},
+		{"calm-day", "San Diego, California", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := LoadWeatherFixture(tt.name, "imperial")
+			if err != nil {
+				t.Fatalf("Failed to load fixture: %v", err)
+			}
+			if data.Location != tt.location {
+				t.Errorf("Expected location %q, got %q", tt.location, data.Location)
+			}
+			if data.Units != "imperial" {
+				t.Errorf("Expected imperial units, got %s", data.Units)
+			}
+			if data.Timezone == "" {
+				t.Error("Expected fixture to carry a time zone")
+			}
+			if tt.alert != "" && (len(data.WeatherAlerts) == 0 || data.WeatherAlerts[0] != tt.alert) {
+				t.Errorf("Expected first alert %q, got %v", tt.alert, data.WeatherAlerts)
+			}
+			if data.LastUpdated.IsZero() {
+				t.Error("Expected LastUpdated to be set")
+			}
+		})
+	}
+}
+
+func TestParseWeatherFixtureConvertsUnits(t *testing.T) {
+	// The blizzard fixture is recorded in metric
+	data, err := LoadWeatherFixture("Blizzard.json", "imperial")
+	if err != nil {
+		t.Fatalf("Failed to load fixture: %v", err)
+	}
+	if math.Abs(data.CurrentTemp-(-11.56)) > 0.01 {
+		t.Errorf("Expected -24.2C converted to -11.56F, got %.2f", data.CurrentTemp)
+	}
+	if data.TempHigh <= data.TempLow {
+		t.Errorf("Expected high above low, got %.1f/%.1f", data.TempHigh, data.TempLow)
+	}
+
+	// Empty target units keep the fixture's own units
+	data, err = LoadWeatherFixture("blizzard", "")
+	if err != nil {
+		t.Fatalf("Failed to load fixture: %v", err)
+	}
+	if data.Units != "metric" || data.CurrentTemp != -24.2 {
+		t.Errorf("Expected metric -24.2, got %s %.1f", data.Units, data.CurrentTemp)
+	}
+}
+
+func TestParseWeatherFixtureShapes(t *testing.T) {
+	tests := []struct {
+		name        string
+		json        string
+		expectError string
+		expectTemp  float64
+		expectPlace string
+	}{
+		{
+			name:        "One Call without metadata falls back to time zone",
+			json:        `{"timezone": "Pacific/Honolulu", "current": {"temp": 81}, "daily": [{"temp": {"min": 72, "max": 86}, "pop": 0.3}]}`,
+			expectTemp:  81,
+			expectPlace: "Pacific/Honolulu",
+		},
+		{
+			name:        "Normalized weather data",
+			json:        `{"temp_high": 50, "temp_low": 40, "current_temp": 45, "units": "imperial", "location": "Portland"}`,
+			expectTemp:  45,
+			expectPlace: "Portland",
+		},
+		{
+			name:        "One Call without daily forecast",
+			json:        `{"current": {"temp": 81}, "daily": []}`,
+			expectError: "no daily forecast",
+		},
+		{
+			name:        "Unrecognized object",
+			json:        `{"cod": 401, "message": "Invalid API key"}`,
+			expectError: "unrecognized fixture",
+		},
+		{
+			name:        "Not JSON",
+			json:        `temp = 5`,
+			expectError: "failed to parse fixture JSON",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := ParseWeatherFixture([]byte(tt.json), "imperial")
+			if tt.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+					t.Fatalf("Expected error containing %q, got %v", tt.expectError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if data.CurrentTemp != tt.expectTemp {
+				t.Errorf("Expected current temp %.1f, got %.1f", tt.expectTemp, data.CurrentTemp)
+			}
+			if data.Location != tt.expectPlace {
+				t.Errorf("Expected location %q, got %q", tt.expectPlace, data.Location)
+			}
+		})
+	}
+}
+
+func TestLoadWeatherFixtureFile(t *testing.T) {
+	dir := t.TempDir()
+	fixturePath := filepath.Join(dir, "hurricane.json")
+	fixture := `{"temp_high": 88, "temp_low": 79, "current_temp": 84, "weather_alerts": ["Hurricane Warning"], "units": "imperial"}`
+	if err := os.WriteFile(fixturePath, []byte(fixture), 0644); err != nil {
+		t.Fatalf("Failed to write fixture: %v", err)
+	}
+
+	data, err := LoadWeatherFixture(fixturePath, "metric")
+	if err != nil {
+		t.Fatalf("Failed to load fixture file: %v", err)
+	}
+	if data.Units != "metric" || len(data.WeatherAlerts) != 1 {
+		t.Errorf("Expected metric data with one alert, got %+v", data)
+	}
+
+	_, err = LoadWeatherFixture(filepath.Join(dir, "missing.json"), "imperial")
+	if err == nil || !strings.Contains(err.Error(), "built-in: blizzard, calm-day, heat-wave, severe-storm") {
+		t.Errorf("Expected error listing built-in fixtures, got %v", err)
+	}
+}
@@ -204,6 +204,20 @@ func (w *WeatherClient) ExtractTodayWeatherFromOneCallWithContext(ctx context.Co
 		"daily_count": len(oneCall.Daily),
 	})
 
+	// Try to get the actual location details via reverse geocoding
+	locationInfo := w.GetLocationInfo(ctx, oneCall.Lat, oneCall.Lon)
+	result := extractTodayWeather(oneCall, locationInfo)
+
+	complete(nil)
+	logger.Debug("Today's weather extracted from One Call: high=%.1f, low=%.1f, current=%.1f, conditions=%s",
+		result.TempHigh, result.TempLow, result.CurrentTemp, result.CurrentConditions)
+
+	return result, nil
+}
+
+// extractTodayWeather builds today's weather from One Call data and an already
+// resolved location; it makes no network calls so fixtures can use it offline
+func extractTodayWeather(oneCall *OneCallResponse, locationInfo LocationInfo) *TodayWeatherData {
 	// Get today's daily forecast (first element in daily array)
 	todayDaily := oneCall.Daily[0]
 
@@ -231,31 +245,26 @@ func (w *WeatherClient) ExtractTodayWeatherFromOneCallWithContext(ctx context.Co
 	// Determine unit system from configuration (already specified in request)
 	units := "metric" // Default, but should match params.Units
 
-	// Try to get the actual location details via reverse geocoding
-	locationInfo := w.GetLocationInfo(ctx, oneCall.Lat, oneCall.Lon)
 	locationName := locationInfo.Display
 	if locationName == "" {
 		// Fallback to timezone if geocoding fails
 		locationName = oneCall.Timezone
 	}
 
-	result := &TodayWeatherData{
+	return &TodayWeatherData{
 		TempHigh:          todayDaily.Temp.Max,  // Proper daily maximum
 		TempLow:           todayDaily.Temp.Min,  // Proper daily minimum
 		CurrentTemp:       oneCall.Current.Temp, // Real-time current temperature
 		CurrentConditions: currentConditions,
 		RainChance:        todayDaily.Pop, // Probability of precipitation (0-1)
 		WindConditions:    windConditions,
+		WindSpeed:         oneCall.Current.WindSpeed,
 		WeatherAlerts:     weatherAlerts,
 		LastUpdated:       time.Now(),
 		Units:             units,
 		Location:          locationName,
 		Country:           locationInfo.Country,
+		Timezone:          oneCall.Timezone,
+		TimezoneOffset:    oneCall.TimezoneOffset,
 	}
-
-	complete(nil)
-	logger.Debug("Today's weather extracted from One Call: high=%.1f, low=%.1f, current=%.1f, conditions=%s",
-		result.TempHigh, result.TempLow, result.CurrentTemp, result.CurrentConditions)
-
-	return result, nil
 }
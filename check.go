@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"text/tabwriter"
+
+	"myrcast/api"
+	"myrcast/config"
+	"myrcast/internal/delivery"
+	"myrcast/internal/logger"
+)
+
+// runChecks verifies credentials, subscriptions and IDs for every service using
+// read-only endpoints, so nothing is generated or billed
+func runChecks(ctx context.Context, cfg *config.Config) []api.CheckResult {
+	var results []api.CheckResult
+
+	logger.Info("Checking OpenWeather credentials...")
+	results = append(results, newWeatherClient(cfg).CheckCredentials(ctx, api.ForecastParams{
+		Latitude:  cfg.Weather.Latitude,
+		Longitude: cfg.Weather.Longitude,
+		Units:     cfg.Weather.Units,
+	})...)
+
+	logger.Info("Checking Claude credentials and model...")
+	if claudeClient, err := newClaudeClient(cfg); err != nil {
+		results = append(results, api.CheckResult{
+			Service: "Claude", Check: "Client setup", Status: api.CheckFail, Detail: err.Error(),
+		})
+	} else {
+		results = append(results, claudeClient.CheckModel(ctx)...)
+	}
+
+	logger.Info("Checking ElevenLabs credentials, voice and model...")
+	if elevenLabsClient, err := newElevenLabsClient(cfg); err != nil {
+		results = append(results, api.CheckResult{
+			Service: "ElevenLabs", Check: "Client setup", Status: api.CheckFail, Detail: err.Error(),
+		})
+	} else {
+		results = append(results, elevenLabsClient.CheckCredentials(ctx)...)
+	}
+
+	// rdimport runs locally, so checking it costs nothing
+	if cfg.Delivery.Adapter == delivery.AdapterRivendell {
+		results = append(results, checkRDImport(cfg.Delivery.Rivendell.Command))
+	}
+	// A missing evergreen file would only show up on the morning a run fails
+	if cfg.Fallback.EvergreenFile != "" {
+		results = append(results, checkEvergreenFile(cfg.Fallback.EvergreenFile))
+	}
+
+	return results
+}
+
+// checkRDImport verifies that the configured rdimport command can be found
+func checkRDImport(command []string) api.CheckResult {
+	result := api.CheckResult{Service: "Rivendell", Check: "rdimport command"}
+	if len(command) == 0 {
+		command = []string{"rdimport"}
+	}
+	path, err := exec.LookPath(command[0])
+	if err != nil {
+		result.Status = api.CheckFail
+		result.Detail = fmt.Sprintf("%s not found", command[0])
+		result.Hint = "Install rdimport on this machine or set [delivery.rivendell] command to its full path"
+		return result
+	}
+	result.Status = api.CheckPass
+	result.Detail = path
+	return result
+}
+
+// checkEvergreenFile verifies that the [fallback] evergreen file can be read
+func checkEvergreenFile(path string) api.CheckResult {
+	result := api.CheckResult{Service: "Fallback", Check: "Evergreen file"}
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() || info.Size() == 0 {
+		result.Status = api.CheckFail
+		result.Detail = fmt.Sprintf("%s is missing or empty", path)
+		result.Hint = "Set [fallback] evergreen_file to a station-produced audio file"
+		return result
+	}
+	result.Status = api.CheckPass
+	result.Detail = path
+	return result
+}
+
+// printCheckResults writes the check table with remediation hints under each
+// row that did not pass, and reports whether any check failed
+func printCheckResults(w io.Writer, results []api.CheckResult) bool {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "SERVICE\tCHECK\tSTATUS\tDETAIL")
+	failed := false
+	for _, result := range results {
+		// Details echo API errors, which may quote a request URL with its key
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", result.Service, result.Check, result.Status, logger.Redact(result.Detail))
+		if result.Hint != "" {
+			// Hints sit in the unaligned last cell so they do not widen the table
+			fmt.Fprintf(tw, "\t\t\t-> %s\n", logger.Redact(result.Hint))
+		}
+		if result.Status == api.CheckFail {
+			failed = true
+		}
+	}
+	tw.Flush()
+	return failed
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"myrcast/api"
+)
+
+// runCacheCommand dispatches the cache actions
+func runCacheCommand(args []string) int {
+	return runCommandGroup("cache", "Inspect or clear the daily weather cache.", []command{
+		{"show", "Print the cached daily forecast and whether it is still valid", runCacheShowCommand},
+		{"clear", "Delete the cache file so the next run fetches fresh data", runCacheClearCommand},
+	}, args)
+}
+
+// runCacheShowCommand prints the cache file contents
+func runCacheShowCommand(args []string) int {
+	fs := newFlagSet("cache show", "cache show [options]", "Print the cached daily forecast and whether it is valid for today.")
+	configPath := fs.String("config", getDefaultConfigPath(), "Path to TOML configuration file")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	cfg, code := loadConfigFile(*configPath)
+	if cfg == nil {
+		return code
+	}
+
+	if _, err := os.Stat(cfg.Cache.FilePath); os.IsNotExist(err) {
+		fmt.Printf("No weather cache at %s\n", cfg.Cache.FilePath)
+		return ExitSuccess
+	}
+
+	cacheManager := api.NewCacheManager(cfg.Cache.FilePath)
+	cache, err := cacheManager.Read()
+	if err != nil {
+		printError("%v", err)
+		return ExitFileSystemError
+	}
+
+	timezone := cache.Timezone
+	if timezone == "" {
+		timezone = "host time zone"
+	}
+	unit := api.GetUnitSuffix("temperature", cache.Units)
+
+	fmt.Printf("File:       %s\n", cfg.Cache.FilePath)
+	fmt.Printf("Location:   %s (%.4f, %.4f)\n", cache.Location, cache.Latitude, cache.Longitude)
+	fmt.Printf("Created:    %s (%s), %s\n", cache.CreatedOn, timezone,
+		time.Unix(cache.CreatedAt, 0).Format(time.RFC3339))
+	fmt.Printf("Units:      %s\n", cache.Units)
+	fmt.Printf("High/Low:   %.1f%s / %.1f%s\n",
+		cache.DailyForecast.TempHigh, unit, cache.DailyForecast.TempLow, unit)
+	fmt.Printf("Valid:      %t (for today at the location)\n", cacheManager.IsValidForToday())
+	return ExitSuccess
+}
+
+// runCacheClearCommand deletes the cache file
+func runCacheClearCommand(args []string) int {
+	fs := newFlagSet("cache clear", "cache clear [options]", "Delete the weather cache so the next run fetches fresh forecast data.")
+	configPath := fs.String("config", getDefaultConfigPath(), "Path to TOML configuration file")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	cfg, code := loadConfigFile(*configPath)
+	if cfg == nil {
+		return code
+	}
+
+	if err := api.NewCacheManager(cfg.Cache.FilePath).Delete(); err != nil {
+		printError("%v", err)
+		return ExitFileSystemError
+	}
+	fmt.Printf("Weather cache cleared: %s\n", cfg.Cache.FilePath)
+	return ExitSuccess
+}
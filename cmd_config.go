@@ -0,0 +1,164 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/pelletier/go-toml/v2"
+	"myrcast/config"
+)
+
+// runConfigCommand dispatches the config actions
+func runConfigCommand(args []string) int {
+	return runCommandGroup("config", "Create, validate or show the configuration file.", []command{
+		{"init", "Write a sample configuration file", runConfigInitCommand},
+		{"validate", "Check the configuration file for errors", runConfigValidateCommand},
+		{"show", "Print the effective configuration with defaults applied", runConfigShowCommand},
+		{"migrate", "Upgrade the configuration file to the current schema version", runConfigMigrateCommand},
+	}, args)
+}
+
+// runConfigInitCommand writes a sample configuration file
+func runConfigInitCommand(args []string) int {
+	fs := newFlagSet("config init", "config init [options]", "Write a sample configuration file to edit with your API keys and station settings.")
+	configPath := fs.String("config", getDefaultConfigPath(), "Path of the configuration file to create")
+	force := fs.Bool("force", false, "Overwrite an existing configuration file")
+	interactive := fs.Bool("interactive", false, "Ask for each setting, checking keys, location, voice and import folder as you go")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	if _, err := os.Stat(*configPath); err == nil && !*force {
+		printError("configuration file already exists: %s (use --force to overwrite)", *configPath)
+		return ExitFileSystemError
+	}
+
+	if *interactive {
+		return runConfigWizard(*configPath, os.Stdin, os.Stdout)
+	}
+
+	if err := config.GenerateSampleConfig(*configPath); err != nil {
+		printError("Failed to generate sample config: %v", err)
+		return ExitFileSystemError
+	}
+	fmt.Printf("Sample configuration file created at: %s\n", *configPath)
+	fmt.Printf("Please edit the file to add your API keys and customize settings\n")
+	return ExitSuccess
+}
+
+// runConfigValidateCommand loads and validates the configuration without calling any API
+func runConfigValidateCommand(args []string) int {
+	fs := newFlagSet("config validate", "config validate [options]", "Check the configuration file for errors without calling any API.")
+	configPath := fs.String("config", getDefaultConfigPath(), "Path to TOML configuration file")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	cfg, code := loadConfigFile(*configPath)
+	if cfg == nil {
+		return code
+	}
+
+	if err := cfg.Validate(); err != nil {
+		var multiErr *config.MultiValidationError
+		if errors.As(err, &multiErr) {
+			fmt.Fprintf(os.Stderr, "Configuration %s has %d problem(s):\n", *configPath, len(multiErr.Errors))
+			for _, validationErr := range multiErr.Errors {
+				fmt.Fprintf(os.Stderr, "  - %s: %s\n", validationErr.Field, validationErr.Message)
+			}
+		} else {
+			fmt.Fprintf(os.Stderr, "Configuration validation failed: %v\n", err)
+		}
+		return ExitValidationError
+	}
+
+	fmt.Printf("Configuration OK: %s\n", *configPath)
+	for _, line := range cfg.DescribeAPIKeys() {
+		fmt.Printf("  %s\n", line)
+	}
+	return ExitSuccess
+}
+
+// runConfigShowCommand prints the effective configuration with API keys masked
+func runConfigShowCommand(args []string) int {
+	fs := newFlagSet("config show", "config show [options]", "Print the effective configuration, with defaults applied and API keys masked.")
+	configPath := fs.String("config", getDefaultConfigPath(), "Path to TOML configuration file")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	cfg, code := loadConfigFile(*configPath)
+	if cfg == nil {
+		return code
+	}
+
+	data, err := toml.Marshal(cfg.Redacted())
+	if err != nil {
+		printError("failed to format configuration: %v", err)
+		return ExitGeneralError
+	}
+	fmt.Printf("# Effective configuration from %s (API keys masked)\n", *configPath)
+	os.Stdout.Write(data)
+	return ExitSuccess
+}
+
+// runConfigMigrateCommand rewrites an older configuration file in the current
+// schema, keeping its comments and saving a backup of the original
+func runConfigMigrateCommand(args []string) int {
+	fs := newFlagSet("config migrate", "config migrate [options]",
+		"Upgrade the configuration file to the current schema version. Comments are kept and the original is saved with a .bak extension.")
+	configPath := fs.String("config", getDefaultConfigPath(), "Path to TOML configuration file")
+	dryRun := fs.Bool("dry-run", false, "Print the upgraded file instead of writing it")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	if err := validateConfigPath(*configPath); err != nil {
+		printError("%v", err)
+		return ExitConfigError
+	}
+	data, err := os.ReadFile(*configPath)
+	if err != nil {
+		printError("failed to read configuration file: %v", err)
+		return ExitConfigError
+	}
+
+	result, err := config.MigrateConfigData(data)
+	if err != nil {
+		printError("%v", err)
+		return ExitConfigError
+	}
+	for _, warning := range result.Warnings {
+		printWarning("%s", warning)
+	}
+	if !result.Changed() {
+		fmt.Printf("Configuration %s is already at version %d\n", *configPath, config.CurrentConfigVersion)
+		return ExitSuccess
+	}
+
+	if *dryRun {
+		os.Stdout.Write(result.Data)
+		for _, change := range result.Changes {
+			fmt.Fprintf(os.Stderr, "  - %s\n", change)
+		}
+		return ExitSuccess
+	}
+
+	backupPath := *configPath + ".bak"
+	if err := os.WriteFile(backupPath, data, 0644); err != nil {
+		printError("failed to write backup: %v", err)
+		return ExitFileSystemError
+	}
+	if err := os.WriteFile(*configPath, result.Data, 0644); err != nil {
+		printError("failed to write configuration file: %v", err)
+		return ExitFileSystemError
+	}
+
+	fmt.Printf("Migrated %s from version %d to %d (original saved as %s)\n",
+		*configPath, result.FromVersion, config.CurrentConfigVersion, backupPath)
+	for _, change := range result.Changes {
+		fmt.Printf("  - %s\n", change)
+	}
+	return ExitSuccess
+}
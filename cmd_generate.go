@@ -0,0 +1,595 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"myrcast/api"
+	"myrcast/config"
+	"myrcast/internal/delivery"
+	"myrcast/internal/feed"
+	"myrcast/internal/hooks"
+	"myrcast/internal/logger"
+)
+
+// runGenerateCommand runs the complete weather report workflow
+func runGenerateCommand(args []string) int {
+	startTime := time.Now()
+
+	fs := newFlagSet("generate", "generate [options]",
+		"Fetch the weather, write the report script with Claude and synthesize it with ElevenLabs.")
+	var common commonFlags
+	common.register(fs, "")
+	dryRun := fs.Bool("dry-run", false, "Validate configuration and show what would happen without executing")
+	check := fs.Bool("check", false, "Verify API keys, subscriptions, model and voice IDs with read-only calls, then exit")
+	scriptOnly := fs.Bool("script-only", false, "Stop after writing the script and weather files to the stage directory (no speech)")
+	fromScript := fs.String("from-script", "", "Synthesize an edited script file, skipping the weather and Claude stages")
+	stageDir := fs.String("stage-dir", "", "Directory for the script and weather stage files (default: [output] stage_dir)")
+	outputDir := fs.String("output-dir", "", "Write the audio to this directory instead of delivering it (no latest file, expiry, feed, hooks or metrics)")
+	output := fs.String("output", outputText, "Result format: text, or json to print one result object to stdout (logs go to stderr)")
+	var source weatherSource
+	source.register(fs)
+	// Legacy flags from the single-command interface, kept for existing scripts
+	generateConfig := fs.Bool("generate-config", false, "Generate a sample configuration file and exit (deprecated: use 'config init')")
+	showVersion := fs.Bool("version", false, "Show version information and exit (deprecated: use 'version')")
+	usage := fs.Usage
+	fs.Usage = func() {
+		usage()
+		fmt.Fprintln(fs.Output())
+		printExitCodes(fs.Output())
+	}
+
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	if *showVersion {
+		return runVersionCommand(nil)
+	}
+
+	if *check && (*dryRun || *scriptOnly || *fromScript != "" || *outputDir != "") {
+		return usageError(fs, "--check cannot be combined with --dry-run, --script-only, --from-script or --output-dir")
+	}
+	if *scriptOnly && *fromScript != "" {
+		return usageError(fs, "--script-only and --from-script cannot be used together")
+	}
+	if *scriptOnly && *outputDir != "" {
+		return usageError(fs, "--output-dir has no effect with --script-only")
+	}
+	if !contains(outputFormats, *output) {
+		return usageError(fs, fmt.Sprintf("invalid --output %q (valid: %s)", *output, strings.Join(outputFormats, ", ")))
+	}
+	jsonOutput := *output == outputJSON
+	if jsonOutput && (*check || *dryRun) {
+		return usageError(fs, "--output json cannot be combined with --check or --dry-run")
+	}
+	if *fromScript != "" && source.Fixture != "" {
+		return usageError(fs, "--weather-fixture has no effect with --from-script")
+	}
+	// A rehearsal must never reach the automation system
+	if source.Fixture != "" && !*scriptOnly && *outputDir == "" {
+		return usageError(fs, "--weather-fixture needs --script-only or --output-dir, so the rehearsal is not delivered to the import folder")
+	}
+
+	if *generateConfig {
+		if err := config.GenerateSampleConfig(common.configPath); err != nil {
+			printError("Failed to generate sample config: %v", err)
+			return ExitFileSystemError
+		}
+		fmt.Printf("Sample configuration file created at: %s\n", common.configPath)
+		fmt.Printf("Please edit the file to add your API keys and customize settings\n")
+		return ExitSuccess
+	}
+
+	common.consoleStderr = jsonOutput
+	cfg, code := common.setup()
+	if cfg == nil {
+		if jsonOutput {
+			setupErr := fmt.Errorf("configuration could not be loaded or validated (see log)")
+			printRunSummary(os.Stdout, newRunSummary(startTime, common.configPath, nil, nil, code, setupErr))
+		}
+		return code
+	}
+	if *stageDir != "" {
+		cfg.Output.StageDir = *stageDir
+	}
+	options := workflowOptions{ScriptOnly: *scriptOnly, FromScript: *fromScript, OutputDir: *outputDir, Weather: source}
+
+	// Application startup
+	logger.Info("=== MYRCAST SESSION STARTED ===")
+	logger.Info("Myrcast - Weather Report Generator")
+
+	// Log START message after final logger initialization
+	logger.Info("START")
+
+	logger.Debug("Weather coordinates: %.4f, %.4f", cfg.Weather.Latitude, cfg.Weather.Longitude)
+	logger.Debug("Units: %s", cfg.Weather.Units)
+
+	// Handle check mode
+	if *check {
+		ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+		defer cancel()
+		if printCheckResults(os.Stdout, runChecks(ctx, cfg)) {
+			logger.Error("One or more checks failed - fix the items marked FAIL before the next run")
+			return ExitAPIError
+		}
+		logger.Info("All checks passed - ready for production run")
+		return ExitSuccess
+	}
+
+	// Handle dry-run mode
+	if *dryRun {
+		logger.Info("DRY RUN MODE - Showing what would happen without executing")
+		if source.Fixture != "" {
+			logger.Info("Weather: Would use fixture %s in %s units", source.Fixture, cfg.Weather.Units)
+		} else {
+			logger.Info("Weather API: Would fetch weather for lat=%.4f, lon=%.4f using %s units",
+				cfg.Weather.Latitude, cfg.Weather.Longitude, cfg.Weather.Units)
+		}
+		logger.Info("Claude API: Would generate weather report using model %s", cfg.Claude.Model)
+		logger.Info("ElevenLabs API: Would synthesize speech using voice %s", cfg.ElevenLabs.VoiceID)
+		if audioName, err := audioBaseName(cfg, options.audioDir(cfg), nil); err == nil {
+			logger.Info("Output: Would save %s to %s", audioName+api.AudioExtension, options.audioDir(cfg))
+		} else {
+			logger.Info("Output: Would save audio file to %s", options.audioDir(cfg))
+		}
+		if options.rehearsal() {
+			logger.Info("Rehearsal: Would not deliver the report, update the latest file, expiry or feed, fire hooks or write metrics")
+		} else {
+			if cfg.Output.Latest != config.LatestNone && cfg.Output.FilenameTemplate != "" {
+				logger.Info("Output: Would %s the report to %s", cfg.Output.Latest, cfg.Output.MediaID+api.AudioExtension)
+			}
+			logger.Info("Delivery: Would hand the report to the %s adapter", cfg.Delivery.Adapter)
+			if cfg.Feed.Enabled {
+				logger.Info("Feed: Would add the report to %s", cfg.Feed.File)
+			}
+			if cfg.Output.Sidecar != delivery.SidecarNone {
+				logger.Info("Sidecar: Would write %s metadata next to the audio file", cfg.Output.Sidecar)
+			}
+			validUntil := reportValidUntil(cfg, time.Now())
+			logger.Info("Expiry: A report made now would be valid until %s (sweep action: %s)",
+				validUntil.Format("Mon 3:04 PM"), cfg.Expiry.Action)
+			if len(cfg.Hooks) > 0 {
+				logger.Info("Hooks: Would notify %d hook(s) when the run finishes", len(cfg.Hooks))
+			}
+			if cfg.Metrics.Textfile != "" {
+				logger.Info("Metrics: Would write run health to %s", cfg.Metrics.Textfile)
+			}
+		}
+		logger.Info("Stage files: Would write script and weather data to %s", cfg.Output.StageDir)
+		logger.Info("All configuration checks passed - ready for production run")
+		logger.Info("Remove --dry-run flag to execute actual weather report generation")
+		return ExitSuccess
+	}
+
+	// Run the main weather report generation workflow
+	result, err := runWeatherReportWorkflow(cfg, options)
+	if err != nil {
+		logger.Error("Weather report generation failed: %v", err)
+
+		// Log execution summary for failed run
+		results := []string{
+			logger.Redact(fmt.Sprintf("Weather report generation failed: %v", err)),
+		}
+		exitCode := exitCodeFor(err)
+		if !options.ScriptOnly && !options.rehearsal() {
+			if fallbackResult, code := applyFallback(cfg); fallbackResult != "" {
+				results = append(results, fallbackResult)
+				if code != ExitSuccess {
+					exitCode = code
+				}
+			}
+		}
+		logger.Get().LogExecutionSummary(startTime, common.configPath, "weather-report", results, exitCode)
+		if !options.rehearsal() {
+			writeMetrics(cfg, startTime, result, exitCode, err)
+
+			payload := hooks.NewPayload(hooks.EventFailure, startTime, common.configPath, "weather-report", results, exitCode)
+			payload.Error = logger.Redact(err.Error())
+			fireHooks(cfg, result, payload)
+		}
+		if jsonOutput {
+			printRunSummary(os.Stdout, newRunSummary(startTime, common.configPath, result, results, exitCode, err))
+		}
+		return exitCode
+	}
+
+	logger.Info("Weather report generation completed successfully")
+
+	// Log execution summary for successful run
+	results := []string{
+		"Weather report generation completed successfully",
+	}
+	if result.Location != "" {
+		results = append(results, fmt.Sprintf("Weather location: %s", result.Location))
+	}
+	if options.FromScript == "" {
+		results = append(results, fmt.Sprintf("Script file: %s", result.Stage.Script))
+	}
+	if result.AudioFile != "" {
+		results = append(results, fmt.Sprintf("Audio file: %s", result.AudioFile))
+		if result.LatestFile != "" {
+			results = append(results, fmt.Sprintf("Latest file: %s", result.LatestFile))
+		}
+		if result.Destination != "" && cfg.Delivery.Adapter != delivery.AdapterMyriad {
+			results = append(results, fmt.Sprintf("Delivered to: %s", result.Destination))
+		}
+	} else {
+		results = append(results, fmt.Sprintf("Weather file: %s", result.Stage.Weather))
+		logger.Info("Script only: edit %s, then run with --from-script to synthesize it", result.Stage.Script)
+	}
+	logger.Get().LogExecutionSummary(startTime, common.configPath, "weather-report", results, ExitSuccess)
+	if options.rehearsal() {
+		logger.Info("Rehearsal: the report was not delivered, and hooks and metrics were skipped")
+	} else {
+		writeMetrics(cfg, startTime, result, ExitSuccess, nil)
+		fireHooks(cfg, result, hooks.NewPayload(hooks.EventSuccess, startTime, common.configPath, "weather-report", results, ExitSuccess))
+	}
+	if jsonOutput {
+		if err := printRunSummary(os.Stdout, newRunSummary(startTime, common.configPath, result, results, ExitSuccess, nil)); err != nil {
+			logger.Error("%v", err)
+			return ExitGeneralError
+		}
+	}
+
+	return ExitSuccess
+}
+
+// applyFallback puts the [fallback] policy into effect after a failed run. It
+// returns a summary line and the exit code for the action taken, or ExitSuccess
+// when nothing changed and the failure's own exit code should stand.
+func applyFallback(cfg *config.Config) (string, int) {
+	spot := filepath.Join(cfg.Output.ImportPath, cfg.Output.MediaID+api.AudioExtension)
+	result, err := delivery.ApplyFallback(delivery.FallbackConfig{
+		Policy:        cfg.Fallback.Policy,
+		MaxAge:        time.Duration(cfg.Fallback.MaxAgeHours) * time.Hour,
+		EvergreenFile: cfg.Fallback.EvergreenFile,
+	}, spot, time.Now())
+	if err != nil {
+		logger.Error("Fallback failed: %v", err)
+		return fmt.Sprintf("Fallback failed: %v", err), ExitSuccess
+	}
+
+	fields := map[string]any{
+		"policy": cfg.Fallback.Policy,
+		"action": result.Action,
+		"file":   result.Path,
+		"reason": result.Reason,
+	}
+	switch result.Action {
+	case delivery.FallbackKeepLast:
+		logger.LogWithFields(logger.WarnLevel, "Fallback: kept the last good report", fields)
+		return fmt.Sprintf("Fallback: kept last good report %s (%s)", spot, result.Reason), ExitFallbackKept
+	case delivery.FallbackEvergreen:
+		fields["evergreen_file"] = cfg.Fallback.EvergreenFile
+		logger.LogWithFields(logger.WarnLevel, "Fallback: replaced the report with the evergreen file", fields)
+		return fmt.Sprintf("Fallback: evergreen file %s put in place at %s (%s)", cfg.Fallback.EvergreenFile, spot, result.Reason), ExitFallbackEvergreen
+	case delivery.FallbackRemove:
+		logger.LogWithFields(logger.WarnLevel, "Fallback: removed the report so automation skips it", fields)
+		return fmt.Sprintf("Fallback: removed %s (%s)", spot, result.Reason), ExitFallbackRemoved
+	}
+	logger.LogWithFields(logger.InfoLevel, "Fallback: import folder left as it is", fields)
+	return "", ExitSuccess
+}
+
+// fireHooks runs the [[hooks]] for a finished run: the success or failure event,
+// then the alert event when the forecast has active weather alerts. Hook failures
+// are logged and never change the exit code.
+func fireHooks(cfg *config.Config, result *workflowResult, payload hooks.Payload) {
+	if len(cfg.Hooks) == 0 {
+		return
+	}
+	if result != nil {
+		payload.Location = result.Location
+		payload.AudioFile = result.AudioFile
+		payload.Alerts = result.Alerts
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+
+	configured := newHooks(cfg)
+	hooks.Fire(ctx, configured, payload)
+	if len(payload.Alerts) > 0 {
+		payload.Event = hooks.EventAlert
+		hooks.Fire(ctx, configured, payload)
+	}
+}
+
+// workflowOptions selects which stages of the weather report workflow run
+type workflowOptions struct {
+	ScriptOnly bool          // Stop after writing the weather and script stage files
+	FromScript string        // Synthesize this script file, skipping the weather and Claude stages
+	OutputDir  string        // Write the audio here and stop, without delivering it
+	Weather    weatherSource // Where the weather stage gets its data
+}
+
+// rehearsal reports whether the run must stay away from the automation system:
+// fixture weather or an explicit output directory
+func (o workflowOptions) rehearsal() bool {
+	return o.Weather.Fixture != "" || o.OutputDir != ""
+}
+
+// audioDir is where the run writes its audio
+func (o workflowOptions) audioDir(cfg *config.Config) string {
+	if o.OutputDir != "" {
+		return o.OutputDir
+	}
+	return cfg.Output.ImportPath
+}
+
+// workflowResult reports what a workflow run produced
+type workflowResult struct {
+	Location    string     // Weather location name (empty when resuming from a script)
+	Stage       stageFiles // Stage files for this run's media ID
+	AudioFile   string     // Generated audio file (empty for script-only runs)
+	LatestFile  string     // Copy or link of the audio under media_id (empty when not kept)
+	Destination string     // Where the delivery adapter put the report
+	Alerts      []string   // Active weather alerts in the fetched forecast
+
+	Weather       *delivery.WeatherSnapshot // Fetched weather (nil when resuming from a script)
+	Script        string                    // Script that was written or synthesized
+	AudioDuration time.Duration             // Playing time of the audio
+	Stages        []stageTiming             // How long each stage took, in order
+}
+
+// timeStage records how long a stage took since start and whether it failed
+func (r *workflowResult) timeStage(name string, start time.Time, err error) {
+	status := "ok"
+	if err != nil {
+		status = "failed"
+	}
+	r.Stages = append(r.Stages, stageTiming{Name: name, DurationMs: time.Since(start).Milliseconds(), Status: status})
+}
+
+// runWeatherReportWorkflow orchestrates the weather report generation stages:
+// weather, script and speech, handing off through the stage files
+func runWeatherReportWorkflow(cfg *config.Config, options workflowOptions) (*workflowResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+
+	logger.Debug("Starting weather report generation workflow")
+	result := &workflowResult{Stage: newStageFiles(cfg.Output.StageDir, cfg.Output.MediaID)}
+
+	var script string
+	var todayWeather *api.TodayWeatherData
+	if options.FromScript != "" {
+		// Resume: the edited script replaces the weather and Claude stages
+		logger.Info("Using script from %s (skipping weather and Claude)", options.FromScript)
+		var err error
+		if script, err = readScriptStage(options.FromScript); err != nil {
+			return result, err
+		}
+		result.Script = script
+		// The saved weather names the location for the sidecar and filename template,
+		// but only while it is the current weather this script was written from
+		todayWeather = resumeWeather(cfg, result.Stage, options.FromScript, time.Now())
+	} else {
+		// Stage 1: Fetch weather data using One Call API (with caching) or a fixture
+		var err error
+		stageStart := time.Now()
+		todayWeather, err = fetchWeather(ctx, cfg, options.Weather)
+		result.timeStage("weather", stageStart, err)
+		if err != nil {
+			return result, err
+		}
+		result.Location = todayWeather.Location
+		result.Alerts = todayWeather.WeatherAlerts
+		result.Weather = weatherSnapshot(todayWeather)
+		if err := writeWeatherStage(result.Stage.Weather, todayWeather); err != nil {
+			if options.ScriptOnly {
+				return result, err
+			}
+			logger.Warn("Failed to save weather stage file: %v", err)
+		}
+
+		// Stage 2: Generate weather report script using Claude
+		stageStart = time.Now()
+		script, err = generateScript(ctx, cfg, todayWeather)
+		result.timeStage("script", stageStart, err)
+		if err != nil {
+			return result, err
+		}
+		result.Script = script
+		if err := writeScriptStage(result.Stage.Script, script); err != nil {
+			if options.ScriptOnly {
+				return result, err
+			}
+			logger.Warn("Failed to save script stage file: %v", err)
+		}
+		logger.Debug("Stage files written: %s, %s", result.Stage.Weather, result.Stage.Script)
+
+		if options.ScriptOnly {
+			return result, nil
+		}
+	}
+
+	// Set up delivery before spending TTS credits, so a bad adapter config fails fast
+	var adapter delivery.Adapter
+	if !options.rehearsal() {
+		var err error
+		if adapter, err = newDeliveryAdapter(cfg); err != nil {
+			return result, err
+		}
+	}
+
+	// Stage 3: Convert script to speech using ElevenLabs, directly into the import directory
+	// (or the --output-dir of a rehearsal)
+	audioName, err := audioBaseName(cfg, options.audioDir(cfg), todayWeather)
+	if err != nil {
+		return result, fmt.Errorf("failed to build audio filename: %w", err)
+	}
+	stageStart := time.Now()
+	speechResponse, err := synthesizeSpeech(ctx, cfg, script, options.audioDir(cfg), audioName, "")
+	result.timeStage("speech", stageStart, err)
+	if err != nil {
+		return result, err
+	}
+	result.AudioFile = speechResponse.AudioFilePath
+	result.AudioDuration = time.Duration(speechResponse.DurationMs) * time.Millisecond
+
+	// Rehearsals stop here: nothing is handed to the automation system
+	if options.rehearsal() {
+		logger.Info("Rehearsal report saved to %s (not delivered)", result.AudioFile)
+		return result, nil
+	}
+
+	// Keep the rolling media_id cart in step with the per-slot file
+	if cfg.Output.Latest != config.LatestNone && audioName != cfg.Output.MediaID {
+		latestPath := filepath.Join(cfg.Output.ImportPath, cfg.Output.MediaID+api.AudioExtension)
+		if err := api.UpdateLatest(result.AudioFile, latestPath, cfg.Output.Latest == config.LatestLink); err != nil {
+			return result, err
+		}
+		result.LatestFile = latestPath
+		logger.Debug("Latest report updated: %s", latestPath)
+	}
+
+	// Stage 4: Hand the report to the automation system
+	report := delivery.Report{
+		AudioFile:   speechResponse.AudioFilePath,
+		Title:       cfg.Delivery.Title,
+		Artist:      cfg.Delivery.Artist,
+		Script:      script,
+		Duration:    result.AudioDuration,
+		GeneratedAt: speechResponse.GeneratedAt,
+		ValidUntil:  reportValidUntil(cfg, reportTime(todayWeather)),
+		RunID:       logger.RunID(),
+		Voice:       speechResponse.VoiceUsed,
+		TTSModel:    cfg.ElevenLabs.Model,
+		ScriptModel: cfg.Claude.Model,
+	}
+	if todayWeather != nil {
+		report.Location = todayWeather.Location
+		report.Album = todayWeather.Location
+		report.Alerts = todayWeather.WeatherAlerts
+		report.Weather = weatherSnapshot(todayWeather)
+	}
+	if err := deliverReport(ctx, cfg, adapter, report, result, reportTime(todayWeather)); err != nil {
+		return result, err
+	}
+
+	logger.Debug("Weather report saved successfully: %s", speechResponse.AudioFilePath)
+	logger.Debug("Ready for import into Myriad radio automation")
+
+	return result, nil
+}
+
+// deliverReport hands the report to the adapter, then records its expiry and adds
+// it to the feed. An adapter that consumes the audio file leaves nothing to
+// sweep or serve, so those steps are skipped for it.
+func deliverReport(ctx context.Context, cfg *config.Config, adapter delivery.Adapter, report delivery.Report, result *workflowResult, localTime time.Time) error {
+	stageStart := time.Now()
+	var err error
+	result.Destination, err = adapter.Deliver(ctx, report)
+	result.timeStage("delivery", stageStart, err)
+	if err != nil {
+		return fmt.Errorf("failed to deliver report with the %s adapter: %w", adapter.Name(), err)
+	}
+
+	// AIDEV-NOTE: Rivendell with delete_source removes the audio after rdimport;
+	// recording it for the sweep or linking it from the feed would point at a
+	// file that no longer exists
+	consumed := delivery.ConsumesSource(adapter)
+	if consumed {
+		logger.Debug("The %s adapter removed %s, so it is not swept or added to the feed", adapter.Name(), report.AudioFile)
+	}
+
+	// Track the report so 'myrcast sweep' can take it off air once it expires
+	var expiring []string
+	if !consumed {
+		expiring = append(expiring, result.AudioFile)
+	}
+	if result.LatestFile != "" {
+		expiring = append(expiring, result.LatestFile)
+	}
+	if len(expiring) > 0 {
+		if err := delivery.RecordExpiry(expiryManifestPath(cfg), expiring, report.ValidUntil); err != nil {
+			logger.Warn("Failed to record report expiry, so the sweep won't remove it: %v", err)
+		} else {
+			logger.Debug("Report valid until %s", report.ValidUntil.Format(time.RFC3339))
+		}
+	}
+
+	// The feed is a convenience for the website; a failure must not fail the broadcast
+	if cfg.Feed.Enabled && !consumed {
+		if err := updateFeed(cfg, report, localTime); err != nil {
+			logger.Warn("Failed to update RSS feed: %v", err)
+		} else {
+			logger.Debug("RSS feed updated: %s", cfg.Feed.File)
+		}
+	}
+	return nil
+}
+
+// weatherSnapshot summarizes the weather for sidecars and the JSON result
+func weatherSnapshot(todayWeather *api.TodayWeatherData) *delivery.WeatherSnapshot {
+	return &delivery.WeatherSnapshot{
+		Units:       todayWeather.Units,
+		Conditions:  todayWeather.CurrentConditions,
+		CurrentTemp: todayWeather.CurrentTemp,
+		High:        todayWeather.TempHigh,
+		Low:         todayWeather.TempLow,
+		RainChance:  todayWeather.RainChance,
+		Wind:        todayWeather.WindConditions,
+	}
+}
+
+// audioBaseName names this run's audio file in dir from [output] filename_template,
+// using the forecast location's local time when the weather is known
+func audioBaseName(cfg *config.Config, dir string, todayWeather *api.TodayWeatherData) (string, error) {
+	values := config.FilenameValues{Time: reportTime(todayWeather)}
+	if todayWeather != nil {
+		values.Location = todayWeather.Location
+	}
+	values.Daypart = api.TimeOfDay(values.Time)
+
+	return cfg.AudioBaseName(values, func(baseName string) bool {
+		_, err := os.Lstat(filepath.Join(dir, baseName+api.AudioExtension))
+		return err == nil
+	})
+}
+
+// reportTime is the current time in the forecast location's time zone, or the
+// local time when the weather is not known
+func reportTime(todayWeather *api.TodayWeatherData) time.Time {
+	if todayWeather == nil {
+		return time.Now()
+	}
+	return todayWeather.LocalTime(time.Now())
+}
+
+// updateFeed adds the report to the RSS feed
+func updateFeed(cfg *config.Config, report delivery.Report, localTime time.Time) error {
+	title := fmt.Sprintf("%s, %s", cfg.Delivery.Title, localTime.Format("Monday January 2, 3:04 PM"))
+	item, err := feed.NewItem(report.AudioFile, title, report.Script, report.GeneratedAt)
+	if err != nil {
+		return err
+	}
+	return feed.Update(cfg.Feed.File, feedChannel(cfg), item, cfg.Feed.MaxItems)
+}
+
+// feedChannel describes the [feed] channel
+func feedChannel(cfg *config.Config) feed.Channel {
+	return feed.Channel{
+		Title:       cfg.Feed.Title,
+		Description: cfg.Feed.Description,
+		Link:        cfg.Feed.Link,
+	}
+}
+
+// reportValidUntil is when a report made at localTime stops being valid: the end
+// of the daypart it airs in, or [expiry] valid_hours later
+func reportValidUntil(cfg *config.Config, localTime time.Time) time.Time {
+	if cfg.Expiry.ValidHours > 0 {
+		return localTime.Add(time.Duration(cfg.Expiry.ValidHours) * time.Hour)
+	}
+	// Reports are made ahead of their slot: a 4:50 run airs at 5 AM, in the morning
+	if cfg.Expiry.LeadMinutes > 0 {
+		localTime = localTime.Add(time.Duration(cfg.Expiry.LeadMinutes) * time.Minute)
+	}
+	return api.DaypartEnd(localTime)
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"myrcast/config"
+	"myrcast/internal/delivery"
+	"myrcast/internal/logger"
+)
+
+// TestReportValidUntil tests that reports made just before a daypart boundary
+// stay valid through the daypart they air in
+func TestReportValidUntil(t *testing.T) {
+	hilo := time.FixedZone("HST", -10*60*60)
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, 3, day, hour, minute, 0, 0, hilo)
+	}
+
+	tests := []struct {
+		name   string
+		expiry config.Expiry
+		made   time.Time
+		want   time.Time
+	}{
+		{name: "Mid morning", expiry: config.Expiry{LeadMinutes: 30}, made: at(14, 9, 0), want: at(14, 12, 0)},
+		{name: "Before the 5 AM slot", expiry: config.Expiry{LeadMinutes: 30}, made: at(14, 4, 50), want: at(14, 12, 0)},
+		{name: "Just outside the lead window", expiry: config.Expiry{LeadMinutes: 30}, made: at(14, 4, 29), want: at(14, 5, 0)},
+		{name: "At the lead window", expiry: config.Expiry{LeadMinutes: 30}, made: at(14, 4, 30), want: at(14, 12, 0)},
+		{name: "Before noon", expiry: config.Expiry{LeadMinutes: 30}, made: at(14, 11, 55), want: at(14, 17, 0)},
+		{name: "Before overnight", expiry: config.Expiry{LeadMinutes: 30}, made: at(14, 21, 45), want: at(15, 5, 0)},
+		{name: "Lead window off", expiry: config.Expiry{LeadMinutes: -1}, made: at(14, 4, 50), want: at(14, 5, 0)},
+		{name: "Fixed lifetime", expiry: config.Expiry{ValidHours: 3, LeadMinutes: 30}, made: at(14, 11, 55), want: at(14, 14, 55)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{Expiry: tt.expiry}
+			if got := reportValidUntil(cfg, tt.made); !got.Equal(tt.want) {
+				t.Errorf("reportValidUntil(%s) = %s, want %s", tt.made.Format("15:04"), got, tt.want)
+			}
+		})
+	}
+}
+
+// TestGenerateFixtureNeedsRehearsal tests that fixture weather is never delivered
+// to the import folder
+func TestGenerateFixtureNeedsRehearsal(t *testing.T) {
+	if code := runGenerateCommand([]string{"--weather-fixture", "blizzard", "--config", "missing.toml"}); code != ExitUsageError {
+		t.Errorf("Expected exit code %d for a fixture run that would be delivered, got %d", ExitUsageError, code)
+	}
+	if code := runGenerateCommand([]string{"--script-only", "--output-dir", t.TempDir()}); code != ExitUsageError {
+		t.Errorf("Expected exit code %d for --output-dir with --script-only, got %d", ExitUsageError, code)
+	}
+
+	tests := []struct {
+		name    string
+		options workflowOptions
+		want    bool
+	}{
+		{name: "Live run", options: workflowOptions{}, want: false},
+		{name: "Script only", options: workflowOptions{ScriptOnly: true}, want: false},
+		{name: "Fixture", options: workflowOptions{ScriptOnly: true, Weather: weatherSource{Fixture: "blizzard"}}, want: true},
+		{name: "Output directory", options: workflowOptions{OutputDir: "/tmp/rehearsal"}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.options.rehearsal(); got != tt.want {
+				t.Errorf("rehearsal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestRDImportHelper stands in for rdimport when run as a subprocess by
+// TestDeliverReport
+func TestRDImportHelper(t *testing.T) {
+	if os.Getenv("MYRCAST_RDIMPORT_HELPER") != "1" {
+		return
+	}
+	os.Exit(0)
+}
+
+// TestDeliverReport tests that a report the adapter consumed is neither
+// recorded for the sweep nor added to the feed
+func TestDeliverReport(t *testing.T) {
+	t.Setenv("MYRCAST_RDIMPORT_HELPER", "1")
+
+	tests := []struct {
+		name        string
+		adapter     delivery.Config
+		wantInFeed  bool
+		wantExpiry  bool
+		wantRemoved bool
+	}{
+		{
+			name:       "Myriad",
+			adapter:    delivery.Config{Adapter: delivery.AdapterMyriad},
+			wantInFeed: true,
+			wantExpiry: true,
+		},
+		{
+			name: "Rivendell deleting the source",
+			adapter: delivery.Config{
+				Adapter:       delivery.AdapterRivendell,
+				SidecarFormat: delivery.SidecarJSON,
+				Rivendell: delivery.RivendellConfig{
+					Command:      []string{os.Args[0], "-test.run=^TestRDImportHelper$", "--"},
+					Group:        "WEATHER",
+					Cart:         10001,
+					DeleteSource: true,
+				},
+			},
+			wantRemoved: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{Output: config.Output{ImportPath: t.TempDir(), StageDir: t.TempDir()}}
+			cfg.Feed.Enabled = true
+			cfg.ApplyDefaults()
+
+			audio := filepath.Join(cfg.Output.ImportPath, "weather_report.mp3")
+			if err := os.WriteFile(audio, []byte("report audio"), 0644); err != nil {
+				t.Fatalf("Failed to write audio: %v", err)
+			}
+			adapter, err := delivery.New(tt.adapter)
+			if err != nil {
+				t.Fatalf("delivery.New failed: %v", err)
+			}
+			report := delivery.Report{
+				AudioFile:   audio,
+				Title:       "Weather Report",
+				Script:      "Good morning, Hilo.",
+				GeneratedAt: time.Date(2026, 3, 14, 6, 0, 0, 0, time.UTC),
+				ValidUntil:  time.Date(2026, 3, 14, 12, 0, 0, 0, time.UTC),
+			}
+			result := &workflowResult{AudioFile: audio}
+			logDir := t.TempDir()
+			if err := logger.Initialize(logger.Config{Enabled: true, Directory: logDir, FilenamePattern: "generate.log", Level: "warn"}); err != nil {
+				t.Fatalf("Failed to initialize logger: %v", err)
+			}
+			t.Cleanup(func() {
+				logger.Get().Close()
+				logger.Initialize(logger.Config{Level: "fatal", ConsoleStderr: true})
+			})
+
+			if err := deliverReport(context.Background(), cfg, adapter, report, result, report.GeneratedAt); err != nil {
+				t.Fatalf("deliverReport failed: %v", err)
+			}
+
+			if _, err := os.Stat(audio); os.IsNotExist(err) != tt.wantRemoved {
+				t.Errorf("Audio removed = %v, want %v", os.IsNotExist(err), tt.wantRemoved)
+			}
+			if _, err := os.Stat(cfg.Feed.File); (err == nil) != tt.wantInFeed {
+				t.Errorf("Feed written = %v, want %v", err == nil, tt.wantInFeed)
+			}
+			if _, err := os.Stat(expiryManifestPath(cfg)); (err == nil) != tt.wantExpiry {
+				t.Errorf("Expiry recorded = %v, want %v", err == nil, tt.wantExpiry)
+			}
+			if warnings, _ := os.ReadFile(filepath.Join(logDir, "generate.log")); len(warnings) > 0 {
+				t.Errorf("Expected no warnings, got %q", warnings)
+			}
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"myrcast/api"
+	"myrcast/config"
+	"myrcast/internal/calendar"
+)
+
+// notesTimeLayouts lists accepted --at formats, most specific first
+var notesTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+// runNotesCommand previews which broadcast notes fire for a given time and weather
+func runNotesCommand(args []string) int {
+	fs := newFlagSet("notes", "notes [options]", "Preview which broadcast notes would be added to the Claude prompt.")
+	configPath := fs.String("config", getDefaultConfigPath(), "Path to TOML configuration file (used for rules file and units)")
+	rulesPath := fs.String("rules", "", "Notes rules file (overrides [notes] rules_file)")
+	country := fs.String("country", "", "Holiday calendar country: US, CA, UK or AU (default: from config)")
+	eventsPath := fs.String("events", "", "Custom calendar events file (overrides [calendar] events_file)")
+	latitude := fs.Float64("lat", 0, "Station latitude for hemisphere-aware seasons (default: from config)")
+	seasonMode := fs.String("season-mode", "", "Season mode: meteorological or astronomical (default: from config)")
+	at := fs.String("at", "", "Time to evaluate: \"2006-01-02 15:04\", \"15:04\" (today) or RFC3339 (default: now)")
+	tz := fs.String("tz", "", "IANA time zone of the station, e.g. Pacific/Honolulu (default: host zone)")
+	temp := fs.Float64("temp", 60, "Current temperature")
+	high := fs.Float64("high", 65, "Today's high temperature")
+	low := fs.Float64("low", 50, "Today's low temperature")
+	pop := fs.Float64("pop", 0.1, "Precipitation probability (0.0-1.0)")
+	wind := fs.Float64("wind", 5, "Current wind speed")
+	windDesc := fs.String("wind-desc", "Light breeze", "Wind description")
+	conditions := fs.String("conditions", "clear sky", "Current conditions description")
+	location := fs.String("location", "", "Location name")
+	units := fs.String("units", "", "Unit system: imperial, metric or kelvin (default: from config)")
+	alerts := fs.String("alerts", "", "Comma-separated active weather alerts")
+	showAll := fs.Bool("all", false, "Also list rules that did not fire and why")
+	printDefaults := fs.Bool("print-defaults", false, "Print the built-in rules file and exit")
+
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	if *printDefaults {
+		os.Stdout.Write(api.DefaultNotesRulesTOML())
+		return ExitSuccess
+	}
+
+	// The config file is optional here; it only supplies rules, calendar, climate and units
+	rulesFile := *rulesPath
+	unitSystem := *units
+	calendarCountry := *country
+	eventsFile := *eventsPath
+	notesOptions := api.NotesOptions{Latitude: *latitude, SeasonMode: *seasonMode}
+	climateUnits := ""
+	var climateThresholds map[string]float64
+	latitudeSet := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "lat" {
+			latitudeSet = true
+		}
+	})
+	if cfg, err := config.LoadConfig(*configPath); err == nil {
+		if rulesFile == "" {
+			rulesFile = cfg.Notes.RulesFile
+		}
+		if unitSystem == "" {
+			unitSystem = cfg.Weather.Units
+		}
+		if calendarCountry == "" {
+			calendarCountry = cfg.Calendar.Country
+		}
+		if eventsFile == "" {
+			eventsFile = cfg.Calendar.EventsFile
+		}
+		if !latitudeSet {
+			notesOptions.Latitude = cfg.Weather.Latitude
+		}
+		if notesOptions.SeasonMode == "" {
+			notesOptions.SeasonMode = cfg.Climate.SeasonMode
+		}
+		climateUnits = cfg.Climate.Units
+		climateThresholds = cfg.Climate.Thresholds
+	} else {
+		var configNotFound *config.ConfigNotFoundError
+		if !errors.As(err, &configNotFound) {
+			printError("%v", err)
+			return ExitConfigError
+		}
+	}
+	if unitSystem == "" {
+		unitSystem = "imperial"
+	}
+
+	ruleSet, err := api.LoadNotesRuleSet(rulesFile)
+	if err != nil {
+		printError("%v", err)
+		return ExitValidationError
+	}
+	ruleSet, err = ruleSet.WithClimate(climateUnits, climateThresholds)
+	if err != nil {
+		printError("%v", err)
+		return ExitValidationError
+	}
+
+	holidayCalendar, err := calendar.Load(calendarCountry, eventsFile)
+	if err != nil {
+		printError("%v", err)
+		return ExitValidationError
+	}
+	notesOptions.Calendar = holidayCalendar
+
+	zone := time.Local
+	if *tz != "" {
+		if zone, err = time.LoadLocation(*tz); err != nil {
+			printError("invalid --tz: %v", err)
+			return ExitUsageError
+		}
+	}
+
+	now, err := parseNotesTime(*at, time.Now().In(zone))
+	if err != nil {
+		printError("%v", err)
+		return ExitUsageError
+	}
+
+	var alertList []string
+	for _, alert := range strings.Split(*alerts, ",") {
+		if alert = strings.TrimSpace(alert); alert != "" {
+			alertList = append(alertList, alert)
+		}
+	}
+
+	todayData := &api.TodayWeatherData{
+		TempHigh:          *high,
+		TempLow:           *low,
+		CurrentTemp:       *temp,
+		CurrentConditions: *conditions,
+		RainChance:        *pop,
+		WindConditions:    *windDesc,
+		WindSpeed:         *wind,
+		WeatherAlerts:     alertList,
+		LastUpdated:       now,
+		Units:             unitSystem,
+		Location:          *location,
+		Timezone:          *tz,
+	}
+
+	ctx, err := api.NewNotesContext(todayData, now, notesOptions)
+	if err != nil {
+		printError("%v", err)
+		return ExitGeneralError
+	}
+	fmt.Printf("Rules:   %s (%d rules)\n", ruleSet.Source, len(ruleSet.Rules))
+	fmt.Printf("Time:    %s (%s, %s)\n", now.Format("Monday, January 2, 2006 15:04 MST"), ctx.TimeOfDay, ctx.Season)
+	if ctx.IsHoliday {
+		fmt.Printf("Holiday: %s (%s calendar)\n", strings.Join(ctx.Holidays, ", "), holidayCalendar.Country())
+	}
+	fmt.Println()
+
+	matches := ruleSet.Explain(ctx)
+	fmt.Println("BROADCAST NOTES:")
+	for _, match := range matches {
+		if match.Fired {
+			fmt.Printf("  - %s  [%s]\n", match.Note, match.Rule.Name)
+		}
+	}
+
+	if *showAll {
+		fmt.Println()
+		fmt.Println("NOT FIRED:")
+		for _, match := range matches {
+			if match.Fired {
+				continue
+			}
+			reason := match.Reason
+			if reason == "" {
+				reason = "conditions not met"
+			}
+			fmt.Printf("  - %s: %s\n", match.Rule.Name, reason)
+		}
+	}
+
+	return ExitSuccess
+}
+
+// parseNotesTime parses the --at value in now's zone; a bare clock time uses today's date
+func parseNotesTime(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return now, nil
+	}
+
+	for _, layout := range notesTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+
+	if t, err := time.ParseInLocation("15:04", value, now.Location()); err == nil {
+		return time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location()), nil
+	}
+
+	return time.Time{}, fmt.Errorf("invalid --at time %q (use \"2006-01-02 15:04\", \"15:04\" or RFC3339)", value)
+}
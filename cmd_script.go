@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"myrcast/internal/logger"
+)
+
+// runScriptCommand fetches the weather and prints Claude's script without synthesizing speech
+func runScriptCommand(args []string) int {
+	fs := newFlagSet("script", "script [options]",
+		"Fetch the weather and write the report script with Claude, without calling ElevenLabs.")
+	var common commonFlags
+	common.register(fs, "warn")
+	var source weatherSource
+	source.register(fs)
+	outPath := fs.String("out", "", "Write the script to this file instead of stdout")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	cfg, code := common.setup()
+	if cfg == nil {
+		return code
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+
+	todayWeather, err := fetchWeather(ctx, cfg, source)
+	if err != nil {
+		logger.Error("%v", err)
+		return exitCodeFor(err)
+	}
+
+	script, err := generateScript(ctx, cfg, todayWeather)
+	if err != nil {
+		logger.Error("%v", err)
+		return exitCodeFor(err)
+	}
+
+	if *outPath == "" {
+		fmt.Println(script)
+		return ExitSuccess
+	}
+	if err := os.WriteFile(*outPath, []byte(script+"\n"), 0644); err != nil {
+		printError("failed to write script file: %v", err)
+		return ExitFileSystemError
+	}
+	fmt.Printf("Script written to: %s\n", *outPath)
+	return ExitSuccess
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"myrcast/config"
+	"myrcast/internal/feed"
+	"myrcast/internal/logger"
+)
+
+// serveShutdownTimeout is how long in-flight requests get to finish on shutdown
+const serveShutdownTimeout = 5 * time.Second
+
+// runServeCommand serves the latest report and the RSS feed over HTTP
+func runServeCommand(args []string) int {
+	fs := newFlagSet("serve", "serve [options]",
+		"Serve the latest report and the RSS feed over HTTP:\n"+
+			"  /latest.mp3   the newest report\n"+
+			"  /latest.json  its sidecar metadata, or a summary from the feed\n"+
+			"  /feed.xml     the RSS feed, whose enclosures point at /<file>\n"+
+			"Reports are added to the feed by runs with [feed] enabled = true.\n"+
+			"Expired reports are swept every [expiry] sweep_minutes (see 'myrcast help sweep').")
+	var common commonFlags
+	common.register(fs, "info")
+	listen := fs.String("listen", "", "Address to listen on (default: [feed] listen)")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	cfg, code := common.setup()
+	if cfg == nil {
+		return code
+	}
+	if *listen == "" {
+		*listen = cfg.Feed.Listen
+	}
+	if !cfg.Feed.Enabled {
+		logger.Warn("[feed] enabled is false, so runs don't add reports to %s", cfg.Feed.File)
+	}
+
+	server := &http.Server{
+		Addr:              *listen,
+		Handler:           newFeedServer(cfg),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), serveShutdownTimeout)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	// serve is the long-running process, so it keeps stale reports off air too
+	if cfg.Expiry.SweepMinutes > 0 {
+		go runSweepLoop(ctx, cfg)
+	}
+
+	logger.Info("Serving %s on %s", cfg.Feed.File, *listen)
+	fmt.Printf("Listening on %s (Ctrl+C to stop)\n", *listen)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		logger.Error("HTTP server failed: %v", err)
+		return ExitNetworkError
+	}
+	logger.Info("HTTP server stopped")
+	return ExitSuccess
+}
+
+// newFeedServer serves the reports in import_path, where runs write them, and the
+// feed wherever [feed] file puts it
+func newFeedServer(cfg *config.Config) *feed.Server {
+	return feed.NewServer(cfg.Output.ImportPath, cfg.Feed.File, cfg.Feed.CacheSeconds)
+}
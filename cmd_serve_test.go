@@ -0,0 +1,53 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"myrcast/config"
+	"myrcast/internal/feed"
+)
+
+// TestServeFeedOutsideImportPath tests that reports are served from import_path
+// when the feed file lives in another folder
+func TestServeFeedOutsideImportPath(t *testing.T) {
+	cfg := &config.Config{Output: config.Output{ImportPath: t.TempDir(), StageDir: t.TempDir()}}
+	cfg.ApplyDefaults()
+	if filepath.Dir(cfg.Feed.File) == cfg.Output.ImportPath {
+		t.Fatalf("Expected the default feed outside import_path, got %s", cfg.Feed.File)
+	}
+
+	audio := filepath.Join(cfg.Output.ImportPath, "weather_report.mp3")
+	if err := os.WriteFile(audio, []byte("report audio"), 0644); err != nil {
+		t.Fatalf("Failed to write audio: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(cfg.Output.ImportPath, "weather_report.json"), []byte(`{"location":"Hilo"}`), 0644); err != nil {
+		t.Fatalf("Failed to write sidecar: %v", err)
+	}
+	item, err := feed.NewItem(audio, "Weather", "Good morning, Hilo.", time.Date(2026, 3, 14, 6, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("NewItem failed: %v", err)
+	}
+	if err := feed.Update(cfg.Feed.File, feedChannel(cfg), item, cfg.Feed.MaxItems); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	server := newFeedServer(cfg)
+	for path, want := range map[string]string{
+		"/weather_report.mp3": "report audio",
+		"/latest.mp3":         "report audio",
+		"/latest.json":        `"location":"Hilo"`,
+		"/feed.xml":           "weather_report.mp3",
+	} {
+		recorder := httptest.NewRecorder()
+		server.ServeHTTP(recorder, httptest.NewRequest("GET", path, nil))
+		if recorder.Code != http.StatusOK || !strings.Contains(recorder.Body.String(), want) {
+			t.Errorf("GET %s = %d %q, want 200 containing %q", path, recorder.Code, recorder.Body.String(), want)
+		}
+	}
+}
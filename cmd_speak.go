@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"myrcast/internal/logger"
+)
+
+// runSpeakCommand synthesizes speech from given text, skipping weather and Claude
+func runSpeakCommand(args []string) int {
+	fs := newFlagSet("speak", "speak (--text TEXT | --file PATH) [options]",
+		"Synthesize speech with ElevenLabs from text you provide, using the configured voice settings.")
+	var common commonFlags
+	common.register(fs, "warn")
+	text := fs.String("text", "", "Text to speak")
+	file := fs.String("file", "", "File containing the text to speak (- for stdin)")
+	outputDir := fs.String("output-dir", "", "Directory for the audio file (default: [output] import_path)")
+	name := fs.String("name", "", "Audio file name without extension (default: [output] media_id)")
+	voice := fs.String("voice", "", "Voice ID to use instead of [elevenlabs] voice_id")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	if (*text == "") == (*file == "") {
+		return usageError(fs, "exactly one of --text or --file is required")
+	}
+
+	input := *text
+	if *file != "" {
+		data, err := readTextInput(*file)
+		if err != nil {
+			printError("%v", err)
+			return ExitFileSystemError
+		}
+		input = data
+	}
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return usageError(fs, "no text to speak")
+	}
+
+	cfg, code := common.setup()
+	if cfg == nil {
+		return code
+	}
+	if *outputDir == "" {
+		*outputDir = cfg.Output.ImportPath
+	}
+	if *name == "" {
+		*name = cfg.Output.MediaID
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+
+	speechResponse, err := synthesizeSpeech(ctx, cfg, input, *outputDir, *name, *voice)
+	if err != nil {
+		logger.Error("%v", err)
+		return exitCodeFor(err)
+	}
+
+	fmt.Printf("Audio file created: %s (%.1fs, voice %s)\n",
+		speechResponse.AudioFilePath, float64(speechResponse.DurationMs)/1000, speechResponse.VoiceUsed)
+	return ExitSuccess
+}
+
+// readTextInput reads text from a file, or from stdin when path is "-"
+func readTextInput(path string) (string, error) {
+	if path == "-" {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return "", fmt.Errorf("failed to read text from stdin: %w", err)
+		}
+		return string(data), nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read text file: %w", err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"myrcast/config"
+	"myrcast/internal/delivery"
+	"myrcast/internal/feed"
+	"myrcast/internal/logger"
+)
+
+// runSweepCommand deletes or replaces reports whose valid-until time has passed
+func runSweepCommand(args []string) int {
+	fs := newFlagSet("sweep", "sweep [options]",
+		"Take expired reports off air. Each report is valid until the end of the daypart\n"+
+			"it was made in (or [expiry] valid_hours); after that the sweep deletes it or\n"+
+			"swaps in the [fallback] evergreen_file, per [expiry] action. Only reports this\n"+
+			"tool generated and that are unchanged since are touched. With --watch the sweep\n"+
+			"keeps running as a daemon and sweeps every [expiry] sweep_minutes, as\n"+
+			"'myrcast serve' does.")
+	var common commonFlags
+	common.register(fs, "info")
+	dryRun := fs.Bool("dry-run", false, "List expired reports without changing anything")
+	watch := fs.Bool("watch", false, "Keep running and sweep every [expiry] sweep_minutes until stopped")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if *watch && *dryRun {
+		printError("--watch and --dry-run cannot be used together")
+		return ExitUsageError
+	}
+
+	cfg, code := common.setup()
+	if cfg == nil {
+		return code
+	}
+
+	if *watch {
+		if cfg.Expiry.SweepMinutes <= 0 {
+			printError("--watch needs [expiry] sweep_minutes to be a number of minutes, got %d", cfg.Expiry.SweepMinutes)
+			return ExitValidationError
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		fmt.Printf("Sweeping expired reports every %d minute(s) (Ctrl+C to stop)\n", cfg.Expiry.SweepMinutes)
+		runSweepLoop(ctx, cfg)
+		logger.Info("Sweep stopped")
+		return ExitSuccess
+	}
+
+	swept, err := sweepExpiredReports(cfg, *dryRun)
+	for _, file := range swept {
+		verb := "Deleted"
+		if file.Action == delivery.ExpiryEvergreen {
+			verb = "Replaced with evergreen"
+		}
+		if *dryRun {
+			verb = "Would delete"
+			if file.Action == delivery.ExpiryEvergreen {
+				verb = "Would replace with evergreen"
+			}
+		}
+		fmt.Printf("%s: %s (valid until %s)\n", verb, file.Path, file.ValidUntil.Format(time.RFC3339))
+	}
+	if len(swept) == 0 && err == nil {
+		fmt.Println("No expired reports")
+	}
+	if err != nil {
+		logger.Error("Sweep failed: %v", err)
+		return ExitFileSystemError
+	}
+	return ExitSuccess
+}
+
+// sweepExpiredReports runs the sweep for the configured manifest, logging each
+// report it takes off air and dropping deleted reports from the RSS feed
+func sweepExpiredReports(cfg *config.Config, dryRun bool) ([]delivery.SweptFile, error) {
+	swept, err := delivery.Sweep(expiryManifestPath(cfg), delivery.SweepConfig{
+		Action:        cfg.Expiry.Action,
+		EvergreenFile: cfg.Fallback.EvergreenFile,
+		DryRun:        dryRun,
+	}, time.Now())
+
+	var deleted []string
+	for _, file := range swept {
+		message := "Expired report removed"
+		if file.Action == delivery.ExpiryEvergreen {
+			message = "Expired report replaced with the evergreen file"
+		}
+		if dryRun {
+			message = "Expired report found (dry run)"
+		}
+		logger.LogWithFields(logger.InfoLevel, message, map[string]any{
+			"file":        file.Path,
+			"valid_until": file.ValidUntil.Format(time.RFC3339),
+			"action":      file.Action,
+		})
+		if file.Action == delivery.ExpiryDelete {
+			deleted = append(deleted, filepath.Base(file.Path))
+		}
+	}
+
+	// Deleted reports would otherwise stay in the feed as broken enclosures
+	if cfg.Feed.Enabled && !dryRun && len(deleted) > 0 {
+		if removed, feedErr := feed.Remove(cfg.Feed.File, feedChannel(cfg), deleted); feedErr != nil {
+			logger.Warn("Failed to remove expired reports from the RSS feed: %v", feedErr)
+		} else if removed > 0 {
+			logger.Debug("Removed %d expired report(s) from %s", removed, cfg.Feed.File)
+		}
+	}
+	return swept, err
+}
+
+// runSweepLoop sweeps every [expiry] sweep_minutes until ctx is done
+func runSweepLoop(ctx context.Context, cfg *config.Config) {
+	interval := time.Duration(cfg.Expiry.SweepMinutes) * time.Minute
+	logger.Info("Sweeping expired reports every %v", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := sweepExpiredReports(cfg, false); err != nil {
+			logger.Warn("Sweep failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// expiryManifestPath is where delivered reports are tracked for the sweep
+func expiryManifestPath(cfg *config.Config) string {
+	return filepath.Join(cfg.Output.StageDir, delivery.ExpiryManifestName)
+}
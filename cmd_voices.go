@@ -0,0 +1,206 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"myrcast/api"
+	"myrcast/internal/logger"
+)
+
+// runVoicesCommand dispatches the voices actions
+func runVoicesCommand(args []string) int {
+	return runCommandGroup("voices", "Browse the ElevenLabs voices available to your API key.", []command{
+		{"list", "List voices with their IDs, labels and languages", runVoicesListCommand},
+		{"audition", "Render a short sample with one or more voices", runVoicesAuditionCommand},
+	}, args)
+}
+
+// runVoicesListCommand prints the voices available to the configured API key
+func runVoicesListCommand(args []string) int {
+	fs := newFlagSet("voices list", "voices list [options]",
+		"List the ElevenLabs voices available to your API key with their labels, languages and\n"+
+			"preview URLs. The configured voice is marked with *.")
+	var common commonFlags
+	common.register(fs, "warn")
+	asJSON := fs.Bool("json", false, "Print the voices as JSON")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	cfg, code := common.setup()
+	if cfg == nil {
+		return code
+	}
+
+	elevenLabsClient, err := newElevenLabsClient(cfg)
+	if err != nil {
+		logger.Error("%v", err)
+		return exitCodeFor(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+
+	voices, err := elevenLabsClient.ListVoices(ctx)
+	if err != nil {
+		logger.Error("%v", err)
+		return exitCodeFor(err)
+	}
+	sort.Slice(voices, func(i, j int) bool {
+		return strings.ToLower(voices[i].Name) < strings.ToLower(voices[j].Name)
+	})
+
+	if *asJSON {
+		data, err := json.MarshalIndent(voices, "", "  ")
+		if err != nil {
+			printError("failed to format voices: %v", err)
+			return ExitGeneralError
+		}
+		fmt.Println(string(data))
+		return ExitSuccess
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  ID\tNAME\tCATEGORY\tLABELS\tLANGUAGES\tPREVIEW")
+	for _, voice := range voices {
+		marker := " "
+		if voice.ID == cfg.ElevenLabs.VoiceID {
+			marker = "*"
+		}
+		fmt.Fprintf(w, "%s %s\t%s\t%s\t%s\t%s\t%s\n", marker, voice.ID, voice.Name, voice.Category,
+			orDash(voice.LabelSummary()), orDash(strings.Join(voice.Languages(), ",")), orDash(voice.PreviewURL))
+	}
+	w.Flush()
+	return ExitSuccess
+}
+
+// orDash returns "-" for empty table cells so columns stay readable
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// auditionMaxChars caps audition text so trying voices doesn't eat the character quota
+const auditionMaxChars = 300
+
+// defaultAuditionText is a typical weather line used when --text is not given
+const defaultAuditionText = "Good morning! Expect sunny skies this afternoon with a high near seventy-two, " +
+	"and a light breeze tonight as temperatures dip into the mid fifties."
+
+// runVoicesAuditionCommand renders a short sample with each requested voice
+func runVoicesAuditionCommand(args []string) int {
+	fs := newFlagSet("voices audition", "voices audition --voice VOICE[,VOICE...] [options]",
+		"Render a short sample with one or more voices, using the configured [elevenlabs] model,\n"+
+			"format and voice settings. Voices can be given by ID or by name. Samples are written to a\n"+
+			"scratch directory, not the automation import folder.")
+	var common commonFlags
+	common.register(fs, "warn")
+	voiceList := fs.String("voice", "", "Voice IDs or names to audition, comma-separated")
+	text := fs.String("text", defaultAuditionText, fmt.Sprintf("Sample text to speak (at most %d characters)", auditionMaxChars))
+	outputDir := fs.String("output-dir", filepath.Join(os.TempDir(), "myrcast-audition"), "Directory for the samples")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	var requested []string
+	for _, voice := range strings.Split(*voiceList, ",") {
+		if voice = strings.TrimSpace(voice); voice != "" {
+			requested = append(requested, voice)
+		}
+	}
+	if len(requested) == 0 {
+		return usageError(fs, "--voice is required")
+	}
+	input := strings.TrimSpace(*text)
+	if input == "" {
+		return usageError(fs, "no text to speak")
+	}
+	if n := len([]rune(input)); n > auditionMaxChars {
+		return usageError(fs, fmt.Sprintf("audition text is %d characters, limit is %d; use 'myrcast speak' for longer text", n, auditionMaxChars))
+	}
+
+	cfg, code := common.setup()
+	if cfg == nil {
+		return code
+	}
+
+	elevenLabsClient, err := newElevenLabsClient(cfg)
+	if err != nil {
+		logger.Error("%v", err)
+		return exitCodeFor(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+
+	voices, err := elevenLabsClient.ListVoices(ctx)
+	if err != nil {
+		logger.Error("%v", err)
+		return exitCodeFor(err)
+	}
+	selected, err := resolveVoices(voices, requested)
+	if err != nil {
+		printError("%v", err)
+		return ExitConfigError
+	}
+
+	for _, voice := range selected {
+		speechResponse, err := synthesizeSpeech(ctx, cfg, input, *outputDir, auditionFileName(voice), voice.ID)
+		if err != nil {
+			logger.Error("%v", err)
+			return exitCodeFor(err)
+		}
+		fmt.Printf("%s (%s): %s (%.1fs)\n", voice.Name, voice.ID,
+			speechResponse.AudioFilePath, float64(speechResponse.DurationMs)/1000)
+	}
+	return ExitSuccess
+}
+
+// resolveVoices matches each reference against voice IDs, then case-insensitive names
+func resolveVoices(voices []api.Voice, refs []string) ([]api.Voice, error) {
+	var selected []api.Voice
+	for _, ref := range refs {
+		match := -1
+		for i, voice := range voices {
+			if voice.ID == ref {
+				match = i
+				break
+			}
+			if match < 0 && strings.EqualFold(voice.Name, ref) {
+				match = i
+			}
+		}
+		if match < 0 {
+			return nil, fmt.Errorf("no voice with ID or name %q (see 'myrcast voices list')", ref)
+		}
+		selected = append(selected, voices[match])
+	}
+	return selected, nil
+}
+
+// auditionFileName builds a file name such as "audition-rachel" from the voice name
+func auditionFileName(voice api.Voice) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(voice.Name) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case b.Len() > 0 && !strings.HasSuffix(b.String(), "-"):
+			b.WriteByte('-')
+		}
+	}
+	name := strings.Trim(b.String(), "-")
+	if name == "" {
+		name = voice.ID
+	}
+	return "audition-" + name
+}
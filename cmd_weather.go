@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"myrcast/internal/logger"
+)
+
+// runWeatherCommand fetches today's weather and prints the normalized data as JSON
+func runWeatherCommand(args []string) int {
+	fs := newFlagSet("weather", "weather [options]",
+		"Fetch today's weather from OpenWeather and print the normalized data passed to Claude.")
+	var common commonFlags
+	common.register(fs, "warn")
+	var source weatherSource
+	source.register(fs)
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	cfg, code := common.setup()
+	if cfg == nil {
+		return code
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+
+	todayWeather, err := fetchWeather(ctx, cfg, source)
+	if err != nil {
+		logger.Error("%v", err)
+		return exitCodeFor(err)
+	}
+
+	data, err := json.MarshalIndent(todayWeather, "", "  ")
+	if err != nil {
+		printError("failed to format weather data: %v", err)
+		return ExitGeneralError
+	}
+	fmt.Println(string(data))
+	return ExitSuccess
+}
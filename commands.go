@@ -0,0 +1,522 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"myrcast/api"
+	"myrcast/config"
+	"myrcast/internal/calendar"
+	"myrcast/internal/delivery"
+	"myrcast/internal/hooks"
+	"myrcast/internal/logger"
+)
+
+// commandTimeout bounds the network work done by a single command
+const commandTimeout = 5 * time.Minute
+
+// validLogLevels lists the accepted --log-level values
+var validLogLevels = []string{"debug", "info", "warn", "error"}
+
+// command is a myrcast subcommand (or an action of a command group)
+type command struct {
+	name    string
+	summary string
+	run     func(args []string) int
+}
+
+// commandList returns the top-level subcommands in help order
+// AIDEV-NOTE: A function rather than a package variable so commands can refer back to it (help)
+func commandList() []command {
+	return []command{
+		{"generate", "Fetch weather, write the script and synthesize audio (default)", runGenerateCommand},
+		{"config", "Create, validate or show the configuration file", runConfigCommand},
+		{"weather", "Fetch and print the normalized weather data", runWeatherCommand},
+		{"script", "Generate the report script with Claude (no speech)", runScriptCommand},
+		{"speak", "Synthesize speech from text with ElevenLabs (no weather or Claude)", runSpeakCommand},
+		{"cache", "Show or clear the weather cache", runCacheCommand},
+		{"voices", "List ElevenLabs voices or audition them with a sample", runVoicesCommand},
+		{"notes", "Preview which broadcast notes fire for a time and weather", runNotesCommand},
+		{"serve", "Serve the latest report and RSS feed over HTTP", runServeCommand},
+		{"sweep", "Delete or replace reports that are past their valid-until time", runSweepCommand},
+		{"help", "Show help for a command", runHelpCommand},
+		{"version", "Show version information", runVersionCommand},
+	}
+}
+
+// findCommand looks up a command by name
+func findCommand(commands []command, name string) (command, bool) {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd, true
+		}
+	}
+	return command{}, false
+}
+
+// appCommand is the executable name used in help text
+func appCommand() string {
+	return strings.ToLower(AppName)
+}
+
+// newFlagSet creates a subcommand flag set whose help shows usage, description and options
+func newFlagSet(name, usage, description string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		out := fs.Output()
+		fmt.Fprintf(out, "USAGE:\n  %s %s\n\n", appCommand(), usage)
+		fmt.Fprintf(out, "%s\n\n", description)
+		fmt.Fprintf(out, "OPTIONS:\n")
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseFlags parses a subcommand's arguments. It returns false with the exit
+// code to use when the command should stop (help requested or bad usage).
+func parseFlags(fs *flag.FlagSet, args []string) (int, bool) {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return ExitSuccess, false
+		}
+		return ExitUsageError, false
+	}
+	if fs.NArg() > 0 {
+		return usageError(fs, "unexpected argument %q", fs.Arg(0)), false
+	}
+	return ExitSuccess, true
+}
+
+// usageError reports a command-line mistake followed by the command's help
+func usageError(fs *flag.FlagSet, format string, args ...any) int {
+	fmt.Fprintf(fs.Output(), "Error: "+format+"\n\n", args...)
+	fs.Usage()
+	return ExitUsageError
+}
+
+// runCommandGroup dispatches "<group> <action>" commands such as "config init"
+func runCommandGroup(group, description string, actions []command, args []string) int {
+	usage := func(w io.Writer) {
+		fmt.Fprintf(w, "USAGE:\n  %s %s <command> [options]\n\n", appCommand(), group)
+		fmt.Fprintf(w, "%s\n\n", description)
+		fmt.Fprintf(w, "COMMANDS:\n")
+		for _, action := range actions {
+			fmt.Fprintf(w, "  %-10s %s\n", action.name, action.summary)
+		}
+		fmt.Fprintf(w, "\nRun '%s %s <command> --help' for command options.\n", appCommand(), group)
+	}
+
+	if len(args) == 0 {
+		usage(os.Stderr)
+		return ExitUsageError
+	}
+	if isHelpArg(args[0]) {
+		usage(os.Stdout)
+		return ExitSuccess
+	}
+
+	action, ok := findCommand(actions, args[0])
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Error: unknown %s command %q\n\n", group, args[0])
+		usage(os.Stderr)
+		return ExitUsageError
+	}
+	return action.run(args[1:])
+}
+
+// isHelpArg reports whether an argument asks for help
+func isHelpArg(arg string) bool {
+	return arg == "-h" || arg == "-help" || arg == "--help" || arg == "help"
+}
+
+// commonFlags are the configuration and logging flags shared by commands that
+// call the APIs
+type commonFlags struct {
+	configPath    string
+	logLevel      string
+	logFile       string
+	verbose       bool
+	consoleStderr bool // Log to stderr so stdout carries only the command's result
+}
+
+// register adds the common flags to fs. An empty defaultLevel means the
+// configured [logging] level applies unless --log-level is given.
+func (c *commonFlags) register(fs *flag.FlagSet, defaultLevel string) {
+	levelUsage := "Logging level (debug, info, warn, error)"
+	if defaultLevel == "" {
+		levelUsage = "Logging level (debug, info, warn, error; default: from config)"
+	}
+	fs.StringVar(&c.configPath, "config", getDefaultConfigPath(), "Path to TOML configuration file")
+	fs.StringVar(&c.logLevel, "log-level", defaultLevel, levelUsage)
+	fs.StringVar(&c.logFile, "log-file", "", "Log output file (default: stdout)")
+	fs.BoolVar(&c.verbose, "verbose", false, "Enable verbose output (equivalent to --log-level=debug)")
+}
+
+// setup starts console logging, loads and validates the configuration, then
+// reinitializes logging from the [logging] section and command-line overrides
+func (c *commonFlags) setup() (*config.Config, int) {
+	if c.verbose {
+		c.logLevel = "debug"
+	}
+	if c.logLevel != "" && !contains(validLogLevels, c.logLevel) {
+		printError("Invalid log level '%s'. Valid levels: %s",
+			c.logLevel, strings.Join(validLogLevels, ", "))
+		return nil, ExitUsageError
+	}
+
+	if err := validateConfigPath(c.configPath); err != nil {
+		printError("%v", err)
+		return nil, ExitConfigError
+	}
+
+	// Use basic console logging for config loading phase
+	startupLevel := c.logLevel
+	if startupLevel == "" {
+		startupLevel = "info"
+	}
+	tempLogConfig := logger.Config{
+		Enabled:         false, // Console only during startup
+		ConsoleOutput:   true,
+		Level:           startupLevel,
+		FilenamePattern: "myrcast-startup.log",
+		Directory:       "logs",
+		MaxFiles:        7,
+		MaxSizeMB:       10,
+		ConsoleStderr:   c.consoleStderr,
+	}
+	c.applyLogFile(&tempLogConfig)
+
+	if err := logger.Initialize(tempLogConfig); err != nil {
+		printWarning("Failed to initialize logging: %v", err)
+		// Continue with fallback logging
+	}
+
+	logger.Debug("Starting with config: %s", c.configPath)
+
+	cfg, err := config.LoadConfig(c.configPath)
+	if err != nil {
+		var configNotFound *config.ConfigNotFoundError
+		if errors.As(err, &configNotFound) {
+			logger.Error("%v", err)
+		} else {
+			logger.Error("Failed to load configuration: %v", err)
+		}
+		return nil, ExitConfigError
+	}
+	// Mask the keys in every log record from here on, including API errors
+	logger.SetSecrets(cfg.Secrets()...)
+	for _, warning := range cfg.Warnings {
+		logger.Warn("Configuration: %s", warning)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		logger.Error("Configuration validation failed: %v", err)
+		return nil, ExitValidationError
+	}
+
+	logger.Debug("Configuration loaded and validated from: %s", c.configPath)
+	for _, line := range cfg.DescribeAPIKeys() {
+		logger.Debug("API key %s", line)
+	}
+
+	// Reinitialize logging with configuration settings (unless overridden by command line)
+	finalLogConfig := logger.Config{
+		Enabled:         cfg.Logging.Enabled,
+		Directory:       cfg.Logging.Directory,
+		FilenamePattern: cfg.Logging.FilenamePattern,
+		Level:           cfg.Logging.Level,
+		Format:          cfg.Logging.Format,
+		MaxFiles:        cfg.Logging.MaxFiles,
+		MaxSizeMB:       cfg.Logging.MaxSizeMB,
+		ConsoleOutput:   cfg.Logging.ConsoleOutput,
+		ConsoleStderr:   c.consoleStderr,
+	}
+	if c.logLevel != "" {
+		finalLogConfig.Level = c.logLevel
+	}
+	c.applyLogFile(&finalLogConfig)
+
+	if err := logger.Initialize(finalLogConfig); err != nil {
+		logger.Warn("Failed to reinitialize logging with config settings: %v", err)
+		// Continue with current logging setup
+	} else {
+		logger.Debug("Enhanced logging initialized from configuration")
+	}
+
+	return cfg, ExitSuccess
+}
+
+// applyLogFile points file logging at --log-file when given
+func (c *commonFlags) applyLogFile(logConfig *logger.Config) {
+	if c.logFile == "" {
+		return
+	}
+	logConfig.Enabled = true
+	logConfig.Directory = filepath.Dir(c.logFile)
+	logConfig.FilenamePattern = filepath.Base(c.logFile)
+}
+
+// errorOutput is where command errors and warnings are printed
+var errorOutput io.Writer = os.Stderr
+
+// printError prints a command error. Errors can quote API requests and config
+// values, so the text is redacted like a log record.
+func printError(format string, args ...any) {
+	fmt.Fprintln(errorOutput, "Error: "+logger.Redact(fmt.Sprintf(format, args...)))
+}
+
+// printWarning prints a command warning, redacted like printError
+func printWarning(format string, args ...any) {
+	fmt.Fprintln(errorOutput, "Warning: "+logger.Redact(fmt.Sprintf(format, args...)))
+}
+
+// loadConfigFile loads a configuration file with defaults applied but without
+// validation, for commands that only need a few settings (cache, config show)
+func loadConfigFile(configPath string) (*config.Config, int) {
+	if err := validateConfigPath(configPath); err != nil {
+		printError("%v", err)
+		return nil, ExitConfigError
+	}
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		printError("%v", err)
+		return nil, ExitConfigError
+	}
+	// Mask the keys in every log record from here on, including API errors
+	logger.SetSecrets(cfg.Secrets()...)
+	for _, warning := range cfg.Warnings {
+		printWarning("%s", warning)
+	}
+	return cfg, ExitSuccess
+}
+
+// exitCodeFor maps a workflow error to the process exit code
+func exitCodeFor(err error) int {
+	switch {
+	case isAPIError(err):
+		return ExitAPIError
+	case isNetworkError(err):
+		return ExitNetworkError
+	case isFileSystemError(err):
+		return ExitFileSystemError
+	default:
+		return ExitGeneralError
+	}
+}
+
+// newDeliveryAdapter creates the [delivery] adapter that hands reports to the automation system
+func newDeliveryAdapter(cfg *config.Config) (delivery.Adapter, error) {
+	rivendell := cfg.Delivery.Rivendell
+	adapter, err := delivery.New(delivery.Config{
+		Adapter:       cfg.Delivery.Adapter,
+		SidecarFormat: cfg.Output.Sidecar,
+		Rivendell: delivery.RivendellConfig{
+			Command:      rivendell.Command,
+			Group:        rivendell.Group,
+			Cart:         rivendell.Cart,
+			Args:         rivendell.Args,
+			DeleteSource: rivendell.DeleteSource,
+			Timeout:      time.Duration(rivendell.TimeoutSeconds) * time.Second,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up delivery: %w", err)
+	}
+	return adapter, nil
+}
+
+// newHooks converts the [[hooks]] entries for the hooks package
+func newHooks(cfg *config.Config) []hooks.Hook {
+	var configured []hooks.Hook
+	for _, hook := range cfg.Hooks {
+		configured = append(configured, hooks.Hook{
+			Name:    hook.Name,
+			Events:  hook.Events,
+			URL:     hook.URL,
+			Headers: hook.Headers,
+			Command: hook.Command,
+			Timeout: time.Duration(hook.TimeoutSeconds) * time.Second,
+			Retries: max(hook.Attempts-1, 0),
+		})
+	}
+	return configured
+}
+
+// newWeatherClient creates the OpenWeather client
+func newWeatherClient(cfg *config.Config) *api.WeatherClientWithRateLimit {
+	return api.NewWeatherClientWithRateLimit(cfg.APIs.OpenWeather)
+}
+
+// newClaudeClient creates the Claude client with broadcast notes rules and the holiday calendar
+func newClaudeClient(cfg *config.Config) (*api.ClaudeClient, error) {
+	// Load broadcast notes rules (built-in rules when no file is configured)
+	notesRules, err := api.LoadNotesRuleSet(cfg.Notes.RulesFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load broadcast notes rules: %w", err)
+	}
+	notesRules, err = notesRules.WithClimate(cfg.Climate.Units, cfg.Climate.Thresholds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply climate thresholds: %w", err)
+	}
+	logger.Debug("Broadcast notes rules loaded from %s (%d rules)", notesRules.Source, len(notesRules.Rules))
+
+	// Load holiday calendar for broadcast notes
+	holidayCalendar, err := calendar.Load(cfg.Calendar.Country, cfg.Calendar.EventsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load holiday calendar: %w", err)
+	}
+	logger.Debug("Holiday calendar loaded for %s", holidayCalendar.Country())
+
+	claudeClient, err := api.NewClaudeClient(api.ClaudeConfig{
+		APIKey:      cfg.APIs.Anthropic,
+		Model:       cfg.Claude.Model,
+		MaxTokens:   cfg.Claude.MaxTokens,
+		Temperature: cfg.Claude.Temperature,
+		MaxRetries:  cfg.Claude.MaxRetries,
+		BaseDelay:   time.Duration(cfg.Claude.BaseDelayMs) * time.Millisecond,
+		MaxDelay:    time.Duration(cfg.Claude.MaxDelayMs) * time.Millisecond,
+		RateLimit:   cfg.Claude.RateLimit,
+		NotesRules:  notesRules,
+		Notes: api.NotesOptions{
+			Calendar:   holidayCalendar,
+			Latitude:   cfg.Weather.Latitude,
+			SeasonMode: cfg.Climate.SeasonMode,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize Claude client: %w", err)
+	}
+	return claudeClient, nil
+}
+
+// newElevenLabsClient creates the ElevenLabs client
+func newElevenLabsClient(cfg *config.Config) (*api.ElevenLabsClient, error) {
+	elevenLabsClient, err := api.NewElevenLabsClient(api.ElevenLabsConfig{
+		APIKey:     cfg.APIs.ElevenLabs,
+		VoiceID:    cfg.ElevenLabs.VoiceID,
+		Model:      cfg.ElevenLabs.Model,
+		Stability:  cfg.ElevenLabs.Stability,
+		Similarity: cfg.ElevenLabs.Similarity,
+		Style:      cfg.ElevenLabs.Style,
+		Speed:      cfg.ElevenLabs.Speed,
+		Format:     cfg.ElevenLabs.Format,
+		MaxRetries: cfg.ElevenLabs.MaxRetries,
+		BaseDelay:  time.Duration(cfg.ElevenLabs.BaseDelayMs) * time.Millisecond,
+		MaxDelay:   time.Duration(cfg.ElevenLabs.MaxDelayMs) * time.Millisecond,
+		RateLimit:  cfg.ElevenLabs.RateLimit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize ElevenLabs client: %w", err)
+	}
+	return elevenLabsClient, nil
+}
+
+// weatherSource selects where a command gets today's weather
+type weatherSource struct {
+	Fixture string // Fixture file or built-in scenario that replaces the live fetch
+	NoCache bool   // Bypass the weather cache on live fetches
+}
+
+// register adds the weather source flags to fs
+func (w *weatherSource) register(fs *flag.FlagSet) {
+	fs.StringVar(&w.Fixture, "weather-fixture", "", fmt.Sprintf(
+		"Use weather from a JSON file (One Call or normalized) or a built-in scenario (%s) instead of OpenWeather",
+		strings.Join(api.WeatherFixtureNames(), ", ")))
+	fs.BoolVar(&w.NoCache, "no-cache", false, "Bypass the weather cache (neither read nor written)")
+}
+
+// fetchWeather loads today's weather from the fixture, or from the One Call API
+// through the cache unless it is bypassed
+func fetchWeather(ctx context.Context, cfg *config.Config, source weatherSource) (*api.TodayWeatherData, error) {
+	var todayWeather *api.TodayWeatherData
+	if source.Fixture != "" {
+		logger.Warn("Using weather fixture %s instead of live OpenWeather data", source.Fixture)
+		var err error
+		if todayWeather, err = api.LoadWeatherFixture(source.Fixture, cfg.Weather.Units); err != nil {
+			return nil, fmt.Errorf("failed to load weather fixture: %w", err)
+		}
+	} else {
+		weatherClient := newWeatherClient(cfg)
+
+		var cacheManager *api.CacheManager
+		if !source.NoCache {
+			cacheManager = api.NewCacheManager(cfg.Cache.FilePath)
+			logger.Debug("Cache manager initialized with file: %s", cfg.Cache.FilePath)
+		}
+
+		logger.Info("Fetching weather data using One Call API...")
+		forecastParams := api.ForecastParams{
+			Latitude:  cfg.Weather.Latitude,
+			Longitude: cfg.Weather.Longitude,
+			Units:     cfg.Weather.Units,
+		}
+
+		var err error
+		todayWeather, _, err = weatherClient.GetTodayWeatherWithOneCallCache(ctx, forecastParams, cfg.Weather.Units, cacheManager)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch weather data: %w", err)
+		}
+	}
+
+	unit := api.GetUnitSuffix("temperature", cfg.Weather.Units)
+	logger.Debug("Weather data loaded for location: %s", todayWeather.Location)
+	logger.Debug("Current conditions: %s, %.1f%s", todayWeather.CurrentConditions, todayWeather.CurrentTemp, unit)
+	logger.Debug("Daily forecast: High=%.1f%s, Low=%.1f%s", todayWeather.TempHigh, unit, todayWeather.TempLow, unit)
+
+	return todayWeather, nil
+}
+
+// generateScript writes the weather report script for the fetched weather
+func generateScript(ctx context.Context, cfg *config.Config, todayWeather *api.TodayWeatherData) (string, error) {
+	claudeClient, err := newClaudeClient(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	logger.Info("Generating weather report script...")
+	reportResponse, err := claudeClient.GenerateWeatherReport(ctx, api.WeatherReportRequest{
+		PromptTemplate: cfg.Prompt.Template,
+		TodayData:      todayWeather,
+		Location:       fmt.Sprintf("%.4f, %.4f", cfg.Weather.Latitude, cfg.Weather.Longitude),
+		OutputPath:     cfg.Output.ImportPath,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to generate weather report script: %w", err)
+	}
+	logger.Debug("Weather report script generated successfully (%d characters)", len(reportResponse.Script))
+
+	return reportResponse.Script, nil
+}
+
+// synthesizeSpeech converts a script to audio in outputDir
+func synthesizeSpeech(ctx context.Context, cfg *config.Config, text, outputDir, fileName, voiceID string) (*api.TextToSpeechResponse, error) {
+	elevenLabsClient, err := newElevenLabsClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Converting script to speech...")
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create import directory: %w", err)
+	}
+
+	speechResponse, err := elevenLabsClient.GenerateTextToSpeech(ctx, api.TextToSpeechRequest{
+		Text:      text,
+		VoiceID:   voiceID,
+		OutputDir: outputDir,
+		FileName:  fileName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert script to speech: %w", err)
+	}
+	logger.Debug("Audio file created: %s (%d ms)", speechResponse.AudioFilePath, speechResponse.DurationMs)
+
+	return speechResponse, nil
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"myrcast/internal/logger"
+)
+
+// captureErrors sends printError and printWarning output to a buffer for the test
+func captureErrors(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	previous := errorOutput
+	errorOutput = &buf
+	t.Cleanup(func() { errorOutput = previous })
+	return &buf
+}
+
+// TestPrintErrorRedacts tests that keys in wrapped API errors never reach stderr
+func TestPrintErrorRedacts(t *testing.T) {
+	const key = "0123456789abcdef0123456789abcdef"
+	logger.SetSecrets(key)
+	t.Cleanup(func() { logger.SetSecrets() })
+	output := captureErrors(t)
+
+	cause := fmt.Errorf(`Get "https://api.openweathermap.org/data/3.0/onecall?appid=%s&lat=21.3": no such host`, key)
+	printError("%v", fmt.Errorf("failed to fetch weather data: %w", cause))
+	printWarning("retrying with key %s", key)
+	printError("%v", fmt.Errorf("geocoding failed: %w", fmt.Errorf("Get %q: timeout", "https://api.openweathermap.org/geo/1.0/direct?q=Hilo&appid=unregistered-key")))
+
+	text := output.String()
+	for _, secret := range []string{key, "unregistered-key"} {
+		if strings.Contains(text, secret) {
+			t.Errorf("Key %q printed to stderr:\n%s", secret, text)
+		}
+	}
+	for _, want := range []string{
+		"Error: failed to fetch weather data: Get \"https://api.openweathermap.org/data/3.0/onecall?appid=[REDACTED]&lat=21.3\": no such host\n",
+		"Warning: retrying with key [REDACTED]\n",
+		"appid=[REDACTED]",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected %q in:\n%s", want, text)
+		}
+	}
+}
+
+// TestShowUsageExitCodes tests that the help lists every exit code, including
+// the fallback outcomes
+func TestShowUsageExitCodes(t *testing.T) {
+	var buf bytes.Buffer
+	showUsage(&buf)
+	text := buf.String()
+
+	codes := []int{
+		ExitSuccess, ExitGeneralError, ExitConfigError, ExitValidationError, ExitAPIError,
+		ExitFileSystemError, ExitNetworkError, ExitUsageError,
+		ExitFallbackKept, ExitFallbackEvergreen, ExitFallbackRemoved,
+	}
+	start := strings.Index(text, "EXIT CODES:")
+	if start < 0 {
+		t.Fatalf("Expected an EXIT CODES section in:\n%s", text)
+	}
+	section := text[start:]
+	for _, code := range codes {
+		if !strings.Contains(section, fmt.Sprintf("%d ", code)) {
+			t.Errorf("Expected exit code %d in:\n%s", code, section)
+		}
+	}
+}
@@ -2,19 +2,28 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
+	"text/template"
+	"time"
 
-	"github.com/pelletier/go-toml/v2"
+	"myrcast/internal/calendar"
+	"myrcast/internal/delivery"
+	"myrcast/internal/feed"
+	"myrcast/internal/hooks"
 )
 
-// APIs contains API key configurations
+// APIs contains API key configurations. Each key may be given directly or as an
+// env:VAR_NAME or file:/path reference.
 type APIs struct {
-	OpenWeather string `toml:"openweather"`
-	Anthropic   string `toml:"anthropic"`
-	ElevenLabs  string `toml:"elevenlabs"`
+	OpenWeather string        `toml:"openweather"`
+	Anthropic   string        `toml:"anthropic"`
+	ElevenLabs  string        `toml:"elevenlabs"`
+	Sources     APIKeySources `toml:"-"` // Where each key was loaded from
 }
 
 // Weather contains weather query configuration
@@ -27,7 +36,13 @@ type Weather struct {
 // Output contains output path configurations
 type Output struct {
 	ImportPath string `toml:"import_path"`
-	MediaID    string `toml:"media_id"` // Base filename for generated audio (without extension)
+	MediaID    string `toml:"media_id"`  // Base filename for generated audio (without extension)
+	StageDir   string `toml:"stage_dir"` // Where the script and weather handoff files are written between stages
+
+	FilenameTemplate string `toml:"filename_template"` // Per-run audio filename, e.g. "{media_id}_{date}_{daypart}" (default: media_id)
+	Profile          string `toml:"profile"`           // Report profile name for {profile} (default: config file name)
+	Latest           string `toml:"latest"`            // Also keep the report under media_id: "none", "copy" or "link"
+	Sidecar          string `toml:"sidecar"`           // Metadata file next to each report: "none", "json" or "xml"
 }
 
 // Prompt contains AI prompt template configuration
@@ -67,6 +82,7 @@ type Logging struct {
 	Directory       string `toml:"directory"`        // Log directory (relative or absolute)
 	FilenamePattern string `toml:"filename_pattern"` // Log filename with date patterns
 	Level           string `toml:"level"`            // Log level: debug, info, warn, error
+	Format          string `toml:"format"`           // Record format: text (default) or json
 	MaxFiles        int    `toml:"max_files"`        // Number of log files to keep
 	MaxSizeMB       int    `toml:"max_size_mb"`      // Rotate when file exceeds this size
 	ConsoleOutput   bool   `toml:"console_output"`   // Also output to console
@@ -77,16 +93,111 @@ type Cache struct {
 	FilePath string `toml:"file_path"` // Path to weather cache file (JSON format)
 }
 
+// Notes contains broadcast notes rules configuration
+type Notes struct {
+	RulesFile string `toml:"rules_file"` // Path to a custom broadcast notes rules file (empty uses built-in rules)
+}
+
+// Calendar contains holiday calendar configuration
+type Calendar struct {
+	Country    string `toml:"country"`     // Holiday pack: US, CA, UK or AU
+	EventsFile string `toml:"events_file"` // Optional TOML file of custom station events
+}
+
+// Climate contains season and temperature threshold settings for broadcast notes
+type Climate struct {
+	SeasonMode string             `toml:"season_mode"` // meteorological or astronomical
+	Units      string             `toml:"units"`       // Units of the threshold overrides (default: weather.units)
+	Thresholds map[string]float64 `toml:"thresholds"`  // Overrides for named notes thresholds (hot, cool, freezing, ...)
+}
+
+// Delivery selects how finished reports reach the automation system
+type Delivery struct {
+	Adapter   string            `toml:"adapter"`   // myriad (default), rivendell or radiodj
+	Title     string            `toml:"title"`     // Title in the automation library (default: Weather Report)
+	Artist    string            `toml:"artist"`    // Artist/source in the automation library (default: Myrcast)
+	Rivendell RivendellDelivery `toml:"rivendell"` // Settings for the rivendell adapter
+}
+
+// RivendellDelivery configures rdimport for the rivendell adapter
+type RivendellDelivery struct {
+	Command        []string `toml:"command"`         // rdimport and any wrapper (default: ["rdimport"])
+	Group          string   `toml:"group"`           // Rivendell group of the cart
+	Cart           int      `toml:"cart"`            // Cart number replaced on each run
+	Args           []string `toml:"args"`            // Extra rdimport options
+	DeleteSource   bool     `toml:"delete_source"`   // Remove the audio file after a successful import
+	TimeoutSeconds int      `toml:"timeout_seconds"` // How long rdimport may run (default: 120)
+}
+
+// Feed configures the RSS feed of recent reports and the built-in HTTP server
+type Feed struct {
+	Enabled      bool   `toml:"enabled"`       // Update the feed after each report
+	Title        string `toml:"title"`         // Feed title (default: Myrcast Weather)
+	Description  string `toml:"description"`   // Feed description
+	Link         string `toml:"link"`          // Base URL the reports are served from
+	File         string `toml:"file"`          // Feed file (default: feed.xml in stage_dir)
+	MaxItems     int    `toml:"max_items"`     // Reports kept in the feed (default: 10)
+	Listen       string `toml:"listen"`        // Address for 'myrcast serve' (default: :8080)
+	CacheSeconds int    `toml:"cache_seconds"` // Cache-Control max-age for served files (default: 60)
+}
+
+// Fallback decides what the automation system gets when a run fails
+type Fallback struct {
+	Policy        string `toml:"policy"`         // none (default), keep_last, evergreen or remove
+	MaxAgeHours   int    `toml:"max_age_hours"`  // keep_last: oldest report still worth airing (default: 12)
+	EvergreenFile string `toml:"evergreen_file"` // Station-produced generic spot for evergreen and stale keep_last
+}
+
+// Expiry sets how long reports stay valid and what the sweep does once they aren't
+type Expiry struct {
+	ValidHours   int    `toml:"valid_hours"`   // Hours a report stays valid (default: 0, until the end of the daypart it airs in)
+	LeadMinutes  int    `toml:"lead_minutes"`  // Reports made this close to the next daypart air in it (default: 30; -1 turns it off)
+	Action       string `toml:"action"`        // What the sweep does with expired reports: delete (default) or evergreen
+	SweepMinutes int    `toml:"sweep_minutes"` // How often 'myrcast serve' and 'myrcast sweep --watch' sweep (default: 5; -1 turns it off in serve)
+}
+
+// Metrics configures the Prometheus textfile written after each run
+type Metrics struct {
+	Textfile string `toml:"textfile"` // .prom file in the node_exporter textfile directory (empty: off)
+}
+
+// Hook posts to a webhook or runs a command when a run succeeds, fails or finds
+// active weather alerts. Each [[hooks]] entry uses either url or command.
+type Hook struct {
+	Name           string            `toml:"name"`            // Label in the log (default: the webhook host or command)
+	Events         []string          `toml:"events"`          // success, failure and/or alert
+	URL            string            `toml:"url"`             // Webhook that receives the JSON payload
+	Headers        map[string]string `toml:"headers"`         // Extra webhook headers, e.g. Authorization
+	Command        []string          `toml:"command"`         // Program and arguments, run with MYRCAST_* variables
+	TimeoutSeconds int               `toml:"timeout_seconds"` // Per-attempt time limit (default: 10)
+	Attempts       int               `toml:"attempts"`        // Tries before giving up (default: 3)
+}
+
 // Config represents the complete application configuration
 type Config struct {
-	APIs       APIs       `toml:"apis"`
-	Weather    Weather    `toml:"weather"`
-	Output     Output     `toml:"output"`
-	Prompt     Prompt     `toml:"prompt"`
-	Claude     Claude     `toml:"claude"`
-	ElevenLabs ElevenLabs `toml:"elevenlabs"`
-	Logging    Logging    `toml:"logging"`
-	Cache      Cache      `toml:"cache"`
+	ConfigVersion int        `toml:"config_version"` // Schema version (see CurrentConfigVersion)
+	APIs          APIs       `toml:"apis"`
+	Weather       Weather    `toml:"weather"`
+	Output        Output     `toml:"output"`
+	Prompt        Prompt     `toml:"prompt"`
+	Claude        Claude     `toml:"claude"`
+	ElevenLabs    ElevenLabs `toml:"elevenlabs"`
+	Logging       Logging    `toml:"logging"`
+	Cache         Cache      `toml:"cache"`
+	Notes         Notes      `toml:"notes"`
+	Calendar      Calendar   `toml:"calendar"`
+	Climate       Climate    `toml:"climate"`
+	Delivery      Delivery   `toml:"delivery"`
+	Feed          Feed       `toml:"feed"`
+	Fallback      Fallback   `toml:"fallback"`
+	Expiry        Expiry     `toml:"expiry"`
+	Metrics       Metrics    `toml:"metrics"`
+	Hooks         []Hook     `toml:"hooks"`
+
+	// Warnings lists migrations applied in memory and deprecated keys found while loading
+	Warnings []string `toml:"-"`
+
+	unknownKeys []ValidationError // Keys in the file that match no setting
 }
 
 // LoadConfig reads and parses a TOML configuration file
@@ -105,13 +216,36 @@ func LoadConfig(configPath string) (*Config, error) {
 		return nil, fmt.Errorf("failed to read configuration file: %w", err)
 	}
 
-	// Parse TOML into Config struct
+	// Upgrade older schema versions in memory
+	migrated, err := MigrateConfigData(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to migrate configuration: %w", err)
+	}
+
+	// Parse TOML into Config struct, collecting unknown keys for Validate
 	var config Config
-	err = toml.Unmarshal(data, &config)
+	config.unknownKeys, err = decodeStrict(migrated.Data, &config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse TOML configuration: %w", err)
 	}
 
+	if migrated.FromVersion < CurrentConfigVersion {
+		config.Warnings = append(config.Warnings, fmt.Sprintf(
+			"configuration is schema version %d and was upgraded to %d in memory; run 'myrcast config migrate' to update the file",
+			migrated.FromVersion, CurrentConfigVersion))
+	}
+	config.Warnings = append(config.Warnings, migrated.Changes...)
+	config.Warnings = append(config.Warnings, migrated.Warnings...)
+
+	// Resolve env: and file: key references and environment overrides
+	config.resolveAPIKeys(filepath.Dir(cleanPath))
+
+	// The config file name is the default report profile (downtown.toml -> downtown)
+	if strings.TrimSpace(config.Output.Profile) == "" {
+		base := filepath.Base(cleanPath)
+		config.Output.Profile = strings.TrimSuffix(base, filepath.Ext(base))
+	}
+
 	// Apply default values
 	config.ApplyDefaults()
 
@@ -120,6 +254,11 @@ func LoadConfig(configPath string) (*Config, error) {
 
 // ApplyDefaults sets default values for optional configuration fields
 func (c *Config) ApplyDefaults() {
+	// Configurations built in code are always current
+	if c.ConfigVersion == 0 {
+		c.ConfigVersion = CurrentConfigVersion
+	}
+
 	// Default weather units
 	if strings.TrimSpace(c.Weather.Units) == "" {
 		c.Weather.Units = "imperial"
@@ -137,6 +276,16 @@ func (c *Config) ApplyDefaults() {
 
 	// Note: MediaID is required - no default value provided
 
+	// Default to a single report file, with no separate latest copy
+	if strings.TrimSpace(c.Output.Latest) == "" {
+		c.Output.Latest = LatestNone
+	}
+
+	// Default stage directory for script and weather handoff files
+	if strings.TrimSpace(c.Output.StageDir) == "" {
+		c.Output.StageDir = filepath.Join(os.TempDir(), "myrcast-stage")
+	}
+
 	// Default prompt template
 	if strings.TrimSpace(c.Prompt.Template) == "" {
 		c.Prompt.Template = "You are a professional radio weather announcer for morning drive time. Generate a 20-second weather report that's upbeat and informative. Include current conditions, today's high and low temperatures, and any weather to watch for. Use conversational language that sounds natural when spoken aloud. Keep it concise and engaging for busy commuters."
@@ -201,6 +350,9 @@ func (c *Config) ApplyDefaults() {
 	if strings.TrimSpace(c.Logging.Level) == "" {
 		c.Logging.Level = "info"
 	}
+	if strings.TrimSpace(c.Logging.Format) == "" {
+		c.Logging.Format = "text"
+	}
 	if c.Logging.MaxFiles <= 0 {
 		c.Logging.MaxFiles = 7 // Keep 7 days of logs by default
 	}
@@ -214,6 +366,93 @@ func (c *Config) ApplyDefaults() {
 		// Use system temp directory for cross-platform compatibility
 		c.Cache.FilePath = filepath.Join(os.TempDir(), "myrcast-weather-cache.toml")
 	}
+
+	// Default holiday calendar
+	if strings.TrimSpace(c.Calendar.Country) == "" {
+		c.Calendar.Country = calendar.DefaultCountry
+	}
+
+	// Default climate settings
+	if strings.TrimSpace(c.Climate.SeasonMode) == "" {
+		c.Climate.SeasonMode = "meteorological"
+	}
+	if strings.TrimSpace(c.Climate.Units) == "" {
+		c.Climate.Units = c.Weather.Units
+	}
+
+	// Default delivery: leave reports in the Myriad import folder
+	if strings.TrimSpace(c.Delivery.Adapter) == "" {
+		c.Delivery.Adapter = delivery.AdapterMyriad
+	}
+	if strings.TrimSpace(c.Delivery.Title) == "" {
+		c.Delivery.Title = "Weather Report"
+	}
+	if strings.TrimSpace(c.Delivery.Artist) == "" {
+		c.Delivery.Artist = "Myrcast"
+	}
+	// RadioDJ reads metadata from a sidecar, so it gets one unless another format is chosen
+	if strings.TrimSpace(c.Output.Sidecar) == "" {
+		c.Output.Sidecar = delivery.SidecarNone
+		if c.Delivery.Adapter == delivery.AdapterRadioDJ {
+			c.Output.Sidecar = delivery.SidecarJSON
+		}
+	}
+	if len(c.Delivery.Rivendell.Command) == 0 {
+		c.Delivery.Rivendell.Command = []string{"rdimport"}
+	}
+	if c.Delivery.Rivendell.TimeoutSeconds == 0 {
+		c.Delivery.Rivendell.TimeoutSeconds = 120
+	}
+
+	// Default feed settings
+	if strings.TrimSpace(c.Feed.Title) == "" {
+		c.Feed.Title = "Myrcast Weather"
+	}
+	if strings.TrimSpace(c.Feed.Description) == "" {
+		c.Feed.Description = "The latest local weather reports"
+	}
+	// Outside import_path, so the automation system never tries to import the feed
+	if strings.TrimSpace(c.Feed.File) == "" {
+		c.Feed.File = filepath.Join(c.Output.StageDir, "feed.xml")
+	}
+	if c.Feed.MaxItems == 0 {
+		c.Feed.MaxItems = feed.DefaultMaxItems
+	}
+	if strings.TrimSpace(c.Feed.Listen) == "" {
+		c.Feed.Listen = ":8080"
+	}
+	if c.Feed.CacheSeconds == 0 {
+		c.Feed.CacheSeconds = feed.DefaultCacheSeconds
+	}
+
+	// Default fallback: leave the import folder alone
+	if strings.TrimSpace(c.Fallback.Policy) == "" {
+		c.Fallback.Policy = delivery.FallbackNone
+	}
+	if c.Fallback.MaxAgeHours == 0 {
+		c.Fallback.MaxAgeHours = 12
+	}
+
+	// Default expiry: delete reports at the end of their daypart
+	if strings.TrimSpace(c.Expiry.Action) == "" {
+		c.Expiry.Action = delivery.ExpiryDelete
+	}
+	if c.Expiry.LeadMinutes == 0 {
+		c.Expiry.LeadMinutes = 30
+	}
+	if c.Expiry.SweepMinutes == 0 {
+		c.Expiry.SweepMinutes = 5
+	}
+
+	// Default hook timing
+	for i := range c.Hooks {
+		if c.Hooks[i].TimeoutSeconds == 0 {
+			c.Hooks[i].TimeoutSeconds = int(hooks.DefaultTimeout / time.Second)
+		}
+		if c.Hooks[i].Attempts == 0 {
+			c.Hooks[i].Attempts = hooks.DefaultRetries + 1
+		}
+	}
 }
 
 // ConfigNotFoundError represents a missing configuration file
@@ -222,7 +461,7 @@ type ConfigNotFoundError struct {
 }
 
 func (e *ConfigNotFoundError) Error() string {
-	return fmt.Sprintf("configuration file not found: %s\n\nTo create a sample configuration file, run:\n  %s --generate-config", e.Path, filepath.Base(os.Args[0]))
+	return fmt.Sprintf("configuration file not found: %s\n\nTo create a sample configuration file, run:\n  %s config init --config %s", e.Path, filepath.Base(os.Args[0]), e.Path)
 }
 
 // ValidationError represents a configuration validation error
@@ -239,6 +478,9 @@ func (e ValidationError) Error() string {
 func (c *Config) Validate() error {
 	var errors []ValidationError
 
+	// Report unknown keys first, since a typo often explains a later error
+	errors = append(errors, c.unknownKeys...)
+
 	// Validate API keys
 	if err := c.validateAPIKeys(); err != nil {
 		errors = append(errors, err...)
@@ -279,6 +521,51 @@ func (c *Config) Validate() error {
 		errors = append(errors, err...)
 	}
 
+	// Validate broadcast notes settings
+	if err := c.validateNotes(); err != nil {
+		errors = append(errors, err...)
+	}
+
+	// Validate holiday calendar settings
+	if err := c.validateCalendar(); err != nil {
+		errors = append(errors, err...)
+	}
+
+	// Validate climate settings
+	if err := c.validateClimate(); err != nil {
+		errors = append(errors, err...)
+	}
+
+	// Validate delivery settings
+	if err := c.validateDelivery(); err != nil {
+		errors = append(errors, err...)
+	}
+
+	// Validate feed settings
+	if err := c.validateFeed(); err != nil {
+		errors = append(errors, err...)
+	}
+
+	// Validate fallback settings
+	if err := c.validateFallback(); err != nil {
+		errors = append(errors, err...)
+	}
+
+	// Validate expiry settings
+	if err := c.validateExpiry(); err != nil {
+		errors = append(errors, err...)
+	}
+
+	// Validate metrics settings
+	if err := c.validateMetrics(); err != nil {
+		errors = append(errors, err...)
+	}
+
+	// Validate hooks
+	if err := c.validateHooks(); err != nil {
+		errors = append(errors, err...)
+	}
+
 	if len(errors) > 0 {
 		return &MultiValidationError{Errors: errors}
 	}
@@ -299,29 +586,31 @@ func (e *MultiValidationError) Error() string {
 	return fmt.Sprintf("configuration validation failed:\n  %s", strings.Join(messages, "\n  "))
 }
 
-// validateAPIKeys checks that required API keys are present
+// validateAPIKeys checks that required API keys are present, naming where each
+// missing key was expected to come from
 func (c *Config) validateAPIKeys() []ValidationError {
 	var errors []ValidationError
 
-	if strings.TrimSpace(c.APIs.OpenWeather) == "" {
-		errors = append(errors, ValidationError{
-			Field:   "apis.openweather",
-			Message: "OpenWeather API key is required. Get one at https://openweathermap.org/api",
-		})
-	}
-
-	if strings.TrimSpace(c.APIs.Anthropic) == "" {
-		errors = append(errors, ValidationError{
-			Field:   "apis.anthropic",
-			Message: "Anthropic API key is required. Get one at https://console.anthropic.com/",
-		})
+	signup := map[string]string{
+		"apis.openweather": "OpenWeather API key is required. Get one at https://openweathermap.org/api",
+		"apis.anthropic":   "Anthropic API key is required. Get one at https://console.anthropic.com/",
+		"apis.elevenlabs":  "ElevenLabs API key is required. Get one at https://elevenlabs.io/",
 	}
 
-	if strings.TrimSpace(c.APIs.ElevenLabs) == "" {
-		errors = append(errors, ValidationError{
-			Field:   "apis.elevenlabs",
-			Message: "ElevenLabs API key is required. Get one at https://elevenlabs.io/",
-		})
+	for _, entry := range c.apiKeyEntries() {
+		if entry.source.Problem != "" {
+			errors = append(errors, ValidationError{
+				Field:   entry.field,
+				Message: fmt.Sprintf("API key from %s could not be loaded: %s", entry.source.Origin, entry.source.Problem),
+			})
+			continue
+		}
+		if strings.TrimSpace(*entry.value) == "" {
+			errors = append(errors, ValidationError{
+				Field:   entry.field,
+				Message: fmt.Sprintf("%s (or set %s)", signup[entry.field], entry.envVar),
+			})
+		}
 	}
 
 	return errors
@@ -391,6 +680,28 @@ func (c *Config) validateOutput() []ValidationError {
 			Message: "media ID is required for audio filename",
 		})
 	}
+	if template := strings.TrimSpace(c.Output.FilenameTemplate); template != "" {
+		if err := checkFilenameTemplate(template); err != nil {
+			errors = append(errors, ValidationError{
+				Field:   "output.filename_template",
+				Message: err.Error(),
+			})
+		}
+	}
+	if c.Output.Sidecar != "" && !isOneOf(c.Output.Sidecar, delivery.SidecarFormats) {
+		errors = append(errors, ValidationError{
+			Field:   "output.sidecar",
+			Message: fmt.Sprintf("sidecar must be one of: %s, got '%s'", strings.Join(delivery.SidecarFormats, ", "), c.Output.Sidecar),
+		})
+	}
+	switch c.Output.Latest {
+	case "", LatestNone, LatestCopy, LatestLink:
+	default:
+		errors = append(errors, ValidationError{
+			Field:   "output.latest",
+			Message: fmt.Sprintf("latest must be %q, %q or %q", LatestNone, LatestCopy, LatestLink),
+		})
+	}
 
 	return errors
 }
@@ -551,6 +862,15 @@ func (c *Config) validateLogging() []ValidationError {
 		}
 	}
 
+	// Validate record format
+	validFormats := []string{"text", "json"}
+	if strings.TrimSpace(c.Logging.Format) != "" && !isOneOf(c.Logging.Format, validFormats) {
+		errors = append(errors, ValidationError{
+			Field:   "logging.format",
+			Message: fmt.Sprintf("format must be one of: %s, got '%s'", strings.Join(validFormats, ", "), c.Logging.Format),
+		})
+	}
+
 	// Validate max files
 	if c.Logging.MaxFiles < 0 || c.Logging.MaxFiles > 365 {
 		errors = append(errors, ValidationError{
@@ -625,42 +945,469 @@ func (c *Config) validateCache() []ValidationError {
 	return errors
 }
 
-// GenerateSampleConfig creates a sample configuration file at the specified path
-func GenerateSampleConfig(configPath string) error {
-	sampleConfig := `# Myrcast Configuration File
+// validateNotes checks broadcast notes configuration
+func (c *Config) validateNotes() []ValidationError {
+	var errors []ValidationError
+
+	// Rules file is optional; when set it must point to a readable file
+	rulesFile := strings.TrimSpace(c.Notes.RulesFile)
+	if rulesFile != "" {
+		info, err := os.Stat(rulesFile)
+		if err != nil {
+			errors = append(errors, ValidationError{
+				Field:   "notes.rules_file",
+				Message: fmt.Sprintf("cannot access notes rules file: %v", err),
+			})
+		} else if info.IsDir() {
+			errors = append(errors, ValidationError{
+				Field:   "notes.rules_file",
+				Message: fmt.Sprintf("notes rules path is a directory, not a file: %s", rulesFile),
+			})
+		}
+	}
+
+	return errors
+}
+
+// validateCalendar checks holiday calendar configuration
+func (c *Config) validateCalendar() []ValidationError {
+	var errors []ValidationError
+
+	if !calendar.IsSupportedCountry(c.Calendar.Country) {
+		errors = append(errors, ValidationError{
+			Field:   "calendar.country",
+			Message: fmt.Sprintf("unsupported country %q (valid: %s)", c.Calendar.Country, strings.Join(calendar.Countries(), ", ")),
+		})
+	}
+
+	// Events file is optional; when set it must parse cleanly
+	eventsFile := strings.TrimSpace(c.Calendar.EventsFile)
+	if eventsFile != "" {
+		if _, err := calendar.LoadEvents(eventsFile); err != nil {
+			errors = append(errors, ValidationError{
+				Field:   "calendar.events_file",
+				Message: err.Error(),
+			})
+		}
+	}
+
+	return errors
+}
+
+// validateDelivery checks the delivery adapter and its settings
+func (c *Config) validateDelivery() []ValidationError {
+	var errors []ValidationError
+
+	if c.Delivery.Adapter != "" && !isOneOf(c.Delivery.Adapter, delivery.Adapters) {
+		errors = append(errors, ValidationError{
+			Field:   "delivery.adapter",
+			Message: fmt.Sprintf("adapter must be one of: %s, got '%s'", strings.Join(delivery.Adapters, ", "), c.Delivery.Adapter),
+		})
+	}
+
+	// Rivendell settings only matter when the adapter is in use
+	if c.Delivery.Adapter == delivery.AdapterRivendell {
+		rivendell := c.Delivery.Rivendell
+		if strings.TrimSpace(rivendell.Group) == "" {
+			errors = append(errors, ValidationError{
+				Field:   "delivery.rivendell.group",
+				Message: "group is required for the rivendell adapter",
+			})
+		}
+		if rivendell.Cart < 1 || rivendell.Cart > 999999 {
+			errors = append(errors, ValidationError{
+				Field:   "delivery.rivendell.cart",
+				Message: fmt.Sprintf("cart must be between 1 and 999999, got %d", rivendell.Cart),
+			})
+		}
+		if len(rivendell.Command) > 0 && strings.TrimSpace(rivendell.Command[0]) == "" {
+			errors = append(errors, ValidationError{
+				Field:   "delivery.rivendell.command",
+				Message: "command must start with the rdimport program",
+			})
+		}
+		if rivendell.TimeoutSeconds < 0 {
+			errors = append(errors, ValidationError{
+				Field:   "delivery.rivendell.timeout_seconds",
+				Message: "timeout_seconds cannot be negative",
+			})
+		}
+	}
+
+	return errors
+}
+
+// validateFeed checks the RSS feed and HTTP server settings
+func (c *Config) validateFeed() []ValidationError {
+	var errors []ValidationError
+
+	// Enclosure URLs must be absolute for podcast clients
+	if c.Feed.Enabled {
+		link, err := url.Parse(c.Feed.Link)
+		if strings.TrimSpace(c.Feed.Link) == "" || err != nil || (link.Scheme != "http" && link.Scheme != "https") || link.Host == "" {
+			errors = append(errors, ValidationError{
+				Field:   "feed.link",
+				Message: "link must be the http:// or https:// URL the reports are served from when the feed is enabled",
+			})
+		}
+	}
+	if c.Feed.MaxItems < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "feed.max_items",
+			Message: "max_items cannot be negative",
+		})
+	}
+	if c.Feed.CacheSeconds < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "feed.cache_seconds",
+			Message: "cache_seconds cannot be negative",
+		})
+	}
+
+	return errors
+}
+
+// validateFallback checks the failed-run fallback policy
+func (c *Config) validateFallback() []ValidationError {
+	var errors []ValidationError
+
+	if c.Fallback.Policy != "" && !isOneOf(c.Fallback.Policy, delivery.FallbackPolicies) {
+		errors = append(errors, ValidationError{
+			Field:   "fallback.policy",
+			Message: fmt.Sprintf("policy must be one of: %s, got '%s'", strings.Join(delivery.FallbackPolicies, ", "), c.Fallback.Policy),
+		})
+	}
+	if c.Fallback.Policy == delivery.FallbackEvergreen && strings.TrimSpace(c.Fallback.EvergreenFile) == "" {
+		errors = append(errors, ValidationError{
+			Field:   "fallback.evergreen_file",
+			Message: "evergreen_file is required for the evergreen policy",
+		})
+	}
+	if c.Fallback.MaxAgeHours < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "fallback.max_age_hours",
+			Message: "max_age_hours cannot be negative",
+		})
+	}
+
+	// The fallback acts on media_id in import_path, so it must be the file automation plays
+	if c.Fallback.Policy != "" && c.Fallback.Policy != delivery.FallbackNone {
+		switch {
+		case c.Delivery.Adapter == delivery.AdapterRivendell:
+			errors = append(errors, ValidationError{
+				Field:   "fallback.policy",
+				Message: "the rivendell adapter imports reports into a cart, which the fallback cannot replace; use policy \"none\"",
+			})
+		case c.Output.FilenameTemplate != "" && c.Delivery.Adapter == delivery.AdapterRadioDJ:
+			errors = append(errors, ValidationError{
+				Field:   "fallback.policy",
+				Message: "the radiodj adapter imports the templated file and its sidecar, which the fallback cannot replace; remove filename_template or use policy \"none\"",
+			})
+		case c.Output.FilenameTemplate != "" && (c.Output.Latest == "" || c.Output.Latest == LatestNone):
+			errors = append(errors, ValidationError{
+				Field:   "fallback.policy",
+				Message: "with a filename_template, set output.latest to \"copy\" or \"link\" so the fallback has a fixed file to act on",
+			})
+		}
+	}
+
+	return errors
+}
+
+// validateExpiry checks the report lifetime and sweep settings
+func (c *Config) validateExpiry() []ValidationError {
+	var errors []ValidationError
+
+	if c.Expiry.ValidHours < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "expiry.valid_hours",
+			Message: "valid_hours cannot be negative",
+		})
+	}
+	// The shortest dayparts are five hours long
+	if c.Expiry.LeadMinutes < -1 || c.Expiry.LeadMinutes > 300 {
+		errors = append(errors, ValidationError{
+			Field:   "expiry.lead_minutes",
+			Message: fmt.Sprintf("lead_minutes must be between 0 and 300 minutes, or -1 to turn the lead window off, got %d", c.Expiry.LeadMinutes),
+		})
+	}
+	if c.Expiry.Action != "" && !isOneOf(c.Expiry.Action, delivery.ExpiryActions) {
+		errors = append(errors, ValidationError{
+			Field:   "expiry.action",
+			Message: fmt.Sprintf("action must be one of: %s, got '%s'", strings.Join(delivery.ExpiryActions, ", "), c.Expiry.Action),
+		})
+	}
+	if c.Expiry.Action == delivery.ExpiryEvergreen && strings.TrimSpace(c.Fallback.EvergreenFile) == "" {
+		errors = append(errors, ValidationError{
+			Field:   "expiry.action",
+			Message: "the evergreen action needs [fallback] evergreen_file",
+		})
+	}
+	if c.Expiry.SweepMinutes < -1 {
+		errors = append(errors, ValidationError{
+			Field:   "expiry.sweep_minutes",
+			Message: fmt.Sprintf("sweep_minutes must be a number of minutes, or -1 to turn the automatic sweep off, got %d", c.Expiry.SweepMinutes),
+		})
+	}
+
+	return errors
+}
+
+// validateMetrics checks the Prometheus textfile path
+func (c *Config) validateMetrics() []ValidationError {
+	var errors []ValidationError
+
+	// node_exporter's textfile collector ignores anything else
+	if textfile := strings.TrimSpace(c.Metrics.Textfile); textfile != "" && filepath.Ext(textfile) != ".prom" {
+		errors = append(errors, ValidationError{
+			Field:   "metrics.textfile",
+			Message: fmt.Sprintf("textfile must end in .prom for the node_exporter textfile collector to read it, got '%s'", textfile),
+		})
+	}
+
+	return errors
+}
+
+// validateHooks checks each [[hooks]] entry
+func (c *Config) validateHooks() []ValidationError {
+	var errors []ValidationError
+
+	for i, hook := range c.Hooks {
+		field := fmt.Sprintf("hooks[%d]", i)
+		if len(hook.Events) == 0 {
+			errors = append(errors, ValidationError{
+				Field:   field + ".events",
+				Message: fmt.Sprintf("events must list at least one of: %s", strings.Join(hooks.Events, ", ")),
+			})
+		}
+		for _, event := range hook.Events {
+			if !isOneOf(event, hooks.Events) {
+				errors = append(errors, ValidationError{
+					Field:   field + ".events",
+					Message: fmt.Sprintf("event must be one of: %s, got '%s'", strings.Join(hooks.Events, ", "), event),
+				})
+			}
+		}
+
+		hasURL := strings.TrimSpace(hook.URL) != ""
+		hasCommand := len(hook.Command) > 0
+		switch {
+		case hasURL && hasCommand:
+			errors = append(errors, ValidationError{
+				Field:   field,
+				Message: "set either url or command, not both",
+			})
+		case !hasURL && !hasCommand:
+			errors = append(errors, ValidationError{
+				Field:   field,
+				Message: "url or command is required",
+			})
+		case hasURL:
+			link, err := url.Parse(hook.URL)
+			if err != nil || (link.Scheme != "http" && link.Scheme != "https") || link.Host == "" {
+				errors = append(errors, ValidationError{
+					Field:   field + ".url",
+					Message: fmt.Sprintf("url must be an http:// or https:// URL, got '%s'", hook.URL),
+				})
+			}
+		case strings.TrimSpace(hook.Command[0]) == "":
+			errors = append(errors, ValidationError{
+				Field:   field + ".command",
+				Message: "command must start with the program to run",
+			})
+		}
+
+		if hook.TimeoutSeconds < 0 {
+			errors = append(errors, ValidationError{
+				Field:   field + ".timeout_seconds",
+				Message: "timeout_seconds cannot be negative",
+			})
+		}
+		if hook.Attempts < 0 || hook.Attempts > 10 {
+			errors = append(errors, ValidationError{
+				Field:   field + ".attempts",
+				Message: fmt.Sprintf("attempts must be between 1 and 10, got %d", hook.Attempts),
+			})
+		}
+	}
+
+	return errors
+}
+
+// validateClimate checks season mode and threshold override settings
+func (c *Config) validateClimate() []ValidationError {
+	var errors []ValidationError
+
+	validModes := []string{"meteorological", "astronomical"}
+	if c.Climate.SeasonMode != "" && !isOneOf(c.Climate.SeasonMode, validModes) {
+		errors = append(errors, ValidationError{
+			Field:   "climate.season_mode",
+			Message: fmt.Sprintf("season_mode must be one of: %s, got '%s'", strings.Join(validModes, ", "), c.Climate.SeasonMode),
+		})
+	}
+
+	validUnits := []string{"metric", "imperial", "kelvin"}
+	if c.Climate.Units != "" && !isOneOf(c.Climate.Units, validUnits) {
+		errors = append(errors, ValidationError{
+			Field:   "climate.units",
+			Message: fmt.Sprintf("units must be one of: %s, got '%s'", strings.Join(validUnits, ", "), c.Climate.Units),
+		})
+	}
+
+	// Threshold names are checked against the notes rules when they are loaded
+	for name := range c.Climate.Thresholds {
+		if strings.TrimSpace(name) == "" {
+			errors = append(errors, ValidationError{
+				Field:   "climate.thresholds",
+				Message: "threshold names cannot be empty",
+			})
+		}
+	}
+
+	return errors
+}
+
+// isOneOf reports whether value matches one of the valid options, ignoring case and spaces
+func isOneOf(value string, valid []string) bool {
+	value = strings.ToLower(strings.TrimSpace(value))
+	for _, option := range valid {
+		if value == option {
+			return true
+		}
+	}
+	return false
+}
+
+// Redacted returns a copy of the configuration with API keys and hook headers
+// masked for display
+func (c *Config) Redacted() *Config {
+	redacted := *c
+	redacted.APIs = APIs{
+		OpenWeather: maskSecret(c.APIs.OpenWeather),
+		Anthropic:   maskSecret(c.APIs.Anthropic),
+		ElevenLabs:  maskSecret(c.APIs.ElevenLabs),
+	}
+	// Webhook headers usually carry a token
+	redacted.Hooks = append([]Hook(nil), c.Hooks...)
+	for i, hook := range c.Hooks {
+		if len(hook.Headers) > 0 {
+			redacted.Hooks[i].Headers = make(map[string]string, len(hook.Headers))
+			for name, value := range hook.Headers {
+				redacted.Hooks[i].Headers[name] = maskSecret(value)
+			}
+		}
+	}
+	return &redacted
+}
+
+// maskSecret hides all but the last four characters of long secrets
+func maskSecret(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	if len(secret) <= 8 {
+		return "****"
+	}
+	return "****" + secret[len(secret)-4:]
+}
+
+// SampleSettings are the station-specific values written into a sample configuration
+type SampleSettings struct {
+	OpenWeatherKey string  // Key or env:/file: reference
+	AnthropicKey   string  // Key or env:/file: reference
+	ElevenLabsKey  string  // Key or env:/file: reference
+	LocationName   string  // Shown in the coordinates comment
+	Latitude       float64 // Forecast location
+	Longitude      float64 // Forecast location
+	Units          string  // metric, imperial or kelvin
+	ImportPath     string  // Automation import folder
+	MediaID        string  // Audio base filename
+	PromptTemplate string  // Report style instruction
+	VoiceID        string  // ElevenLabs voice
+	VoiceName      string  // Shown in the voice comment (optional)
+	Country        string  // Holiday calendar
+}
+
+// DefaultSampleSettings returns the placeholder values of the sample configuration
+func DefaultSampleSettings() SampleSettings {
+	return SampleSettings{
+		OpenWeatherKey: "your-openweather-api-key-here",
+		AnthropicKey:   "your-anthropic-api-key-here",
+		ElevenLabsKey:  "your-elevenlabs-api-key-here",
+		LocationName:   "example: San Francisco",
+		Latitude:       37.7749,
+		Longitude:      -122.4194,
+		Units:          "imperial",
+		ImportPath:     "/Users/username/Documents/Myrcast",
+		MediaID:        "weather_report",
+		PromptTemplate: PromptPresets[0].Template,
+		VoiceID:        "pNInz6obpgDQGcFmaJgB",
+		Country:        "US",
+	}
+}
+
+// sampleConfigTemplate is the commented configuration file written by config init
+var sampleConfigTemplate = template.Must(template.New("config").Funcs(template.FuncMap{
+	"quote":      tomlQuote,
+	"coordinate": func(value float64) string { return strconv.FormatFloat(value, 'f', 4, 64) },
+}).Parse(`# Myrcast Configuration File
 # Weather Report Generator with AI and Speech
 
+# Configuration schema version (upgrade with 'myrcast config migrate')
+config_version = 2
+
 [apis]
+# Keys can also be references: "env:VAR_NAME" reads an environment variable and
+# "file:/path/to/key.txt" reads a secrets file (relative to this file).
+# MYRCAST_OPENWEATHER_KEY, MYRCAST_ANTHROPIC_KEY and MYRCAST_ELEVENLABS_KEY
+# override these values when set.
+
 # Get your OpenWeather API key at: https://openweathermap.org/api
-openweather = "your-openweather-api-key-here"
+openweather = {{quote .OpenWeatherKey}}
 
 # Get your Anthropic API key at: https://console.anthropic.com/
-anthropic = "your-anthropic-api-key-here"
+anthropic = {{quote .AnthropicKey}}
 
 # Get your ElevenLabs API key at: https://elevenlabs.io/
-elevenlabs = "your-elevenlabs-api-key-here"
+elevenlabs = {{quote .ElevenLabsKey}}
 
 [weather]
-# Coordinates for your location (example: San Francisco)
-latitude = 37.7749
-longitude = -122.4194
+# Coordinates for your location ({{.LocationName}})
+latitude = {{coordinate .Latitude}}
+longitude = {{coordinate .Longitude}}
 
 # Units: "metric", "imperial", or "kelvin"
-units = "imperial"
+units = {{quote .Units}}
 
 [output]
 # Directory where Myriad should import generated content
-import_path = "/Users/username/Documents/Myrcast"
+import_path = {{quote .ImportPath}}
 
 # Base filename for generated audio files (without extension)
 # The .wav extension will be added automatically
-media_id = "weather_report"
+media_id = {{quote .MediaID}}
+
+# Directory for the script and weather files handed between stages
+# (--script-only writes them, --from-script reads an edited script back)
+# Default: a myrcast-stage folder in the system temp directory
+# stage_dir = "/Users/username/Documents/Myrcast/stage"
+
+# Name each report per slot instead of overwriting media_id
+# Tokens: {media_id} {date} {hour} {daypart} {profile} {location} {seq}
+# filename_template = "{media_id}_{date}_{daypart}"
+
+# Also keep the latest report under media_id, for a rolling cart: "none", "copy" or "link"
+# latest = "copy"
+
+# Write a metadata file next to each report (script, duration, valid-until time,
+# location, weather, alerts, voice and model): "none", "json" or "xml"
+# sidecar = "json"
 
 [prompt]
 # Template for AI weather report generation
 # Describe the style, tone, and format you want for your weather reports
 # Claude will automatically extract relevant details from the weather data provided
-template = "You are a professional radio weather announcer for morning drive time. Generate a 20-second weather report that's upbeat and informative. Include current conditions, today's high and low temperatures, and any weather to watch for. Use conversational language that sounds natural when spoken aloud. Keep it concise and engaging for busy commuters."
+template = {{quote .PromptTemplate}}
 
 [claude]
 # Claude model to use (defaults to claude-3-5-sonnet-20241022)
@@ -673,8 +1420,9 @@ max_tokens = 1000
 temperature = 0.7
 
 [elevenlabs]
-# Voice ID from ElevenLabs (find at https://elevenlabs.io/voice-library)
-voice_id = "pNInz6obpgDQGcFmaJgB"
+# Voice ID from ElevenLabs (list yours with 'myrcast voices list'){{if .VoiceName}}
+# {{.VoiceName}}{{end}}
+voice_id = {{quote .VoiceID}}
 
 # Voice model to use
 model = "eleven_multilingual_v1"
@@ -714,6 +1462,7 @@ directory = "logs"                          # Log directory (relative to working
 filename_pattern = "myrcast-YYYYMMDD.log"  # Daily rotation pattern
                                            # YYYY=year, MM=month, DD=day, HH=hour, MM=minute
 level = "info"                             # Log level: debug, info, warn, error
+format = "text"                            # text, or json for log aggregators (every record has a run_id)
 max_files = 7                              # Keep 7 days of logs (0 = unlimited)
 max_size_mb = 10                           # Rotate when file exceeds 10MB (0 = unlimited)  
 console_output = true                      # Also output to console (helpful for debugging)
@@ -727,7 +1476,141 @@ file_path = ""                             # Path to weather cache file (leave e
                                            # Default: system temp directory
                                            # Windows: %TEMP%\myrcast-weather-cache.toml
                                            # macOS/Linux: /tmp/myrcast-weather-cache.toml
-`
+
+[notes]
+# Broadcast notes rules guide the tone and topics of each report
+# Leave empty to use the built-in rules. To customize, export them with:
+#   myrcast notes --print-defaults > notes.toml
+# and preview which notes fire at a given time with:
+#   myrcast notes --at "2025-12-24 07:30"
+rules_file = ""
+
+[calendar]
+# Holiday calendar used for broadcast notes: "US", "CA", "UK" or "AU"
+country = {{quote .Country}}
+# Optional file of custom station events (anniversaries, remotes, local festivals):
+#   [[event]]
+#   name = "Station Anniversary"
+#   month = 6
+#   day = 12
+# Events can also use date = "2025-08-14", weekday + week (-1 for last),
+# or easter_offset = -2
+events_file = ""
+
+[climate]
+# Seasons follow the hemisphere of weather.latitude (south of the equator is flipped)
+# Season mode: "meteorological" (whole months) or "astronomical" (solstices/equinoxes)
+season_mode = "meteorological"
+# Units of the threshold overrides below (defaults to weather.units)
+# units = {{quote .Units}}
+# Override the named temperature thresholds used by broadcast notes so "hot"
+# matches your local climate. Built-in names: hot, cool, freezing, large_swing
+# [climate.thresholds]
+# hot = 100        # e.g. Phoenix
+# cool = 55
+
+[delivery]
+# How finished reports reach your automation system:
+#   "myriad"    - leave the file in import_path for the import watcher (default;
+#                 works with any system that watches a drop folder)
+#   "rivendell" - load the file from import_path into a cart with rdimport
+#   "radiodj"   - leave the file in import_path (RadioDJ's watched folder) with
+#                 a metadata sidecar next to it (output.sidecar, default "json")
+adapter = "myriad"
+# Title and artist shown in the automation library (album is the weather location)
+# title = "Weather Report"
+# artist = "Myrcast"
+
+# [delivery.rivendell]
+# group = "WEATHER"        # Rivendell group of the cart
+# cart = 10001             # Cart whose audio is replaced on each run
+# command = ["rdimport"]   # Or a wrapper, e.g. ["sudo", "-u", "rivendell", "rdimport"]
+# args = ["--normalization-level=-13"]  # Extra rdimport options
+# delete_source = true     # Remove the file from import_path after importing
+# timeout_seconds = 120
+
+[feed]
+# Keep an RSS 2.0 feed of recent reports (with enclosures) for websites,
+# podcast apps and smart speakers, and serve it with 'myrcast serve'
+enabled = false
+# Base URL the reports are served from; enclosure URLs are this plus the file name
+# link = "https://example.org/weather/"
+# title = "Myrcast Weather"
+# description = "The latest local weather reports"
+# file = ""              # Default: feed.xml in stage_dir
+# max_items = 10
+# listen = ":8080"       # Address for 'myrcast serve'
+# cache_seconds = 60     # How long clients may cache /latest.mp3, /latest.json and /feed.xml
+
+[fallback]
+# What the automation system gets when a run fails. The spot is media_id in
+# import_path (use latest = "copy" or "link" with a filename_template). Not
+# available with the rivendell adapter, or radiodj with a filename_template.
+# none: leave the import folder as it is
+# keep_last: keep the last good spot while it is younger than max_age_hours,
+#            then use evergreen_file (or remove the spot if there is none)
+# evergreen: replace the spot with evergreen_file
+# remove: remove the spot so automation skips it
+policy = "none"
+# max_age_hours = 12
+# evergreen_file = "/path/to/station-weather-generic.mp3"
+
+[expiry]
+# Each report is valid until the end of the daypart it airs in (morning ends
+# at noon, afternoon at 5 PM, evening at 10 PM, overnight at 5 AM). A report
+# made within lead_minutes of the next daypart airs in that one, so a 4:50 AM
+# run for the 5 AM slot stays valid until noon. 'myrcast sweep' deletes or
+# replaces reports past that time; 'myrcast sweep --watch' and 'myrcast serve'
+# sweep every sweep_minutes.
+# valid_hours = 0              # Valid for this many hours instead (0 = end of daypart)
+# lead_minutes = 30            # -1 uses the daypart the report was made in
+action = "delete"              # delete, or evergreen to swap in [fallback] evergreen_file
+# sweep_minutes = 5            # -1 turns the automatic sweep in 'myrcast serve' off
+
+[metrics]
+# Write run health (last success time, stage durations, retries, HTTP status
+# counts, tokens, characters, audio length, cache hits) for the Prometheus
+# node_exporter textfile collector after each run. Use one file per profile.
+# textfile = "/var/lib/node_exporter/textfile/myrcast.prom"
+
+# Notify other systems when a run succeeds, fails or finds active weather
+# alerts. Add one [[hooks]] table per webhook or command; each uses either url
+# (the run is POSTed as JSON) or command (the run is described in MYRCAST_*
+# environment variables). Hook failures are logged and never fail the run.
+# [[hooks]]
+# name = "on-call"
+# events = ["failure"]                         # success, failure and/or alert
+# url = "https://hooks.example.org/myrcast"
+# headers = { Authorization = "Bearer env-token-here" }
+# timeout_seconds = 10                         # Per attempt
+# attempts = 3                                 # Retried on network errors, timeouts and 5xx
+#
+# [[hooks]]
+# name = "studio-sign"
+# events = ["alert"]
+# command = ["/usr/local/bin/studio-sign", "--flash"]
+`))
+
+// RenderSampleConfig returns a commented configuration file with the given settings
+func RenderSampleConfig(settings SampleSettings) (string, error) {
+	var out strings.Builder
+	if err := sampleConfigTemplate.Execute(&out, settings); err != nil {
+		return "", fmt.Errorf("failed to render sample config: %w", err)
+	}
+	return out.String(), nil
+}
+
+// GenerateSampleConfig creates a sample configuration file at the specified path
+func GenerateSampleConfig(configPath string) error {
+	return WriteSampleConfig(configPath, DefaultSampleSettings())
+}
+
+// WriteSampleConfig writes a commented configuration file with the given settings
+func WriteSampleConfig(configPath string, settings SampleSettings) error {
+	sampleConfig, err := RenderSampleConfig(settings)
+	if err != nil {
+		return err
+	}
 
 	// Create directory if it doesn't exist
 	dir := filepath.Dir(configPath)
@@ -743,6 +1626,34 @@ file_path = ""                             # Path to weather cache file (leave e
 	return nil
 }
 
+// tomlQuote formats a string as a TOML basic string
+func tomlQuote(value string) string {
+	var out strings.Builder
+	out.WriteByte('"')
+	for _, r := range value {
+		switch r {
+		case '"':
+			out.WriteString(`\"`)
+		case '\\':
+			out.WriteString(`\\`)
+		case '\n':
+			out.WriteString(`\n`)
+		case '\t':
+			out.WriteString(`\t`)
+		case '\r':
+			out.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&out, `\u%04X`, r)
+			} else {
+				out.WriteRune(r)
+			}
+		}
+	}
+	out.WriteByte('"')
+	return out.String()
+}
+
 // isValidElevenLabsFormat validates the ElevenLabs audio format pattern
 func isValidElevenLabsFormat(format string) bool {
 	// ElevenLabs format pattern: codec_samplerate_bitrate
@@ -582,6 +582,17 @@ func TestLoggingValidationErrors(t *testing.T) {
 			},
 			expectError: "level must be one of",
 		},
+		{
+			name: "Invalid log format",
+			logging: Logging{
+				Enabled:         true,
+				Directory:       "logs",
+				FilenamePattern: "test.log",
+				Level:           "info",
+				Format:          "logfmt", // Invalid
+			},
+			expectError: "format must be one of",
+		},
 		{
 			name: "Negative max_files",
 			logging: Logging{
@@ -651,3 +662,390 @@ func TestLoggingValidationErrors(t *testing.T) {
 		})
 	}
 }
+
+// TestNotesValidation tests the optional broadcast notes rules file setting
+func TestNotesValidation(t *testing.T) {
+	tempDir := t.TempDir()
+	rulesFile := filepath.Join(tempDir, "notes.toml")
+	if err := os.WriteFile(rulesFile, []byte("[[rule]]\nnote = \"Test\"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write rules file: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		rulesFile   string
+		expectError string
+	}{
+		{name: "Empty uses built-in rules", rulesFile: ""},
+		{name: "Existing file", rulesFile: rulesFile},
+		{name: "Missing file", rulesFile: filepath.Join(tempDir, "missing.toml"), expectError: "cannot access notes rules file"},
+		{name: "Directory", rulesFile: tempDir, expectError: "is a directory"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Notes: Notes{RulesFile: tt.rulesFile}}
+			errs := cfg.validateNotes()
+
+			if tt.expectError == "" {
+				if len(errs) != 0 {
+					t.Errorf("Expected no errors, got %v", errs)
+				}
+				return
+			}
+			if len(errs) != 1 {
+				t.Fatalf("Expected 1 error, got %v", errs)
+			}
+			if errs[0].Field != "notes.rules_file" {
+				t.Errorf("Expected field notes.rules_file, got %s", errs[0].Field)
+			}
+			if !strings.Contains(errs[0].Message, tt.expectError) {
+				t.Errorf("Expected error containing %q, got %q", tt.expectError, errs[0].Message)
+			}
+		})
+	}
+}
+
+// TestCalendarValidation tests holiday calendar country and events file settings
+func TestCalendarValidation(t *testing.T) {
+	tempDir := t.TempDir()
+	eventsFile := filepath.Join(tempDir, "events.toml")
+	if err := os.WriteFile(eventsFile, []byte("[[event]]\nname = \"Station Anniversary\"\nmonth = 6\nday = 12\n"), 0644); err != nil {
+		t.Fatalf("Failed to write events file: %v", err)
+	}
+	badEventsFile := filepath.Join(tempDir, "bad.toml")
+	if err := os.WriteFile(badEventsFile, []byte("[[event]]\nname = \"Broken\"\nmonth = 13\nday = 1\n"), 0644); err != nil {
+		t.Fatalf("Failed to write events file: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		calendar    Calendar
+		expectField string
+		expectError string
+	}{
+		{name: "Default country", calendar: Calendar{Country: "US"}},
+		{name: "Lowercase alias", calendar: Calendar{Country: "gb"}},
+		{name: "Valid events file", calendar: Calendar{Country: "CA", EventsFile: eventsFile}},
+		{name: "Unknown country", calendar: Calendar{Country: "FR"}, expectField: "calendar.country", expectError: "unsupported country"},
+		{name: "Invalid event", calendar: Calendar{Country: "US", EventsFile: badEventsFile}, expectField: "calendar.events_file", expectError: "month must be between 1 and 12"},
+		{name: "Missing events file", calendar: Calendar{Country: "US", EventsFile: filepath.Join(tempDir, "missing.toml")}, expectField: "calendar.events_file", expectError: "failed to read"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Calendar: tt.calendar}
+			errs := cfg.validateCalendar()
+
+			if tt.expectError == "" {
+				if len(errs) != 0 {
+					t.Errorf("Expected no errors, got %v", errs)
+				}
+				return
+			}
+			if len(errs) != 1 {
+				t.Fatalf("Expected 1 error, got %v", errs)
+			}
+			if errs[0].Field != tt.expectField {
+				t.Errorf("Expected field %s, got %s", tt.expectField, errs[0].Field)
+			}
+			if !strings.Contains(errs[0].Message, tt.expectError) {
+				t.Errorf("Expected error containing %q, got %q", tt.expectError, errs[0].Message)
+			}
+		})
+	}
+}
+
+// TestClimateValidation tests season mode and threshold override settings
+func TestClimateValidation(t *testing.T) {
+	tests := []struct {
+		name        string
+		climate     Climate
+		expectField string
+		expectError string
+	}{
+		{name: "Meteorological", climate: Climate{SeasonMode: "meteorological", Units: "imperial"}},
+		{name: "Astronomical with overrides", climate: Climate{SeasonMode: "Astronomical", Units: "metric", Thresholds: map[string]float64{"hot": 38}}},
+		{name: "Unknown season mode", climate: Climate{SeasonMode: "solar", Units: "imperial"}, expectField: "climate.season_mode", expectError: "season_mode must be one of"},
+		{name: "Unknown units", climate: Climate{SeasonMode: "meteorological", Units: "celsius"}, expectField: "climate.units", expectError: "units must be one of"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Climate: tt.climate}
+			errs := cfg.validateClimate()
+
+			if tt.expectError == "" {
+				if len(errs) != 0 {
+					t.Errorf("Expected no errors, got %v", errs)
+				}
+				return
+			}
+			if len(errs) != 1 {
+				t.Fatalf("Expected 1 error, got %v", errs)
+			}
+			if errs[0].Field != tt.expectField {
+				t.Errorf("Expected field %s, got %s", tt.expectField, errs[0].Field)
+			}
+			if !strings.Contains(errs[0].Message, tt.expectError) {
+				t.Errorf("Expected error containing %q, got %q", tt.expectError, errs[0].Message)
+			}
+		})
+	}
+
+	// Climate units follow the weather units by default
+	cfg := &Config{Weather: Weather{Units: "metric"}}
+	cfg.ApplyDefaults()
+	if cfg.Climate.Units != "metric" || cfg.Climate.SeasonMode != "meteorological" {
+		t.Errorf("Expected metric/meteorological climate defaults, got %s/%s", cfg.Climate.Units, cfg.Climate.SeasonMode)
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	cfg := &Config{
+		APIs:    APIs{OpenWeather: "0123456789abcdef", Anthropic: "short", ElevenLabs: ""},
+		Weather: Weather{Latitude: 21.3},
+		Hooks:   []Hook{{URL: "https://example.org/hook", Headers: map[string]string{"Authorization": "Bearer 0123456789"}}},
+	}
+
+	redacted := cfg.Redacted()
+	if redacted.APIs.OpenWeather != "****cdef" {
+		t.Errorf("Expected last four characters kept, got %q", redacted.APIs.OpenWeather)
+	}
+	if redacted.APIs.Anthropic != "****" {
+		t.Errorf("Expected short key fully masked, got %q", redacted.APIs.Anthropic)
+	}
+	if redacted.APIs.ElevenLabs != "" {
+		t.Errorf("Expected empty key to stay empty, got %q", redacted.APIs.ElevenLabs)
+	}
+	if redacted.Weather.Latitude != 21.3 {
+		t.Error("Expected non-secret settings to be preserved")
+	}
+	if header := redacted.Hooks[0].Headers["Authorization"]; header != "****6789" {
+		t.Errorf("Expected hook header masked, got %q", header)
+	}
+	if cfg.APIs.OpenWeather != "0123456789abcdef" || cfg.Hooks[0].Headers["Authorization"] != "Bearer 0123456789" {
+		t.Error("Redacted must not modify the original configuration")
+	}
+}
+
+func TestFallbackValidation(t *testing.T) {
+	tests := []struct {
+		name        string
+		fallback    Fallback
+		output      Output
+		adapter     string
+		expectField string
+		expectError string
+	}{
+		{name: "None", fallback: Fallback{Policy: "none"}},
+		{name: "Keep last without evergreen", fallback: Fallback{Policy: "keep_last", MaxAgeHours: 6}},
+		{name: "Evergreen", fallback: Fallback{Policy: "evergreen", EvergreenFile: "/audio/weather-generic.mp3"}},
+		{name: "Unknown policy", fallback: Fallback{Policy: "skip"}, expectField: "fallback.policy", expectError: "policy must be one of"},
+		{name: "Evergreen without file", fallback: Fallback{Policy: "evergreen"}, expectField: "fallback.evergreen_file", expectError: "required"},
+		{name: "Negative age", fallback: Fallback{Policy: "keep_last", MaxAgeHours: -1}, expectField: "fallback.max_age_hours", expectError: "cannot be negative"},
+		{name: "Template with latest copy", fallback: Fallback{Policy: "remove"}, output: Output{FilenameTemplate: "weather_{daypart}", Latest: "copy"}},
+		{name: "Template without latest", fallback: Fallback{Policy: "remove"}, output: Output{FilenameTemplate: "weather_{daypart}", Latest: "none"}, expectField: "fallback.policy", expectError: "set output.latest"},
+		{name: "Template without latest and no policy", fallback: Fallback{Policy: "none"}, output: Output{FilenameTemplate: "weather_{daypart}"}},
+		{name: "Rivendell", fallback: Fallback{Policy: "keep_last"}, adapter: "rivendell", expectField: "fallback.policy", expectError: "rivendell"},
+		{name: "RadioDJ", fallback: Fallback{Policy: "keep_last"}, adapter: "radiodj"},
+		{name: "RadioDJ with template", fallback: Fallback{Policy: "keep_last"}, adapter: "radiodj", output: Output{FilenameTemplate: "weather_{daypart}", Latest: "copy"}, expectField: "fallback.policy", expectError: "radiodj"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Fallback: tt.fallback, Output: tt.output, Delivery: Delivery{Adapter: tt.adapter}}
+			errs := cfg.validateFallback()
+
+			if tt.expectError == "" {
+				if len(errs) != 0 {
+					t.Errorf("Expected no errors, got %v", errs)
+				}
+				return
+			}
+			if len(errs) != 1 {
+				t.Fatalf("Expected 1 error, got %v", errs)
+			}
+			if errs[0].Field != tt.expectField {
+				t.Errorf("Expected field %s, got %s", tt.expectField, errs[0].Field)
+			}
+			if !strings.Contains(errs[0].Message, tt.expectError) {
+				t.Errorf("Expected error containing %q, got %q", tt.expectError, errs[0].Message)
+			}
+		})
+	}
+}
+
+func TestExpiryValidation(t *testing.T) {
+	tests := []struct {
+		name        string
+		expiry      Expiry
+		fallback    Fallback
+		expectField string
+		expectError string
+	}{
+		{name: "Delete at end of daypart", expiry: Expiry{Action: "delete", SweepMinutes: 5}},
+		{name: "Evergreen after four hours", expiry: Expiry{ValidHours: 4, Action: "evergreen", SweepMinutes: -1}, fallback: Fallback{EvergreenFile: "/audio/weather-generic.mp3"}},
+		{name: "Unknown action", expiry: Expiry{Action: "archive"}, expectField: "expiry.action", expectError: "action must be one of"},
+		{name: "Evergreen without file", expiry: Expiry{Action: "evergreen"}, expectField: "expiry.action", expectError: "evergreen_file"},
+		{name: "Negative hours", expiry: Expiry{ValidHours: -2, Action: "delete"}, expectField: "expiry.valid_hours", expectError: "cannot be negative"},
+		{name: "Negative interval", expiry: Expiry{Action: "delete", SweepMinutes: -5}, expectField: "expiry.sweep_minutes", expectError: "-1 to turn"},
+		{name: "Lead window off", expiry: Expiry{Action: "delete", LeadMinutes: -1}},
+		{name: "Negative lead", expiry: Expiry{Action: "delete", LeadMinutes: -10}, expectField: "expiry.lead_minutes", expectError: "-1 to turn the lead window off"},
+		{name: "Lead longer than a daypart", expiry: Expiry{Action: "delete", LeadMinutes: 360}, expectField: "expiry.lead_minutes", expectError: "between 0 and 300"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Expiry: tt.expiry, Fallback: tt.fallback}
+			errs := cfg.validateExpiry()
+
+			if tt.expectError == "" {
+				if len(errs) != 0 {
+					t.Errorf("Expected no errors, got %v", errs)
+				}
+				return
+			}
+			if len(errs) != 1 {
+				t.Fatalf("Expected 1 error, got %v", errs)
+			}
+			if errs[0].Field != tt.expectField {
+				t.Errorf("Expected field %s, got %s", tt.expectField, errs[0].Field)
+			}
+			if !strings.Contains(errs[0].Message, tt.expectError) {
+				t.Errorf("Expected error containing %q, got %q", tt.expectError, errs[0].Message)
+			}
+		})
+	}
+}
+
+func TestMetricsValidation(t *testing.T) {
+	tests := []struct {
+		name        string
+		textfile    string
+		expectError string
+	}{
+		{name: "Off", textfile: ""},
+		{name: "Textfile", textfile: "/var/lib/node_exporter/textfile/myrcast.prom"},
+		{name: "Wrong extension", textfile: "/var/lib/node_exporter/textfile/myrcast.txt", expectError: "must end in .prom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Metrics: Metrics{Textfile: tt.textfile}}
+			errs := cfg.validateMetrics()
+
+			if tt.expectError == "" {
+				if len(errs) != 0 {
+					t.Errorf("Expected no errors, got %v", errs)
+				}
+				return
+			}
+			if len(errs) != 1 || errs[0].Field != "metrics.textfile" {
+				t.Fatalf("Expected 1 metrics.textfile error, got %v", errs)
+			}
+			if !strings.Contains(errs[0].Message, tt.expectError) {
+				t.Errorf("Expected error containing %q, got %q", tt.expectError, errs[0].Message)
+			}
+		})
+	}
+}
+
+func TestHooksValidation(t *testing.T) {
+	tests := []struct {
+		name        string
+		hook        Hook
+		expectField string
+		expectError string
+	}{
+		{name: "Webhook", hook: Hook{Events: []string{"failure", "alert"}, URL: "https://hooks.example.org/myrcast"}},
+		{name: "Command", hook: Hook{Events: []string{"success"}, Command: []string{"/usr/local/bin/notify", "--channel", "ops"}}},
+		{name: "No events", hook: Hook{URL: "https://hooks.example.org/myrcast"}, expectField: "hooks[0].events", expectError: "at least one of"},
+		{name: "Unknown event", hook: Hook{Events: []string{"finished"}, URL: "https://hooks.example.org/myrcast"}, expectField: "hooks[0].events", expectError: "event must be one of"},
+		{name: "Neither url nor command", hook: Hook{Events: []string{"failure"}}, expectField: "hooks[0]", expectError: "url or command is required"},
+		{name: "Both url and command", hook: Hook{Events: []string{"failure"}, URL: "https://hooks.example.org/myrcast", Command: []string{"notify"}}, expectField: "hooks[0]", expectError: "not both"},
+		{name: "Relative url", hook: Hook{Events: []string{"failure"}, URL: "hooks.example.org/myrcast"}, expectField: "hooks[0].url", expectError: "http:// or https://"},
+		{name: "Too many attempts", hook: Hook{Events: []string{"failure"}, URL: "https://hooks.example.org/myrcast", Attempts: 50}, expectField: "hooks[0].attempts", expectError: "between 1 and 10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Hooks: []Hook{tt.hook}}
+			errs := cfg.validateHooks()
+
+			if tt.expectError == "" {
+				if len(errs) != 0 {
+					t.Errorf("Expected no errors, got %v", errs)
+				}
+				return
+			}
+			if len(errs) != 1 {
+				t.Fatalf("Expected 1 error, got %v", errs)
+			}
+			if errs[0].Field != tt.expectField {
+				t.Errorf("Expected field %s, got %s", tt.expectField, errs[0].Field)
+			}
+			if !strings.Contains(errs[0].Message, tt.expectError) {
+				t.Errorf("Expected error containing %q, got %q", tt.expectError, errs[0].Message)
+			}
+		})
+	}
+
+	// Hooks get a timeout and retries by default
+	cfg := &Config{Hooks: []Hook{{Events: []string{"failure"}, URL: "https://hooks.example.org/myrcast"}}}
+	cfg.ApplyDefaults()
+	if cfg.Hooks[0].TimeoutSeconds != 10 || cfg.Hooks[0].Attempts != 3 {
+		t.Errorf("Expected 10s timeout and 3 attempts, got %ds and %d", cfg.Hooks[0].TimeoutSeconds, cfg.Hooks[0].Attempts)
+	}
+}
+
+// TestRenderSampleConfig tests that wizard answers round-trip through the sample template
+func TestRenderSampleConfig(t *testing.T) {
+	settings := DefaultSampleSettings()
+	settings.OpenWeatherKey = "env:STATION_OWM"
+	settings.LocationName = "Winnipeg, Manitoba, CA"
+	settings.Latitude = 49.8954
+	settings.Longitude = -97.1385
+	settings.Units = "metric"
+	settings.ImportPath = `C:\Myriad\Import`
+	settings.MediaID = "wx_morning"
+	settings.PromptTemplate = PromptPresets[2].Template + ` Say "eh" once.`
+	settings.VoiceID = "voice123"
+	settings.VoiceName = "Adam"
+	settings.Country = "CA"
+
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	if err := WriteSampleConfig(configPath, settings); err != nil {
+		t.Fatalf("WriteSampleConfig failed: %v", err)
+	}
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+	for _, want := range []string{"# Coordinates for your location (Winnipeg, Manitoba, CA)", "# Adam\nvoice_id"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("Expected rendered config to contain %q", want)
+		}
+	}
+
+	t.Setenv("STATION_OWM", "owm-key")
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.APIs.OpenWeather != "owm-key" {
+		t.Errorf("Expected env: reference to be kept, got %q", cfg.APIs.OpenWeather)
+	}
+	if cfg.Weather.Latitude != 49.8954 || cfg.Weather.Units != "metric" || cfg.Climate.Units != "metric" {
+		t.Errorf("Unexpected weather settings: %+v, climate units %q", cfg.Weather, cfg.Climate.Units)
+	}
+	if cfg.Output.ImportPath != `C:\Myriad\Import` || cfg.Output.MediaID != "wx_morning" {
+		t.Errorf("Unexpected output settings: %+v", cfg.Output)
+	}
+	if cfg.Prompt.Template != settings.PromptTemplate {
+		t.Errorf("Expected prompt to survive quoting, got %q", cfg.Prompt.Template)
+	}
+	if cfg.ElevenLabs.VoiceID != "voice123" || cfg.Calendar.Country != "CA" {
+		t.Errorf("Unexpected voice %q or calendar %q", cfg.ElevenLabs.VoiceID, cfg.Calendar.Country)
+	}
+}
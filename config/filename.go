@@ -0,0 +1,116 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// AIDEV-NOTE: [output] filename_template names each report from tokens such as
+// {date} and {daypart}, so a run can write per-slot files instead of overwriting
+// media_id. Stage files keep using media_id so --from-script still finds them.
+
+// Latest-file modes for [output] latest
+const (
+	LatestNone = "none" // Only write the templated file
+	LatestCopy = "copy" // Also copy the report to media_id
+	LatestLink = "link" // Also point a symlink named media_id at the report
+)
+
+// filenameTokenPattern matches {token} placeholders in a filename template
+var filenameTokenPattern = regexp.MustCompile(`\{([^{}]*)\}`)
+
+// filenameTokens are the tokens a filename template can use
+var filenameTokens = []string{"media_id", "date", "hour", "daypart", "profile", "location", "seq"}
+
+// maxFilenameSequence bounds the search for an unused {seq} number
+const maxFilenameSequence = 999
+
+// FilenameValues supplies the run-specific values for filename template tokens
+type FilenameValues struct {
+	Time     time.Time // Report time in the forecast location's time zone
+	Daypart  string    // Daypart of Time
+	Location string    // Weather location name
+}
+
+// AudioBaseName returns the audio file name without extension for this run. With
+// no filename_template it is the media ID. exists reports whether a candidate name
+// is already taken, and is used to pick the {seq} number.
+func (c *Config) AudioBaseName(values FilenameValues, exists func(baseName string) bool) (string, error) {
+	template := strings.TrimSpace(c.Output.FilenameTemplate)
+	if template == "" {
+		return c.Output.MediaID, nil
+	}
+	if err := checkFilenameTemplate(template); err != nil {
+		return "", err
+	}
+
+	location := slugify(values.Location)
+	if location == "" {
+		location = "unknown"
+	}
+	replacements := map[string]string{
+		"media_id": c.Output.MediaID,
+		"date":     values.Time.Format("2006-01-02"),
+		"hour":     values.Time.Format("15"),
+		"daypart":  values.Daypart,
+		"profile":  slugify(c.Output.Profile),
+		"location": location,
+	}
+	render := func(seq int) string {
+		return filenameTokenPattern.ReplaceAllStringFunc(template, func(token string) string {
+			name := token[1 : len(token)-1]
+			if name == "seq" {
+				return fmt.Sprintf("%02d", seq)
+			}
+			return replacements[name]
+		})
+	}
+
+	if !strings.Contains(template, "{seq}") {
+		return render(0), nil
+	}
+	for seq := 1; seq <= maxFilenameSequence; seq++ {
+		if name := render(seq); !exists(name) {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("no unused {seq} number left for filename template %q", template)
+}
+
+// checkFilenameTemplate reports unknown tokens and characters that are not allowed in file names
+func checkFilenameTemplate(template string) error {
+	for _, match := range filenameTokenPattern.FindAllStringSubmatch(template, -1) {
+		if !slices.Contains(filenameTokens, match[1]) {
+			return fmt.Errorf("unknown token {%s} (use {%s})", match[1], strings.Join(filenameTokens, "}, {"))
+		}
+	}
+	literal := filenameTokenPattern.ReplaceAllString(template, "")
+	if strings.ContainsAny(literal, "{}") {
+		return fmt.Errorf("unbalanced braces in %q", template)
+	}
+	if strings.ContainsAny(literal, `<>:"/\|?*`) {
+		return fmt.Errorf("%q contains characters that are not allowed in file names", template)
+	}
+	return nil
+}
+
+// slugify lowercases s and joins its letters and digits with hyphens ("San Francisco, CA" -> "san-francisco-ca")
+func slugify(s string) string {
+	var b strings.Builder
+	pendingHyphen := false
+	for _, r := range strings.ToLower(s) {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+			if pendingHyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			pendingHyphen = false
+		} else {
+			pendingHyphen = true
+		}
+	}
+	return b.String()
+}
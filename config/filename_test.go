@@ -0,0 +1,115 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestAudioBaseName tests filename template rendering
+func TestAudioBaseName(t *testing.T) {
+	reportTime := time.Date(2026, 3, 14, 6, 30, 0, 0, time.UTC)
+	values := FilenameValues{Time: reportTime, Daypart: "morning", Location: "San Francisco, CA"}
+
+	tests := []struct {
+		name     string
+		template string
+		taken    []string
+		want     string
+	}{
+		{"no template uses media ID", "", nil, "weather_report"},
+		{"date and daypart", "{media_id}_{date}_{daypart}", nil, "weather_report_2026-03-14_morning"},
+		{"hour and location", "wx-{location}-{hour}", nil, "wx-san-francisco-ca-06"},
+		{"profile", "{profile}_{date}", nil, "downtown-am_2026-03-14"},
+		{"first sequence number", "{media_id}_{seq}", nil, "weather_report_01"},
+		{"next unused sequence number", "{media_id}_{seq}", []string{"weather_report_01", "weather_report_02"}, "weather_report_03"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Output: Output{MediaID: "weather_report", Profile: "Downtown AM", FilenameTemplate: tt.template}}
+			exists := func(baseName string) bool {
+				for _, taken := range tt.taken {
+					if taken == baseName {
+						return true
+					}
+				}
+				return false
+			}
+
+			got, err := cfg.AudioBaseName(values, exists)
+			if err != nil {
+				t.Fatalf("AudioBaseName failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestAudioBaseNameUnknownLocation tests the placeholder for a run without weather data
+func TestAudioBaseNameUnknownLocation(t *testing.T) {
+	cfg := &Config{Output: Output{MediaID: "wx", FilenameTemplate: "{media_id}_{location}"}}
+	got, err := cfg.AudioBaseName(FilenameValues{Time: time.Now()}, func(string) bool { return false })
+	if err != nil {
+		t.Fatalf("AudioBaseName failed: %v", err)
+	}
+	if got != "wx_unknown" {
+		t.Errorf("Expected wx_unknown, got %q", got)
+	}
+}
+
+// TestFilenameTemplateValidation tests that bad templates and latest modes are reported
+func TestFilenameTemplateValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		template  string
+		latest    string
+		wantField string
+		wantText  string
+	}{
+		{"valid", "{media_id}_{date}_{seq}", LatestLink, "", ""},
+		{"unknown token", "{media_id}_{slot}", "", "output.filename_template", "unknown token {slot}"},
+		{"unbalanced brace", "{media_id}_{date", "", "output.filename_template", "unbalanced braces"},
+		{"path separator", "reports/{date}", "", "output.filename_template", "not allowed"},
+		{"bad latest mode", "", "symlink", "output.latest", `"none", "copy" or "link"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Output: Output{ImportPath: "/tmp", MediaID: "wx", FilenameTemplate: tt.template, Latest: tt.latest}}
+			errs := cfg.validateOutput()
+			if tt.wantField == "" {
+				if len(errs) != 0 {
+					t.Errorf("Expected no errors, got %v", errs)
+				}
+				return
+			}
+			if len(errs) != 1 {
+				t.Fatalf("Expected one error, got %v", errs)
+			}
+			if errs[0].Field != tt.wantField {
+				t.Errorf("Expected error for %s, got %v", tt.wantField, errs[0])
+			}
+			if !strings.Contains(errs[0].Message, tt.wantText) {
+				t.Errorf("Expected message containing %q, got %q", tt.wantText, errs[0].Message)
+			}
+		})
+	}
+}
+
+// TestSlugify tests location and profile slugs
+func TestSlugify(t *testing.T) {
+	tests := map[string]string{
+		"San Francisco, CA": "san-francisco-ca",
+		"  Honolulu  ":      "honolulu",
+		"St. John's":        "st-john-s",
+		"":                  "",
+	}
+	for input, want := range tests {
+		if got := slugify(input); got != want {
+			t.Errorf("slugify(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
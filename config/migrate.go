@@ -0,0 +1,346 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// AIDEV-NOTE: Migrations edit the TOML text line by line instead of round-tripping
+// through the Config struct, so comments, ordering and formatting survive
+// 'config migrate'. LoadConfig runs the same migrations in memory and surfaces
+// what changed as warnings. To change the schema: bump CurrentConfigVersion, add
+// a migration from the previous version, and update GenerateSampleConfig.
+
+// CurrentConfigVersion is the configuration schema version written by this release
+const CurrentConfigVersion = 2
+
+// migration upgrades configuration text from one version to the next
+type migration struct {
+	from  int
+	apply func(doc *configDocument) []string // Returns a note for each change made
+}
+
+// migrations lists the schema upgrades in order
+var migrations = []migration{
+	{from: 1, apply: migrateV1ToV2},
+}
+
+// deprecatedKey describes a key that is no longer read
+type deprecatedKey struct {
+	section string
+	key     string // Empty matches every key in the section
+	message string
+}
+
+// deprecatedKeys are reported when they appear in a current-version file. No
+// key has been retired yet; add one here when it is removed from Config.
+var deprecatedKeys []deprecatedKey
+
+// MigrationResult describes an upgrade of configuration file contents
+type MigrationResult struct {
+	FromVersion int      // Version found in the file (1 when config_version is missing)
+	Data        []byte   // Upgraded file contents (unchanged when already current)
+	Changes     []string // What each migration step changed
+	Warnings    []string // Deprecated keys that are still present and ignored
+}
+
+// Changed reports whether the migration modified the file contents
+func (r *MigrationResult) Changed() bool {
+	return r.FromVersion != CurrentConfigVersion || len(r.Changes) > 0
+}
+
+// MigrateConfigData upgrades configuration file contents to CurrentConfigVersion
+func MigrateConfigData(data []byte) (*MigrationResult, error) {
+	doc := newConfigDocument(data)
+
+	version, err := doc.version()
+	if err != nil {
+		return nil, err
+	}
+	if version > CurrentConfigVersion {
+		return nil, fmt.Errorf("config_version %d is newer than this release of myrcast supports (%d); upgrade myrcast", version, CurrentConfigVersion)
+	}
+
+	result := &MigrationResult{FromVersion: version}
+	for _, m := range migrations {
+		if m.from < version {
+			continue
+		}
+		for _, change := range m.apply(doc) {
+			result.Changes = append(result.Changes, fmt.Sprintf("version %d -> %d: %s", m.from, m.from+1, change))
+		}
+	}
+	if version < CurrentConfigVersion {
+		doc.setVersion(CurrentConfigVersion)
+	}
+
+	result.Warnings = doc.deprecationWarnings()
+	result.Data = doc.bytes()
+	return result, nil
+}
+
+// migrateV1ToV2 expands short audio format names and adds the [cache] section
+// introduced with caching
+func migrateV1ToV2(doc *configDocument) []string {
+	var changes []string
+
+	legacyFormats := map[string]string{
+		"mp3":  "mp3_44100_128",
+		"pcm":  "pcm_44100",
+		"wav":  "pcm_44100",
+		"ulaw": "ulaw_8000",
+	}
+	if value, ok := doc.value("elevenlabs", "format"); ok {
+		format := strings.Trim(value, `"'`)
+		if replacement, ok := legacyFormats[strings.ToLower(format)]; ok {
+			doc.set("elevenlabs", "format", strconv.Quote(replacement))
+			changes = append(changes, fmt.Sprintf("changed elevenlabs.format %q to %q", format, replacement))
+		}
+	}
+
+	if !doc.hasSection("cache") {
+		doc.appendLines("",
+			"[cache]",
+			"# Weather data cache file (default: system temp directory)",
+			`# file_path = "/path/to/weather_cache.json"`)
+		changes = append(changes, "added the [cache] section (defaults apply)")
+	}
+
+	return changes
+}
+
+var (
+	headerPattern = regexp.MustCompile(`^\s*\[\s*([A-Za-z0-9_.-]+)\s*\]\s*(#.*)?$`)
+	keyPattern    = regexp.MustCompile(`^\s*([A-Za-z0-9_-]+)\s*=\s*(.*?)\s*$`)
+)
+
+// configDocument is configuration text edited line by line to preserve comments
+type configDocument struct {
+	lines []string
+	crlf  bool // Write Windows line endings back
+}
+
+// docLine is a classified line of a configuration document
+type docLine struct {
+	section string // Enclosing table ("" before the first header)
+	header  bool   // Line is a [table] header
+	key     string // Key assigned on this line (empty for other lines)
+	value   string // Raw value text including any trailing comment
+	span    int    // Number of lines the assignment occupies (multi-line strings)
+}
+
+// newConfigDocument splits configuration text into lines
+func newConfigDocument(data []byte) *configDocument {
+	doc := &configDocument{crlf: strings.Contains(string(data), "\r\n")}
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	text = strings.TrimSuffix(text, "\n")
+	if text != "" {
+		doc.lines = strings.Split(text, "\n")
+	}
+	return doc
+}
+
+// bytes joins the document back into file contents
+func (d *configDocument) bytes() []byte {
+	newline := "\n"
+	if d.crlf {
+		newline = "\r\n"
+	}
+	return []byte(strings.Join(d.lines, newline) + newline)
+}
+
+// scan classifies each line, skipping the bodies of multi-line strings
+func (d *configDocument) scan() []docLine {
+	parsed := make([]docLine, len(d.lines))
+	section := ""
+	for i := 0; i < len(d.lines); i++ {
+		line := d.lines[i]
+		parsed[i].section = section
+		if match := headerPattern.FindStringSubmatch(line); match != nil {
+			section = match[1]
+			parsed[i] = docLine{section: section, header: true}
+			continue
+		}
+		match := keyPattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		parsed[i] = docLine{section: section, key: match[1], value: match[2], span: 1}
+
+		// Multi-line strings continue until the closing delimiter
+		for _, delim := range []string{`"""`, `'''`} {
+			if !strings.HasPrefix(match[2], delim) || strings.Count(match[2], delim) >= 2 {
+				continue
+			}
+			for j := i + 1; j < len(d.lines); j++ {
+				parsed[j].section = section
+				parsed[i].span++
+				if strings.Contains(d.lines[j], delim) {
+					break
+				}
+			}
+			i += parsed[i].span - 1
+		}
+	}
+	return parsed
+}
+
+// find returns the line index of a key, or -1
+func (d *configDocument) find(section, key string) int {
+	for i, line := range d.scan() {
+		if line.section == section && line.key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// value returns the raw value text of a single-line key, without its comment
+func (d *configDocument) value(section, key string) (string, bool) {
+	i := d.find(section, key)
+	if i < 0 {
+		return "", false
+	}
+	line := d.scan()[i]
+	if line.span > 1 {
+		return strings.Join(append([]string{line.value}, d.lines[i+1:i+line.span]...), "\n"), true
+	}
+	return stripComment(line.value), true
+}
+
+// set replaces a key's value in place, keeping its indentation and comment, or
+// adds it to the end of its section
+func (d *configDocument) set(section, key, value string) {
+	if i := d.find(section, key); i >= 0 {
+		line := d.scan()[i]
+		indent := d.lines[i][:len(d.lines[i])-len(strings.TrimLeft(d.lines[i], " \t"))]
+		updated := indent + key + " = " + value
+		if comment := strings.TrimPrefix(line.value, stripComment(line.value)); line.span == 1 && strings.TrimSpace(comment) != "" {
+			updated += " " + strings.TrimSpace(comment)
+		}
+		d.replace(i, line.span, strings.Split(updated, "\n"))
+		return
+	}
+	if !d.hasSection(section) {
+		d.appendLines("", "["+section+"]")
+	}
+	d.insert(d.sectionEnd(section), strings.Split(key+" = "+value, "\n"))
+}
+
+// hasSection reports whether the document has a [section] header
+func (d *configDocument) hasSection(section string) bool {
+	return d.headerIndex(section) >= 0
+}
+
+// headerIndex returns the line index of a section header, or -1
+func (d *configDocument) headerIndex(section string) int {
+	for i, line := range d.scan() {
+		if line.header && line.section == section {
+			return i
+		}
+	}
+	return -1
+}
+
+// sectionEnd returns the index after the last key of a section, so new keys land
+// before any blank lines and comments that introduce the next section
+func (d *configDocument) sectionEnd(section string) int {
+	end := d.headerIndex(section) + 1
+	for i, line := range d.scan() {
+		if line.section == section && line.key != "" {
+			end = i + line.span
+		}
+	}
+	return end
+}
+
+// version reads config_version, treating files without it as version 1
+func (d *configDocument) version() (int, error) {
+	value, ok := d.value("", "config_version")
+	if !ok {
+		return 1, nil
+	}
+	version, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || version < 1 {
+		return 0, fmt.Errorf("config_version must be a positive whole number, got %s", value)
+	}
+	return version, nil
+}
+
+// setVersion writes config_version before the first section, after the file's
+// opening comment block
+func (d *configDocument) setVersion(version int) {
+	line := fmt.Sprintf("config_version = %d", version)
+	if i := d.find("", "config_version"); i >= 0 {
+		d.lines[i] = line
+		return
+	}
+	insertAt := 0
+	for insertAt < len(d.lines) && strings.HasPrefix(strings.TrimSpace(d.lines[insertAt]), "#") {
+		insertAt++
+	}
+	lines := []string{"# Configuration schema version (upgrade with 'myrcast config migrate')", line, ""}
+	if insertAt > 0 {
+		lines = append([]string{""}, lines...)
+		if insertAt < len(d.lines) && strings.TrimSpace(d.lines[insertAt]) == "" {
+			lines = lines[:len(lines)-1]
+		}
+	}
+	d.insert(insertAt, lines)
+}
+
+// deprecationWarnings lists deprecated keys that are present and ignored
+func (d *configDocument) deprecationWarnings() []string {
+	var warnings []string
+	for _, line := range d.scan() {
+		if line.key == "" {
+			continue
+		}
+		for _, deprecated := range deprecatedKeys {
+			if line.section == deprecated.section && (deprecated.key == "" || deprecated.key == line.key) {
+				warnings = append(warnings, fmt.Sprintf("%s.%s is deprecated and ignored: %s", line.section, line.key, deprecated.message))
+			}
+		}
+	}
+	return warnings
+}
+
+// appendLines adds lines to the end of the document
+func (d *configDocument) appendLines(lines ...string) {
+	d.lines = append(d.lines, lines...)
+}
+
+// insert adds lines before index i
+func (d *configDocument) insert(i int, lines []string) {
+	d.replace(i, 0, lines)
+}
+
+// replace swaps count lines starting at i for the given lines
+func (d *configDocument) replace(i, count int, lines []string) {
+	updated := make([]string, 0, len(d.lines)-count+len(lines))
+	updated = append(updated, d.lines[:i]...)
+	updated = append(updated, lines...)
+	updated = append(updated, d.lines[i+count:]...)
+	d.lines = updated
+}
+
+// stripComment removes a trailing comment from a single-line value
+func stripComment(value string) string {
+	var quote byte
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case quote == '"' && c == '\\':
+			i++ // Skip the escaped character
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote == 0 && (c == '"' || c == '\''):
+			quote = c
+		case quote == 0 && c == '#':
+			return strings.TrimSpace(value[:i])
+		}
+	}
+	return strings.TrimSpace(value)
+}
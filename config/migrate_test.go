@@ -0,0 +1,186 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const legacyConfig = `# Station config - do not delete the comments below
+# Maintained by engineering
+
+[apis]
+openweather = "owm" # weather key
+anthropic = "sk-ant"
+elevenlabs = "xi"
+
+[prompt]
+template = """
+Keep it short.
+[cache] is not a table in here
+"""
+
+[elevenlabs]
+voice_id = "voice123"
+model = "eleven_multilingual_v1"
+format = "mp3"   # old short format name
+`
+
+// TestMigrateV1Config tests upgrading an unversioned config while keeping comments
+func TestMigrateV1Config(t *testing.T) {
+	result, err := MigrateConfigData([]byte(legacyConfig))
+	if err != nil {
+		t.Fatalf("MigrateConfigData failed: %v", err)
+	}
+
+	if result.FromVersion != 1 {
+		t.Errorf("Expected version 1 for a file without config_version, got %d", result.FromVersion)
+	}
+	if !result.Changed() {
+		t.Fatal("Expected the legacy config to change")
+	}
+
+	migrated := string(result.Data)
+	for _, want := range []string{
+		"# Station config - do not delete the comments below\n# Maintained by engineering\n\n# Configuration schema version",
+		"config_version = 2\n\n[apis]",
+		`openweather = "owm" # weather key`,
+		"[cache] is not a table in here",
+		`format = "mp3_44100_128" # old short format name`,
+		`voice_id = "voice123"`,
+		`model = "eleven_multilingual_v1"`,
+		"\n[cache]\n",
+	} {
+		if !strings.Contains(migrated, want) {
+			t.Errorf("Expected migrated file to contain %q:\n%s", want, migrated)
+		}
+	}
+
+	changes := strings.Join(result.Changes, "\n")
+	for _, want := range []string{
+		`changed elevenlabs.format "mp3" to "mp3_44100_128"`,
+		"added the [cache] section",
+	} {
+		if !strings.Contains(changes, want) {
+			t.Errorf("Expected change %q in:\n%s", want, changes)
+		}
+	}
+
+	// Migrating again is a no-op
+	again, err := MigrateConfigData(result.Data)
+	if err != nil {
+		t.Fatalf("Second migration failed: %v", err)
+	}
+	if again.Changed() || string(again.Data) != migrated {
+		t.Errorf("Expected second migration to change nothing, got %v", again.Changes)
+	}
+}
+
+// TestLoadConfigMigratesInMemory tests that LoadConfig applies migrations with warnings
+func TestLoadConfigMigratesInMemory(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(configPath, []byte(strings.ReplaceAll(legacyConfig, "\n", "\r\n")), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.ConfigVersion != CurrentConfigVersion {
+		t.Errorf("Expected version %d, got %d", CurrentConfigVersion, cfg.ConfigVersion)
+	}
+	if cfg.ElevenLabs.Format != "mp3_44100_128" {
+		t.Errorf("Expected expanded format, got %q", cfg.ElevenLabs.Format)
+	}
+	if len(cfg.Warnings) == 0 || !strings.Contains(cfg.Warnings[0], "config migrate") {
+		t.Errorf("Expected a warning pointing at 'config migrate', got %v", cfg.Warnings)
+	}
+
+	// The file itself is untouched
+	data, _ := os.ReadFile(configPath)
+	if strings.Contains(string(data), "config_version") {
+		t.Error("LoadConfig must not rewrite the file")
+	}
+}
+
+// TestMigrateCurrentConfig tests current files, deprecated keys and future versions
+func TestMigrateCurrentConfig(t *testing.T) {
+	// No key is deprecated yet, so the test retires one of its own
+	previous := deprecatedKeys
+	deprecatedKeys = []deprecatedKey{{section: "legacy", message: "move these settings to [elevenlabs]"}}
+	t.Cleanup(func() { deprecatedKeys = previous })
+
+	tests := []struct {
+		name        string
+		data        string
+		wantChanged bool
+		wantWarning string
+		wantErr     string
+	}{
+		{
+			name: "current version",
+			data: "config_version = 2\n\n[apis]\nopenweather = \"owm\"\n",
+		},
+		{
+			name:        "deprecated section in current file",
+			data:        "config_version = 2\n\n[legacy]\nvoice_id = \"abc\"\n",
+			wantWarning: "legacy.voice_id is deprecated and ignored: move these settings to [elevenlabs]",
+		},
+		{
+			name:    "newer version",
+			data:    "config_version = 99\n",
+			wantErr: "upgrade myrcast",
+		},
+		{
+			name:    "invalid version",
+			data:    "config_version = \"two\"\n",
+			wantErr: "positive whole number",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := MigrateConfigData([]byte(tt.data))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("MigrateConfigData failed: %v", err)
+			}
+			if result.Changed() != tt.wantChanged {
+				t.Errorf("Expected changed=%v, got %v (%v)", tt.wantChanged, result.Changed(), result.Changes)
+			}
+			warnings := strings.Join(result.Warnings, "\n")
+			if tt.wantWarning == "" && warnings != "" {
+				t.Errorf("Expected no warnings, got %q", warnings)
+			}
+			if !strings.Contains(warnings, tt.wantWarning) {
+				t.Errorf("Expected warning containing %q, got %q", tt.wantWarning, warnings)
+			}
+		})
+	}
+}
+
+// TestSampleConfigIsCurrent tests that generated configs need no migration
+func TestSampleConfigIsCurrent(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	if err := GenerateSampleConfig(configPath); err != nil {
+		t.Fatalf("GenerateSampleConfig failed: %v", err)
+	}
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("Failed to read sample config: %v", err)
+	}
+	result, err := MigrateConfigData(data)
+	if err != nil {
+		t.Fatalf("MigrateConfigData failed: %v", err)
+	}
+	if result.Changed() || len(result.Warnings) > 0 {
+		t.Errorf("Expected sample config to be current, got changes %v warnings %v", result.Changes, result.Warnings)
+	}
+}
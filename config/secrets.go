@@ -0,0 +1,164 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// AIDEV-NOTE: API keys can be kept out of config.toml. A key value of "env:NAME"
+// reads the environment variable NAME and "file:PATH" reads a secrets file (relative
+// paths are relative to the config file). The standard MYRCAST_*_KEY variables
+// override whatever the file says. Only the source is ever reported, never the key.
+
+// Reference prefixes for API key values
+const (
+	envKeyPrefix  = "env:"
+	fileKeyPrefix = "file:"
+)
+
+// Standard environment variables that override the API keys in the config file
+const (
+	EnvOpenWeatherKey = "MYRCAST_OPENWEATHER_KEY"
+	EnvAnthropicKey   = "MYRCAST_ANTHROPIC_KEY"
+	EnvElevenLabsKey  = "MYRCAST_ELEVENLABS_KEY"
+)
+
+// KeySource records where an API key was loaded from
+type KeySource struct {
+	Origin  string // Human-readable source, e.g. "config file" or "file:/run/secrets/owm"
+	Problem string // Why a reference could not be resolved (empty when it was)
+}
+
+// APIKeySources records the source of each API key
+type APIKeySources struct {
+	OpenWeather KeySource
+	Anthropic   KeySource
+	ElevenLabs  KeySource
+}
+
+// apiKeyEntry ties an API key field to its config name, override variable and source
+type apiKeyEntry struct {
+	field  string
+	envVar string
+	value  *string
+	source *KeySource
+}
+
+// apiKeyEntries lists the API keys in config file order
+func (c *Config) apiKeyEntries() []apiKeyEntry {
+	return []apiKeyEntry{
+		{"apis.openweather", EnvOpenWeatherKey, &c.APIs.OpenWeather, &c.APIs.Sources.OpenWeather},
+		{"apis.anthropic", EnvAnthropicKey, &c.APIs.Anthropic, &c.APIs.Sources.Anthropic},
+		{"apis.elevenlabs", EnvElevenLabsKey, &c.APIs.ElevenLabs, &c.APIs.Sources.ElevenLabs},
+	}
+}
+
+// resolveAPIKeys replaces env: and file: references with the keys they point to
+// and applies the standard environment overrides. Unresolvable references leave
+// the key empty and record the problem for Validate to report.
+func (c *Config) resolveAPIKeys(configDir string) {
+	for _, entry := range c.apiKeyEntries() {
+		if override := strings.TrimSpace(os.Getenv(entry.envVar)); override != "" {
+			*entry.value = override
+			*entry.source = KeySource{Origin: "environment variable " + entry.envVar}
+			continue
+		}
+		*entry.value, *entry.source = ResolveKeyReference(*entry.value, configDir)
+	}
+}
+
+// ResolveKeyReference returns the key a config value refers to and its source.
+// Plain values are returned unchanged.
+func ResolveKeyReference(value, configDir string) (string, KeySource) {
+	switch {
+	case strings.HasPrefix(value, envKeyPrefix):
+		name := strings.TrimSpace(strings.TrimPrefix(value, envKeyPrefix))
+		source := KeySource{Origin: envKeyPrefix + name}
+		key := strings.TrimSpace(os.Getenv(name))
+		if name == "" {
+			source.Problem = "env: reference has no variable name"
+		} else if key == "" {
+			source.Problem = fmt.Sprintf("environment variable %s is not set", name)
+		}
+		return key, source
+
+	case strings.HasPrefix(value, fileKeyPrefix):
+		path := strings.TrimSpace(strings.TrimPrefix(value, fileKeyPrefix))
+		if path != "" && !filepath.IsAbs(path) && configDir != "" {
+			path = filepath.Join(configDir, path)
+		}
+		source := KeySource{Origin: fileKeyPrefix + path}
+		if path == "" {
+			source.Problem = "file: reference has no path"
+			return "", source
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			source.Problem = fmt.Sprintf("cannot read secrets file: %v", err)
+			return "", source
+		}
+		key := strings.TrimSpace(string(data))
+		if key == "" {
+			source.Problem = "secrets file is empty"
+		}
+		return key, source
+
+	default:
+		return value, KeySource{Origin: "config file"}
+	}
+}
+
+// DescribeAPIKeys lists where each API key came from, without revealing the keys
+func (c *Config) DescribeAPIKeys() []string {
+	var lines []string
+	for _, entry := range c.apiKeyEntries() {
+		origin := entry.source.Origin
+		if origin == "" {
+			origin = "config file"
+		}
+		status := "set"
+		if strings.TrimSpace(*entry.value) == "" {
+			status = "missing"
+		}
+		lines = append(lines, fmt.Sprintf("%s: %s (%s)", entry.field, origin, status))
+	}
+	return lines
+}
+
+// Secrets lists the credential values that must never appear in logs: the API
+// keys, webhook URLs (Slack and Teams put the token in the path) and the values
+// of credential headers such as Authorization
+func (c *Config) Secrets() []string {
+	values := []string{c.APIs.OpenWeather, c.APIs.Anthropic, c.APIs.ElevenLabs}
+	for _, hook := range c.Hooks {
+		values = append(values, hook.URL)
+		for name, value := range hook.Headers {
+			if !isCredentialHeader(name) {
+				continue
+			}
+			values = append(values, value)
+			// "Bearer <token>": the token on its own is just as secret
+			if _, token, found := strings.Cut(value, " "); found {
+				values = append(values, token)
+			}
+		}
+	}
+	return values
+}
+
+// credentialHeaderWords mark header names that carry credentials, e.g.
+// Authorization, X-Api-Key or X-Auth-Token
+var credentialHeaderWords = []string{"auth", "key", "token", "secret", "signature", "password", "cookie"}
+
+// isCredentialHeader reports whether a header's value is a credential
+func isCredentialHeader(name string) bool {
+	name = strings.ToLower(name)
+	for _, word := range credentialHeaderWords {
+		if strings.Contains(name, word) {
+			return true
+		}
+	}
+	return false
+}
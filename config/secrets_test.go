@@ -0,0 +1,179 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// TestAPIKeyReferences tests env: and file: references and the standard overrides
+func TestAPIKeyReferences(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "anthropic.key"), []byte("sk-ant-from-file\n"), 0600); err != nil {
+		t.Fatalf("Failed to write secrets file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "empty.key"), []byte("\n"), 0600); err != nil {
+		t.Fatalf("Failed to write secrets file: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		value       string
+		env         map[string]string
+		wantKey     string
+		wantOrigin  string
+		wantProblem string
+	}{
+		{
+			name:       "plain value",
+			value:      "sk-ant-inline",
+			wantKey:    "sk-ant-inline",
+			wantOrigin: "config file",
+		},
+		{
+			name:       "env reference",
+			value:      "env:STATION_ANTHROPIC",
+			env:        map[string]string{"STATION_ANTHROPIC": "sk-ant-from-env"},
+			wantKey:    "sk-ant-from-env",
+			wantOrigin: "env:STATION_ANTHROPIC",
+		},
+		{
+			name:        "env reference not set",
+			value:       "env:STATION_ANTHROPIC",
+			wantOrigin:  "env:STATION_ANTHROPIC",
+			wantProblem: "is not set",
+		},
+		{
+			name:       "relative file reference",
+			value:      "file:anthropic.key",
+			wantKey:    "sk-ant-from-file",
+			wantOrigin: "file:" + filepath.Join(tmpDir, "anthropic.key"),
+		},
+		{
+			name:        "missing file",
+			value:       "file:missing.key",
+			wantOrigin:  "file:" + filepath.Join(tmpDir, "missing.key"),
+			wantProblem: "cannot read secrets file",
+		},
+		{
+			name:        "empty file",
+			value:       "file:empty.key",
+			wantOrigin:  "file:" + filepath.Join(tmpDir, "empty.key"),
+			wantProblem: "secrets file is empty",
+		},
+		{
+			name:       "standard variable overrides file value",
+			value:      "file:missing.key",
+			env:        map[string]string{EnvAnthropicKey: "sk-ant-override"},
+			wantKey:    "sk-ant-override",
+			wantOrigin: "environment variable " + EnvAnthropicKey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(EnvAnthropicKey, "")
+			t.Setenv("STATION_ANTHROPIC", "")
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			configPath := filepath.Join(tmpDir, "config.toml")
+			content := "[apis]\nopenweather = \"owm\"\nanthropic = \"" + tt.value + "\"\nelevenlabs = \"xi\"\n"
+			if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+
+			cfg, err := LoadConfig(configPath)
+			if err != nil {
+				t.Fatalf("LoadConfig failed: %v", err)
+			}
+			if cfg.APIs.Anthropic != tt.wantKey {
+				t.Errorf("Expected key %q, got %q", tt.wantKey, cfg.APIs.Anthropic)
+			}
+			source := cfg.APIs.Sources.Anthropic
+			if source.Origin != tt.wantOrigin {
+				t.Errorf("Expected origin %q, got %q", tt.wantOrigin, source.Origin)
+			}
+			if !strings.Contains(source.Problem, tt.wantProblem) || (tt.wantProblem == "" && source.Problem != "") {
+				t.Errorf("Expected problem containing %q, got %q", tt.wantProblem, source.Problem)
+			}
+
+			// Unresolved references are reported by validation with their source
+			var problems []string
+			for _, validationErr := range cfg.validateAPIKeys() {
+				problems = append(problems, validationErr.Field+": "+validationErr.Message)
+			}
+			if tt.wantProblem != "" {
+				if len(problems) != 1 || !strings.Contains(problems[0], tt.wantOrigin) {
+					t.Errorf("Expected one apis.anthropic error naming %q, got %v", tt.wantOrigin, problems)
+				}
+			} else if len(problems) != 0 {
+				t.Errorf("Expected no API key errors, got %v", problems)
+			}
+		})
+	}
+}
+
+// TestDescribeAPIKeys tests that key sources are reported without the keys
+func TestDescribeAPIKeys(t *testing.T) {
+	cfg := &Config{APIs: APIs{
+		OpenWeather: "owm-secret-value",
+		Anthropic:   "sk-ant-secret-value",
+		Sources: APIKeySources{
+			OpenWeather: KeySource{Origin: "config file"},
+			Anthropic:   KeySource{Origin: "environment variable " + EnvAnthropicKey},
+			ElevenLabs:  KeySource{Origin: "env:XI_KEY", Problem: "environment variable XI_KEY is not set"},
+		},
+	}}
+
+	lines := cfg.DescribeAPIKeys()
+	want := []string{
+		"apis.openweather: config file (set)",
+		"apis.anthropic: environment variable MYRCAST_ANTHROPIC_KEY (set)",
+		"apis.elevenlabs: env:XI_KEY (missing)",
+	}
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Expected %v, got %v", want, lines)
+	}
+	for _, line := range lines {
+		if strings.Contains(line, "secret-value") {
+			t.Errorf("Key value leaked into %q", line)
+		}
+	}
+}
+
+func TestSecrets(t *testing.T) {
+	cfg := &Config{
+		APIs: APIs{OpenWeather: "owm-secret-value", Anthropic: "sk-ant-secret-value", ElevenLabs: "xi-secret-value"},
+		Hooks: []Hook{
+			{
+				URL: "https://hooks.slack.com/services/T000/B000/slack-path-token",
+				Headers: map[string]string{
+					"Authorization": "Bearer hook-token",
+					"X-Api-Key":     "hook-api-key",
+					"Content-Type":  "application/json; charset=utf-8",
+					"X-Station":     "KXYZ FM",
+				},
+			},
+		},
+	}
+	secrets := cfg.Secrets()
+	for _, want := range []string{
+		"owm-secret-value", "sk-ant-secret-value", "xi-secret-value",
+		"https://hooks.slack.com/services/T000/B000/slack-path-token",
+		"Bearer hook-token", "hook-token", "hook-api-key",
+	} {
+		if !slices.Contains(secrets, want) {
+			t.Errorf("Expected %q among the secrets, got %q", want, secrets)
+		}
+	}
+	// Ordinary headers would otherwise mask harmless text everywhere
+	for _, harmless := range []string{"application/json; charset=utf-8", "charset=utf-8", "KXYZ FM", "FM"} {
+		if slices.Contains(secrets, harmless) {
+			t.Errorf("Harmless header value %q registered as a secret", harmless)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// AIDEV-NOTE: Unknown keys are decoded in strict mode and kept on the Config so
+// Validate can report them alongside every other problem (one run, one list of
+// fixes) rather than failing LoadConfig on the first typo. Known keys come from
+// the toml struct tags, so new settings need no extra registration here.
+
+// decodeStrict parses configuration TOML, returning the unknown keys as
+// validation errors with suggestions
+func decodeStrict(data []byte, config *Config) ([]ValidationError, error) {
+	decoder := toml.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	err := decoder.Decode(config)
+	var strictErr *toml.StrictMissingError
+	if !errors.As(err, &strictErr) {
+		return nil, err
+	}
+
+	known := knownConfigKeys()
+	var unknown []ValidationError
+	for _, decodeErr := range strictErr.Errors {
+		path := strings.Join(decodeErr.Key(), ".")
+		if isDeprecatedKey(path) {
+			continue // Reported as a deprecation warning instead
+		}
+		unknown = append(unknown, ValidationError{
+			Field:   path,
+			Message: unknownKeyMessage(path, known),
+		})
+	}
+	return unknown, nil
+}
+
+// isDeprecatedKey reports whether a key path is covered by a deprecation warning
+func isDeprecatedKey(path string) bool {
+	for _, deprecated := range deprecatedKeys {
+		prefix := deprecated.section
+		if deprecated.key != "" {
+			prefix += "." + deprecated.key
+		}
+		if path == prefix || strings.HasPrefix(path, prefix+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// unknownKeyMessage explains an unknown key, suggesting the closest known key
+// or section
+func unknownKeyMessage(path string, known []string) string {
+	section, _ := splitKeyPath(path)
+	if section == "" {
+		// A lone name is either a top-level key or a whole unknown [table]
+		if suggestion := suggestSection(path, known); suggestion != "" {
+			return fmt.Sprintf("unknown section; did you mean [%s]?", suggestion)
+		}
+	}
+	if suggestion := suggestKey(path, known); suggestion != "" {
+		return fmt.Sprintf("unknown key; did you mean %q?", suggestion)
+	}
+	if section != "" && !hasSectionPrefix(section, known) {
+		return fmt.Sprintf("unknown section [%s]", section)
+	}
+	return "unknown key"
+}
+
+// suggestSection returns the known section closest to name, or ""
+func suggestSection(name string, known []string) string {
+	best, bestDistance := "", -1
+	for _, key := range known {
+		section, _ := splitKeyPath(key)
+		if section == "" || strings.Contains(section, ".") {
+			continue
+		}
+		if distance := editDistance(name, section); bestDistance < 0 || distance < bestDistance {
+			best, bestDistance = section, distance
+		}
+	}
+	if bestDistance < 0 || bestDistance > maxTypoDistance(name) {
+		return ""
+	}
+	return best
+}
+
+// suggestKey returns the known key closest to an unknown one, or "". Typos of
+// keys in the same section win; otherwise a key with the same name in another
+// section (e.g. voice_id under [claude]) is suggested.
+func suggestKey(path string, known []string) string {
+	section, name := splitKeyPath(path)
+	limit := maxTypoDistance(path)
+
+	// Scores are doubled distances so a one-letter typo in the right section (2)
+	// beats the same name in the wrong section (3)
+	best, bestScore := "", -1
+	for _, candidate := range known {
+		candidateSection, candidateName := splitKeyPath(candidate)
+		score := -1
+		switch {
+		case candidateSection == section:
+			if distance := editDistance(name, candidateName); distance <= limit {
+				score = 2 * distance
+			}
+		case candidateName == name:
+			score = 3
+		}
+		if score >= 0 && (bestScore < 0 || score < bestScore) {
+			best, bestScore = candidate, score
+		}
+	}
+	return best
+}
+
+// maxTypoDistance scales the allowed edit distance with the key length
+func maxTypoDistance(path string) int {
+	_, name := splitKeyPath(path)
+	if limit := len(name) / 3; limit > 2 {
+		return limit
+	}
+	return 2
+}
+
+// splitKeyPath splits "section.key" into its section and key name
+func splitKeyPath(path string) (string, string) {
+	if i := strings.LastIndex(path, "."); i >= 0 {
+		return path[:i], path[i+1:]
+	}
+	return "", path
+}
+
+// hasSectionPrefix reports whether any known key is in the given section
+func hasSectionPrefix(section string, known []string) bool {
+	for _, key := range known {
+		if strings.HasPrefix(key, section+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// knownConfigKeys lists every key path Config accepts, from its toml tags
+func knownConfigKeys() []string {
+	var keys []string
+	collectKeys(reflect.TypeOf(Config{}), "", &keys)
+	sort.Strings(keys)
+	return keys
+}
+
+// collectKeys walks struct fields, descending into nested tables and arrays of
+// tables such as [[hooks]]
+func collectKeys(t reflect.Type, prefix string, keys *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("toml"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		path := prefix + name
+		if field.Type.Kind() == reflect.Struct {
+			collectKeys(field.Type, path+".", keys)
+			continue
+		}
+		if field.Type.Kind() == reflect.Slice && field.Type.Elem().Kind() == reflect.Struct {
+			collectKeys(field.Type.Elem(), path+".", keys)
+			continue
+		}
+		*keys = append(*keys, path)
+	}
+}
+
+// editDistance returns the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
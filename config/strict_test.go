@@ -0,0 +1,123 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestUnknownKeys tests that unknown keys become validation errors with suggestions
+func TestUnknownKeys(t *testing.T) {
+	tests := []struct {
+		name        string
+		extra       string
+		wantField   string
+		wantMessage string
+	}{
+		{
+			name:        "typo in section",
+			extra:       "[claude]\ntemprature = 0.5\n",
+			wantField:   "claude.temprature",
+			wantMessage: `did you mean "claude.temperature"?`,
+		},
+		{
+			name:        "key in the wrong section",
+			extra:       "[claude]\nvoice_id = \"abc\"\n",
+			wantField:   "claude.voice_id",
+			wantMessage: `did you mean "elevenlabs.voice_id"?`,
+		},
+		{
+			name:        "misspelled section",
+			extra:       "[elevenlab]\nvoice_id = \"abc\"\n",
+			wantField:   "elevenlab",
+			wantMessage: "did you mean [elevenlabs]?",
+		},
+		{
+			name:        "top-level typo",
+			extra:       "confg_version = 2\n",
+			wantField:   "confg_version",
+			wantMessage: `did you mean "config_version"?`,
+		},
+		{
+			name:        "typo in array of tables",
+			extra:       "[[hooks]]\nevents = [\"failure\"]\nurl = \"https://example.org/hook\"\ntimeout_second = 5\n",
+			wantField:   "hooks.timeout_second",
+			wantMessage: `did you mean "hooks.timeout_seconds"?`,
+		},
+		{
+			name:        "nothing close",
+			extra:       "[output]\nfrobnicate = true\n",
+			wantField:   "output.frobnicate",
+			wantMessage: "unknown key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config.toml")
+			content := tt.extra + "\n[apis]\nopenweather = \"owm\"\nanthropic = \"sk-ant\"\nelevenlabs = \"xi\"\n"
+			if strings.HasPrefix(tt.extra, "[") {
+				content = "[apis]\nopenweather = \"owm\"\nanthropic = \"sk-ant\"\nelevenlabs = \"xi\"\n\n" + tt.extra
+			}
+			if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+
+			cfg, err := LoadConfig(configPath)
+			if err != nil {
+				t.Fatalf("LoadConfig failed: %v", err)
+			}
+
+			var multiErr *MultiValidationError
+			if err := cfg.Validate(); !errors.As(err, &multiErr) {
+				t.Fatalf("Expected MultiValidationError, got %v", err)
+			}
+			first := multiErr.Errors[0]
+			if first.Field != tt.wantField {
+				t.Errorf("Expected field %q, got %q", tt.wantField, first.Field)
+			}
+			if !strings.Contains(first.Message, tt.wantMessage) {
+				t.Errorf("Expected message containing %q, got %q", tt.wantMessage, first.Message)
+			}
+		})
+	}
+}
+
+// TestShippedConfigsHaveNoUnknownKeys tests the sample and example configs against the schema
+func TestShippedConfigsHaveNoUnknownKeys(t *testing.T) {
+	samplePath := filepath.Join(t.TempDir(), "config.toml")
+	if err := GenerateSampleConfig(samplePath); err != nil {
+		t.Fatalf("GenerateSampleConfig failed: %v", err)
+	}
+
+	for _, path := range []string{samplePath, "../example-config.toml"} {
+		cfg, err := LoadConfig(path)
+		if err != nil {
+			t.Fatalf("LoadConfig(%s) failed: %v", path, err)
+		}
+		for _, unknown := range cfg.unknownKeys {
+			t.Errorf("%s: %s: %s", path, unknown.Field, unknown.Message)
+		}
+	}
+}
+
+// TestEditDistance tests the Levenshtein distance used for suggestions
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"temperature", "temperature", 0},
+		{"temprature", "temperature", 1},
+		{"voice", "voice_id", 3},
+		{"kitten", "sitting", 3},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+// Package calendar computes public holidays, observances and custom station events
+// for the broadcast notes engine. Dates are civil dates: only year, month and day
+// are compared, in whatever zone the caller's time is in.
+package calendar
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// AIDEV-NOTE: Holiday rules are data, not code. Country packs in packs.go and station
+// events files both produce []Rule; observed-day shifting happens per calendar so
+// substitute days can skip dates already taken by another holiday (UK Boxing Day).
+
+// Observed-day policies for holidays that fall on a weekend
+const (
+	ObservedNone       = ""           // No observed day
+	ObservedNearest    = "nearest"    // Saturday moves to Friday, Sunday to Monday (US federal)
+	ObservedSubstitute = "substitute" // Weekend moves to the next free weekday (UK, CA, AU)
+)
+
+// DefaultCountry is used when no country is configured
+const DefaultCountry = "US"
+
+// Holiday is a single dated occurrence of a rule
+type Holiday struct {
+	Name       string
+	Date       time.Time // Midnight UTC of the civil date
+	Observed   bool      // Day off in lieu of a weekend holiday
+	Observance bool      // Cultural observance rather than a public holiday
+}
+
+// Calendar holds the holiday rules for one country plus any station events
+type Calendar struct {
+	country string
+	rules   []Rule
+}
+
+// New creates a calendar for a country pack with optional extra events
+func New(country string, events ...Rule) (*Calendar, error) {
+	code := normalizeCountry(country)
+	pack, ok := countryPacks[code]
+	if !ok {
+		return nil, fmt.Errorf("unknown holiday calendar country %q (valid: %s)", country, strings.Join(Countries(), ", "))
+	}
+
+	for i, event := range events {
+		if err := event.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid event #%d: %w", i+1, err)
+		}
+	}
+
+	rules := make([]Rule, 0, len(pack)+len(events))
+	rules = append(rules, pack...)
+	rules = append(rules, events...)
+
+	return &Calendar{country: code, rules: rules}, nil
+}
+
+// Load creates a calendar for a country, adding events from an optional events file
+func Load(country, eventsFile string) (*Calendar, error) {
+	var events []Rule
+	if strings.TrimSpace(eventsFile) != "" {
+		loaded, err := LoadEvents(eventsFile)
+		if err != nil {
+			return nil, err
+		}
+		events = loaded
+	}
+	return New(country, events...)
+}
+
+// Default returns the calendar for DefaultCountry without custom events
+func Default() *Calendar {
+	cal, err := New(DefaultCountry)
+	if err != nil {
+		panic(err) // Built-in pack is always valid
+	}
+	return cal
+}
+
+// Countries returns the supported country codes
+func Countries() []string {
+	codes := make([]string, 0, len(countryPacks))
+	for code := range countryPacks {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// IsSupportedCountry reports whether a country code has a holiday pack
+func IsSupportedCountry(country string) bool {
+	_, ok := countryPacks[normalizeCountry(country)]
+	return ok
+}
+
+// Country returns the calendar's country code
+func (c *Calendar) Country() string {
+	return c.country
+}
+
+// Holidays returns every holiday, observed day and event in a year, sorted by date
+func (c *Calendar) Holidays(year int) []Holiday {
+	var result []Holiday
+	// Observed days can cross a year boundary (New Year's Day on a Saturday)
+	for y := year - 1; y <= year+1; y++ {
+		for _, holiday := range c.compute(y) {
+			if holiday.Date.Year() == year {
+				result = append(result, holiday)
+			}
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Date.Before(result[j].Date)
+	})
+	return result
+}
+
+// On returns the holidays falling on t's civil date, public holidays first
+func (c *Calendar) On(t time.Time) []Holiday {
+	day := civilDate(t.Year(), t.Month(), t.Day())
+
+	var result []Holiday
+	for _, holiday := range c.Holidays(t.Year()) {
+		if holiday.Date.Equal(day) {
+			result = append(result, holiday)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return !result[i].Observance && result[j].Observance
+	})
+	return result
+}
+
+// compute evaluates all rules for one year, including observed days
+func (c *Calendar) compute(year int) []Holiday {
+	var holidays []Holiday
+	taken := make(map[time.Time]bool)
+	var shifting []Holiday
+	var policies []string
+
+	for _, rule := range c.rules {
+		date, ok := rule.dateIn(year)
+		if !ok {
+			continue
+		}
+		holiday := Holiday{Name: rule.Name, Date: date, Observance: rule.Observance}
+		holidays = append(holidays, holiday)
+		if !rule.Observance {
+			taken[date] = true
+		}
+		if rule.Observed != ObservedNone && !rule.Observance && isWeekend(date) {
+			shifting = append(shifting, holiday)
+			policies = append(policies, rule.Observed)
+		}
+	}
+
+	// Observed days are assigned in date order so substitutes queue up correctly
+	order := make([]int, len(shifting))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return shifting[order[a]].Date.Before(shifting[order[b]].Date)
+	})
+
+	for _, i := range order {
+		observed := observedDate(shifting[i].Date, policies[i], taken)
+		taken[observed] = true
+		holidays = append(holidays, Holiday{
+			Name:     shifting[i].Name + " (observed)",
+			Date:     observed,
+			Observed: true,
+		})
+	}
+
+	return holidays
+}
+
+// observedDate picks the weekday a weekend holiday is observed on
+func observedDate(date time.Time, policy string, taken map[time.Time]bool) time.Time {
+	if policy == ObservedNearest {
+		if date.Weekday() == time.Saturday {
+			return date.AddDate(0, 0, -1)
+		}
+		return date.AddDate(0, 0, 1)
+	}
+
+	observed := date.AddDate(0, 0, 1)
+	for isWeekend(observed) || taken[observed] {
+		observed = observed.AddDate(0, 0, 1)
+	}
+	return observed
+}
+
+// Easter returns Easter Sunday for a year (Gregorian calendar)
+func Easter(year int) time.Time {
+	// Anonymous Gregorian algorithm (Meeus/Jones/Butcher)
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return civilDate(year, time.Month(month), day)
+}
+
+// civilDate returns midnight UTC for a calendar date
+func civilDate(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// isWeekend reports whether a date falls on Saturday or Sunday
+func isWeekend(date time.Time) bool {
+	return date.Weekday() == time.Saturday || date.Weekday() == time.Sunday
+}
+
+// normalizeCountry maps a country code or alias to its pack key
+func normalizeCountry(country string) string {
+	code := strings.ToUpper(strings.TrimSpace(country))
+	if code == "" {
+		return DefaultCountry
+	}
+	if alias, ok := countryAliases[code]; ok {
+		return alias
+	}
+	return code
+}
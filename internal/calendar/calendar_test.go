@@ -0,0 +1,249 @@
+package calendar
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// holidayNames returns the names of the holidays on a date
+func holidayNames(cal *Calendar, year int, month time.Month, day int) []string {
+	var names []string
+	for _, holiday := range cal.On(time.Date(year, month, day, 9, 0, 0, 0, time.Local)) {
+		names = append(names, holiday.Name)
+	}
+	return names
+}
+
+func TestEaster(t *testing.T) {
+	tests := []struct {
+		year  int
+		month time.Month
+		day   int
+	}{
+		{2019, time.April, 21},
+		{2024, time.March, 31},
+		{2025, time.April, 20},
+		{2026, time.April, 5},
+		{2038, time.April, 25},
+	}
+
+	for _, tt := range tests {
+		got := Easter(tt.year)
+		if got.Month() != tt.month || got.Day() != tt.day {
+			t.Errorf("Easter(%d) = %s, expected %s %d", tt.year, got.Format("2006-01-02"), tt.month, tt.day)
+		}
+	}
+}
+
+func TestCountryPacks(t *testing.T) {
+	tests := []struct {
+		country string
+		year    int
+		month   time.Month
+		day     int
+		name    string
+	}{
+		{"US", 2025, time.January, 20, "Martin Luther King Jr. Day"},
+		{"US", 2025, time.May, 26, "Memorial Day"},
+		{"US", 2025, time.November, 27, "Thanksgiving"},
+		{"US", 2025, time.April, 20, "Easter Sunday"},
+		{"US", 2025, time.May, 11, "Mother's Day"},
+		{"CA", 2025, time.May, 19, "Victoria Day"},
+		{"CA", 2024, time.May, 20, "Victoria Day"},
+		{"CA", 2025, time.October, 13, "Thanksgiving"},
+		{"UK", 2025, time.April, 18, "Good Friday"},
+		{"UK", 2025, time.April, 21, "Easter Monday"},
+		{"UK", 2025, time.August, 25, "Summer Bank Holiday"},
+		{"AU", 2025, time.April, 25, "Anzac Day"},
+		{"AU", 2025, time.June, 9, "King's Birthday"},
+		{"gb", 2025, time.May, 5, "Early May Bank Holiday"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.country+" "+tt.name, func(t *testing.T) {
+			cal, err := New(tt.country)
+			if err != nil {
+				t.Fatalf("Failed to create calendar: %v", err)
+			}
+			names := holidayNames(cal, tt.year, tt.month, tt.day)
+			if len(names) == 0 || names[0] != tt.name {
+				t.Errorf("Expected %s on %d-%02d-%02d, got %v", tt.name, tt.year, tt.month, tt.day, names)
+			}
+		})
+	}
+}
+
+func TestObservedDays(t *testing.T) {
+	tests := []struct {
+		name     string
+		country  string
+		year     int
+		month    time.Month
+		day      int
+		expected string
+	}{
+		// US: Saturday holidays move to Friday, Sunday holidays to Monday
+		{"US July 4 on Saturday", "US", 2026, time.July, 3, "Independence Day (observed)"},
+		{"US Christmas on Sunday", "US", 2022, time.December, 26, "Christmas Day (observed)"},
+		{"US New Year on Saturday crosses year", "US", 2021, time.December, 31, "New Year's Day (observed)"},
+		// UK: Christmas Saturday and Boxing Day Sunday take Monday and Tuesday
+		{"UK Christmas substitute", "UK", 2021, time.December, 27, "Christmas Day (observed)"},
+		{"UK Boxing Day substitute skips taken day", "UK", 2021, time.December, 28, "Boxing Day (observed)"},
+		// UK: Christmas Sunday substitutes after Boxing Day Monday
+		{"UK Christmas after Boxing Day", "UK", 2022, time.December, 27, "Christmas Day (observed)"},
+		{"AU Australia Day on Sunday", "AU", 2025, time.January, 27, "Australia Day (observed)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cal, err := New(tt.country)
+			if err != nil {
+				t.Fatalf("Failed to create calendar: %v", err)
+			}
+			names := holidayNames(cal, tt.year, tt.month, tt.day)
+			if len(names) == 0 || names[0] != tt.expected {
+				t.Errorf("Expected %s, got %v", tt.expected, names)
+			}
+		})
+	}
+
+	// Observances are never shifted
+	cal := Default()
+	if names := holidayNames(cal, 2026, time.February, 16); len(names) != 1 || names[0] != "Presidents' Day" {
+		t.Errorf("Expected only Presidents' Day on 2026-02-16, got %v", names)
+	}
+}
+
+func TestOnOrdersPublicHolidaysFirst(t *testing.T) {
+	cal, err := New("US", Rule{Name: "Station Anniversary", Month: 12, Day: 31})
+	if err != nil {
+		t.Fatalf("Failed to create calendar: %v", err)
+	}
+
+	holidays := cal.On(time.Date(2025, time.December, 31, 18, 0, 0, 0, time.UTC))
+	if len(holidays) != 2 {
+		t.Fatalf("Expected 2 holidays, got %v", holidays)
+	}
+	if holidays[0].Name != "Station Anniversary" || holidays[0].Observance {
+		t.Errorf("Expected station event first, got %+v", holidays[0])
+	}
+	if holidays[1].Name != "New Year's Eve" || !holidays[1].Observance {
+		t.Errorf("Expected New Year's Eve observance second, got %+v", holidays[1])
+	}
+}
+
+func TestNewUnknownCountry(t *testing.T) {
+	_, err := New("ZZ")
+	if err == nil {
+		t.Fatal("Expected error for unknown country")
+	}
+	if !strings.Contains(err.Error(), "valid: AU, CA, UK, US") {
+		t.Errorf("Expected valid countries in error, got %v", err)
+	}
+	if !IsSupportedCountry("usa") || IsSupportedCountry("ZZ") {
+		t.Error("IsSupportedCountry returned unexpected result")
+	}
+}
+
+func TestRuleValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		rule        Rule
+		expectError string
+	}{
+		{"fixed", Rule{Name: "Fair", Month: 8, Day: 14}, ""},
+		{"one-off", Rule{Name: "Remote", Date: "2025-08-14"}, ""},
+		{"easter", Rule{Name: "Ash Wednesday", EasterOffset: intPtr(-46)}, ""},
+		{"nth weekday", Rule{Name: "Fair", Month: 8, Weekday: "sat", Week: 2}, ""},
+		{"missing name", Rule{Month: 1, Day: 1}, "name is required"},
+		{"bad month", Rule{Name: "X", Month: 13, Day: 1}, "month must be between 1 and 12"},
+		{"bad day", Rule{Name: "X", Month: 1}, "day must be between 1 and 31"},
+		{"bad weekday", Rule{Name: "X", Month: 1, Weekday: "funday", Week: 1}, "unknown weekday"},
+		{"bad week", Rule{Name: "X", Month: 1, Weekday: "monday", Week: 6}, "week must be 1-5"},
+		{"bad date", Rule{Name: "X", Date: "08/14/2025"}, "date must be YYYY-MM-DD"},
+		{"bad observed", Rule{Name: "X", Month: 1, Day: 1, Observed: "monday"}, "observed must be"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate()
+			if tt.expectError == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+				t.Errorf("Expected error containing %q, got %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestRuleDateIn(t *testing.T) {
+	// Fifth Friday does not exist in every month
+	rule := Rule{Name: "Fifth Friday", Month: 2, Weekday: "friday", Week: 5}
+	if _, ok := rule.dateIn(2025); ok {
+		t.Error("Expected no fifth Friday in February 2025")
+	}
+
+	// February 29 only occurs in leap years
+	leap := Rule{Name: "Leap Day", Month: 2, Day: 29}
+	if _, ok := leap.dateIn(2025); ok {
+		t.Error("Expected no February 29 in 2025")
+	}
+	if date, ok := leap.dateIn(2028); !ok || date.Day() != 29 {
+		t.Errorf("Expected February 29 2028, got %v", date)
+	}
+
+	// One-off dates only match their own year
+	once := Rule{Name: "Remote", Date: "2025-08-14"}
+	if _, ok := once.dateIn(2026); ok {
+		t.Error("Expected one-off event to be absent in other years")
+	}
+}
+
+func TestLoadEvents(t *testing.T) {
+	dir := t.TempDir()
+
+	validPath := filepath.Join(dir, "events.toml")
+	valid := `
+[[event]]
+name = "Station Anniversary"
+month = 6
+day = 12
+
+[[event]]
+name = "County Fair Remote"
+date = "2025-08-14"
+`
+	if err := os.WriteFile(validPath, []byte(valid), 0644); err != nil {
+		t.Fatalf("Failed to write events file: %v", err)
+	}
+
+	cal, err := Load("US", validPath)
+	if err != nil {
+		t.Fatalf("Failed to load calendar: %v", err)
+	}
+	if names := holidayNames(cal, 2025, time.June, 12); len(names) != 1 || names[0] != "Station Anniversary" {
+		t.Errorf("Expected station anniversary, got %v", names)
+	}
+	if names := holidayNames(cal, 2025, time.August, 14); len(names) != 1 || names[0] != "County Fair Remote" {
+		t.Errorf("Expected county fair, got %v", names)
+	}
+
+	typoPath := filepath.Join(dir, "typo.toml")
+	if err := os.WriteFile(typoPath, []byte("[[event]]\nname = \"X\"\nmonht = 6\nday = 1\n"), 0644); err != nil {
+		t.Fatalf("Failed to write events file: %v", err)
+	}
+	if _, err := LoadEvents(typoPath); err == nil || !strings.Contains(err.Error(), "monht") {
+		t.Errorf("Expected unknown key error, got %v", err)
+	}
+
+	if _, err := Load("US", filepath.Join(dir, "missing.toml")); err == nil {
+		t.Error("Expected error for missing events file")
+	}
+}
@@ -0,0 +1,53 @@
+package calendar
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// eventsFile is the on-disk format for custom station events:
+//
+//	[[event]]
+//	name = "Station Anniversary"
+//	month = 6
+//	day = 12
+type eventsFile struct {
+	Events []Rule `toml:"event"`
+}
+
+// LoadEvents reads custom station events from a TOML file
+func LoadEvents(path string) ([]Rule, error) {
+	cleanPath := filepath.Clean(path)
+	data, err := os.ReadFile(cleanPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read calendar events file: %w", err)
+	}
+	return ParseEvents(data, cleanPath)
+}
+
+// ParseEvents decodes and validates custom events, rejecting unknown keys
+func ParseEvents(data []byte, source string) ([]Rule, error) {
+	var file eventsFile
+	decoder := toml.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&file); err != nil {
+		var strictErr *toml.StrictMissingError
+		if errors.As(err, &strictErr) {
+			return nil, fmt.Errorf("invalid calendar events in %s: %s", source, strictErr.String())
+		}
+		return nil, fmt.Errorf("failed to parse calendar events in %s: %w", source, err)
+	}
+
+	for i, event := range file.Events {
+		if err := event.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid calendar event #%d in %s: %w", i+1, source, err)
+		}
+	}
+
+	return file.Events, nil
+}
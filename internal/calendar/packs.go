@@ -0,0 +1,103 @@
+package calendar
+
+// intPtr returns a pointer to an int literal
+func intPtr(v int) *int {
+	return &v
+}
+
+// countryAliases maps alternate country codes to pack keys
+var countryAliases = map[string]string{
+	"USA": "US",
+	"GB":  "UK",
+	"CAN": "CA",
+	"AUS": "AU",
+}
+
+// commonObservances are observed in every supported country
+var commonObservances = []Rule{
+	{Name: "Valentine's Day", Month: 2, Day: 14, Observance: true},
+	{Name: "International Women's Day", Month: 3, Day: 8, Observance: true},
+	{Name: "Earth Day", Month: 4, Day: 22, Observance: true},
+	{Name: "New Year's Eve", Month: 12, Day: 31, Observance: true},
+}
+
+// withObservances appends the common observances to a country's rules
+func withObservances(rules ...Rule) []Rule {
+	return append(rules, commonObservances...)
+}
+
+// countryPacks holds the built-in national holiday rules, public holidays first
+var countryPacks = map[string][]Rule{
+	"US": withObservances(
+		Rule{Name: "New Year's Day", Month: 1, Day: 1, Observed: ObservedNearest},
+		Rule{Name: "Martin Luther King Jr. Day", Month: 1, Weekday: "monday", Week: 3},
+		Rule{Name: "Presidents' Day", Month: 2, Weekday: "monday", Week: 3},
+		Rule{Name: "Memorial Day", Month: 5, Weekday: "monday", Week: -1},
+		Rule{Name: "Juneteenth", Month: 6, Day: 19, Observed: ObservedNearest},
+		Rule{Name: "Independence Day", Month: 7, Day: 4, Observed: ObservedNearest},
+		Rule{Name: "Labor Day", Month: 9, Weekday: "monday", Week: 1},
+		Rule{Name: "Columbus Day", Month: 10, Weekday: "monday", Week: 2},
+		Rule{Name: "Veterans Day", Month: 11, Day: 11, Observed: ObservedNearest},
+		Rule{Name: "Thanksgiving", Month: 11, Weekday: "thursday", Week: 4},
+		Rule{Name: "Christmas Day", Month: 12, Day: 25, Observed: ObservedNearest},
+		Rule{Name: "St. Patrick's Day", Month: 3, Day: 17, Observance: true},
+		Rule{Name: "Easter Sunday", EasterOffset: intPtr(0), Observance: true},
+		Rule{Name: "International Workers' Day", Month: 5, Day: 1, Observance: true},
+		Rule{Name: "Mother's Day", Month: 5, Weekday: "sunday", Week: 2, Observance: true},
+		Rule{Name: "Father's Day", Month: 6, Weekday: "sunday", Week: 3, Observance: true},
+		Rule{Name: "Halloween", Month: 10, Day: 31, Observance: true},
+		Rule{Name: "Christmas Eve", Month: 12, Day: 24, Observance: true},
+	),
+	"CA": withObservances(
+		Rule{Name: "New Year's Day", Month: 1, Day: 1, Observed: ObservedSubstitute},
+		Rule{Name: "Good Friday", EasterOffset: intPtr(-2)},
+		Rule{Name: "Victoria Day", Month: 5, Weekday: "monday", OnOrBefore: 24},
+		Rule{Name: "Canada Day", Month: 7, Day: 1, Observed: ObservedSubstitute},
+		Rule{Name: "Civic Holiday", Month: 8, Weekday: "monday", Week: 1},
+		Rule{Name: "Labour Day", Month: 9, Weekday: "monday", Week: 1},
+		Rule{Name: "National Day for Truth and Reconciliation", Month: 9, Day: 30, Observed: ObservedSubstitute},
+		Rule{Name: "Thanksgiving", Month: 10, Weekday: "monday", Week: 2},
+		Rule{Name: "Remembrance Day", Month: 11, Day: 11, Observed: ObservedSubstitute},
+		Rule{Name: "Christmas Day", Month: 12, Day: 25, Observed: ObservedSubstitute},
+		Rule{Name: "Boxing Day", Month: 12, Day: 26, Observed: ObservedSubstitute},
+		Rule{Name: "St. Patrick's Day", Month: 3, Day: 17, Observance: true},
+		Rule{Name: "Easter Sunday", EasterOffset: intPtr(0), Observance: true},
+		Rule{Name: "Mother's Day", Month: 5, Weekday: "sunday", Week: 2, Observance: true},
+		Rule{Name: "Father's Day", Month: 6, Weekday: "sunday", Week: 3, Observance: true},
+		Rule{Name: "Halloween", Month: 10, Day: 31, Observance: true},
+		Rule{Name: "Christmas Eve", Month: 12, Day: 24, Observance: true},
+	),
+	"UK": withObservances(
+		Rule{Name: "New Year's Day", Month: 1, Day: 1, Observed: ObservedSubstitute},
+		Rule{Name: "Good Friday", EasterOffset: intPtr(-2)},
+		Rule{Name: "Easter Monday", EasterOffset: intPtr(1)},
+		Rule{Name: "Early May Bank Holiday", Month: 5, Weekday: "monday", Week: 1},
+		Rule{Name: "Spring Bank Holiday", Month: 5, Weekday: "monday", Week: -1},
+		Rule{Name: "Summer Bank Holiday", Month: 8, Weekday: "monday", Week: -1},
+		Rule{Name: "Christmas Day", Month: 12, Day: 25, Observed: ObservedSubstitute},
+		Rule{Name: "Boxing Day", Month: 12, Day: 26, Observed: ObservedSubstitute},
+		Rule{Name: "St. Patrick's Day", Month: 3, Day: 17, Observance: true},
+		Rule{Name: "Mothering Sunday", EasterOffset: intPtr(-21), Observance: true},
+		Rule{Name: "Easter Sunday", EasterOffset: intPtr(0), Observance: true},
+		Rule{Name: "Father's Day", Month: 6, Weekday: "sunday", Week: 3, Observance: true},
+		Rule{Name: "Halloween", Month: 10, Day: 31, Observance: true},
+		Rule{Name: "Bonfire Night", Month: 11, Day: 5, Observance: true},
+		Rule{Name: "Remembrance Sunday", Month: 11, Weekday: "sunday", Week: 2, Observance: true},
+		Rule{Name: "Christmas Eve", Month: 12, Day: 24, Observance: true},
+	),
+	"AU": withObservances(
+		Rule{Name: "New Year's Day", Month: 1, Day: 1, Observed: ObservedSubstitute},
+		Rule{Name: "Australia Day", Month: 1, Day: 26, Observed: ObservedSubstitute},
+		Rule{Name: "Good Friday", EasterOffset: intPtr(-2)},
+		Rule{Name: "Easter Saturday", EasterOffset: intPtr(-1)},
+		Rule{Name: "Easter Monday", EasterOffset: intPtr(1)},
+		Rule{Name: "Anzac Day", Month: 4, Day: 25},
+		Rule{Name: "King's Birthday", Month: 6, Weekday: "monday", Week: 2},
+		Rule{Name: "Christmas Day", Month: 12, Day: 25, Observed: ObservedSubstitute},
+		Rule{Name: "Boxing Day", Month: 12, Day: 26, Observed: ObservedSubstitute},
+		Rule{Name: "Easter Sunday", EasterOffset: intPtr(0), Observance: true},
+		Rule{Name: "Mother's Day", Month: 5, Weekday: "sunday", Week: 2, Observance: true},
+		Rule{Name: "Father's Day", Month: 9, Weekday: "sunday", Week: 1, Observance: true},
+		Rule{Name: "Christmas Eve", Month: 12, Day: 24, Observance: true},
+	),
+}
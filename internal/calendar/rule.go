@@ -0,0 +1,136 @@
+package calendar
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Rule describes how to find a holiday's date in a given year.
+// Exactly one form is used, chosen by which fields are set:
+//   - date = "2025-08-14"                        one-off event
+//   - easter_offset = -2                          days relative to Easter Sunday
+//   - month + weekday + week (1-5, -1 for last)   nth or last weekday of the month
+//   - month + weekday + on_or_before = 24         last weekday on or before a day
+//   - month + day                                 fixed date every year
+type Rule struct {
+	Name         string `toml:"name"`
+	Month        int    `toml:"month"`
+	Day          int    `toml:"day"`
+	Weekday      string `toml:"weekday"`
+	Week         int    `toml:"week"`
+	OnOrBefore   int    `toml:"on_or_before"`
+	EasterOffset *int   `toml:"easter_offset"`
+	Date         string `toml:"date"`
+	Observed     string `toml:"observed"`   // "", "nearest" or "substitute"
+	Observance   bool   `toml:"observance"` // Cultural observance, never shifted
+}
+
+// Validate checks that a rule has a name and exactly one usable date form
+func (r Rule) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	switch r.Observed {
+	case ObservedNone, ObservedNearest, ObservedSubstitute:
+	default:
+		return fmt.Errorf("%s: observed must be %q or %q, got %q", r.Name, ObservedNearest, ObservedSubstitute, r.Observed)
+	}
+
+	if r.Date != "" {
+		if _, err := time.Parse("2006-01-02", r.Date); err != nil {
+			return fmt.Errorf("%s: date must be YYYY-MM-DD, got %q", r.Name, r.Date)
+		}
+		return nil
+	}
+
+	if r.EasterOffset != nil {
+		if *r.EasterOffset < -100 || *r.EasterOffset > 100 {
+			return fmt.Errorf("%s: easter_offset must be between -100 and 100, got %d", r.Name, *r.EasterOffset)
+		}
+		return nil
+	}
+
+	if r.Month < 1 || r.Month > 12 {
+		return fmt.Errorf("%s: month must be between 1 and 12, got %d", r.Name, r.Month)
+	}
+
+	if r.Weekday != "" {
+		if _, ok := parseWeekday(r.Weekday); !ok {
+			return fmt.Errorf("%s: unknown weekday %q", r.Name, r.Weekday)
+		}
+		if r.OnOrBefore != 0 {
+			if r.OnOrBefore < 1 || r.OnOrBefore > 31 {
+				return fmt.Errorf("%s: on_or_before must be between 1 and 31, got %d", r.Name, r.OnOrBefore)
+			}
+			return nil
+		}
+		if r.Week != -1 && (r.Week < 1 || r.Week > 5) {
+			return fmt.Errorf("%s: week must be 1-5 or -1 for last, got %d", r.Name, r.Week)
+		}
+		return nil
+	}
+
+	if r.Day < 1 || r.Day > 31 {
+		return fmt.Errorf("%s: day must be between 1 and 31, got %d", r.Name, r.Day)
+	}
+	return nil
+}
+
+// dateIn returns the rule's date in a year, false if it does not occur
+func (r Rule) dateIn(year int) (time.Time, bool) {
+	if r.Date != "" {
+		date, err := time.Parse("2006-01-02", r.Date)
+		if err != nil || date.Year() != year {
+			return time.Time{}, false
+		}
+		return date, true
+	}
+
+	if r.EasterOffset != nil {
+		return Easter(year).AddDate(0, 0, *r.EasterOffset), true
+	}
+
+	month := time.Month(r.Month)
+	if r.Weekday == "" {
+		date := civilDate(year, month, r.Day)
+		// Skip dates that do not exist this year, such as February 29
+		return date, date.Month() == month
+	}
+
+	weekday, _ := parseWeekday(r.Weekday)
+	switch {
+	case r.OnOrBefore != 0:
+		date := civilDate(year, month, r.OnOrBefore)
+		for date.Weekday() != weekday {
+			date = date.AddDate(0, 0, -1)
+		}
+		return date, true
+	case r.Week == -1:
+		date := civilDate(year, month+1, 1).AddDate(0, 0, -1)
+		for date.Weekday() != weekday {
+			date = date.AddDate(0, 0, -1)
+		}
+		return date, true
+	default:
+		date := civilDate(year, month, 1)
+		for date.Weekday() != weekday {
+			date = date.AddDate(0, 0, 1)
+		}
+		date = date.AddDate(0, 0, (r.Week-1)*7)
+		return date, date.Month() == month
+	}
+}
+
+// parseWeekday accepts full or three-letter day names
+func parseWeekday(name string) (time.Weekday, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		full := strings.ToLower(d.String())
+		if name == full || name == full[:3] {
+			return d, true
+		}
+	}
+	return time.Sunday, false
+}
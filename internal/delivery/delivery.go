@@ -0,0 +1,110 @@
+// Package delivery hands finished weather reports to the station's automation system.
+package delivery
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// AIDEV-NOTE: The speech stage always writes the audio into [output] import_path
+// first. An adapter then makes it available to the automation system: Myriad and
+// other drop-folder systems import it from there as-is, Rivendell needs rdimport
+// to load it into a cart, and RadioDJ gets a metadata sidecar next to it.
+
+// Adapter names for [delivery] adapter
+const (
+	AdapterMyriad    = "myriad"
+	AdapterRivendell = "rivendell"
+	AdapterRadioDJ   = "radiodj"
+)
+
+// Adapters lists the supported adapter names, default first
+var Adapters = []string{AdapterMyriad, AdapterRivendell, AdapterRadioDJ}
+
+// Report describes a finished report ready for delivery
+type Report struct {
+	AudioFile   string        // Generated audio file in the import folder
+	Title       string        // Title for the automation library
+	Artist      string        // Artist/source for the automation library
+	Album       string        // Album/grouping, usually the weather location
+	Script      string        // Script that was spoken
+	Location    string        // Weather location name
+	Duration    time.Duration // Estimated playing time
+	GeneratedAt time.Time     // When the audio was generated
+	ValidUntil  time.Time     // When the report stops being accurate enough to air
+	RunID       string        // Run that generated the report, as in the log records
+
+	Voice       string           // ElevenLabs voice ID
+	TTSModel    string           // ElevenLabs model
+	ScriptModel string           // Claude model that wrote the script
+	Weather     *WeatherSnapshot // Key weather values (nil when resuming from a script without current saved weather)
+	Alerts      []string         // Active weather alerts
+}
+
+// Adapter delivers a report to an automation system
+type Adapter interface {
+	// Name identifies the adapter in logs and summaries
+	Name() string
+	// Deliver makes the report available to the automation system and
+	// describes where it went
+	Deliver(ctx context.Context, report Report) (string, error)
+}
+
+// Config selects and configures the delivery adapter
+type Config struct {
+	Adapter       string          // One of Adapters (default: myriad)
+	SidecarFormat string          // One of SidecarFormats (default: none, json for RadioDJ)
+	Rivendell     RivendellConfig // Settings for the Rivendell adapter
+}
+
+// New creates the adapter selected by config. Adapters other than RadioDJ, which
+// always writes one, are wrapped to write a sidecar first when a format is set.
+func New(config Config) (Adapter, error) {
+	var adapter Adapter
+	switch config.Adapter {
+	case "", AdapterMyriad:
+		adapter = &MyriadAdapter{}
+	case AdapterRivendell:
+		rivendell, err := NewRivendellAdapter(config.Rivendell)
+		if err != nil {
+			return nil, err
+		}
+		adapter = rivendell
+	case AdapterRadioDJ:
+		return &RadioDJAdapter{SidecarFormat: config.SidecarFormat}, nil
+	default:
+		return nil, fmt.Errorf("unknown delivery adapter %q", config.Adapter)
+	}
+
+	switch config.SidecarFormat {
+	case "", SidecarNone:
+		return adapter, nil
+	case SidecarJSON, SidecarXML:
+		return &sidecarAdapter{Adapter: adapter, format: config.SidecarFormat}, nil
+	default:
+		return nil, fmt.Errorf("unknown sidecar format %q", config.SidecarFormat)
+	}
+}
+
+// ConsumesSource reports whether the adapter removes the report's audio file once
+// it is delivered (Rivendell with delete_source). Nothing else should point at
+// the file after such a delivery.
+func ConsumesSource(adapter Adapter) bool {
+	consumer, ok := adapter.(interface{ ConsumesSource() bool })
+	return ok && consumer.ConsumesSource()
+}
+
+// MyriadAdapter leaves the report in the drop folder for the automation system's
+// import watcher. It works with any system that imports from a watched folder.
+type MyriadAdapter struct{}
+
+// Name identifies the adapter
+func (a *MyriadAdapter) Name() string {
+	return AdapterMyriad
+}
+
+// Deliver does nothing more: the report is already in the import folder
+func (a *MyriadAdapter) Deliver(ctx context.Context, report Report) (string, error) {
+	return report.AudioFile, nil
+}
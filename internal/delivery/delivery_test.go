@@ -0,0 +1,270 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestRDImportStub stands in for rdimport when run as a subprocess by the
+// Rivendell tests. It records its arguments and fails when asked to.
+func TestRDImportStub(t *testing.T) {
+	if os.Getenv("MYRCAST_RDIMPORT_STUB") != "1" {
+		return
+	}
+	args := os.Args
+	for i, arg := range args {
+		if arg == "--" {
+			args = args[i+1:]
+			break
+		}
+	}
+	if err := os.WriteFile(os.Getenv("MYRCAST_RDIMPORT_LOG"), []byte(strings.Join(args, "\n")), 0644); err != nil {
+		os.Exit(3)
+	}
+	if os.Getenv("MYRCAST_RDIMPORT_FAIL") == "1" {
+		fmt.Fprintln(os.Stderr, "rdimport: no such group")
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+// stubCommand runs TestRDImportStub in place of rdimport and returns its argument log
+func stubCommand(t *testing.T, fail bool) ([]string, string) {
+	logPath := filepath.Join(t.TempDir(), "rdimport.args")
+	t.Setenv("MYRCAST_RDIMPORT_STUB", "1")
+	t.Setenv("MYRCAST_RDIMPORT_LOG", logPath)
+	if fail {
+		t.Setenv("MYRCAST_RDIMPORT_FAIL", "1")
+	} else {
+		t.Setenv("MYRCAST_RDIMPORT_FAIL", "")
+	}
+	return []string{os.Args[0], "-test.run=^TestRDImportStub$", "--"}, logPath
+}
+
+// testReport returns a report whose audio file exists in a temp directory
+func testReport(t *testing.T) Report {
+	audioFile := filepath.Join(t.TempDir(), "weather_report.mp3")
+	if err := os.WriteFile(audioFile, []byte("audio"), 0644); err != nil {
+		t.Fatalf("Failed to write audio file: %v", err)
+	}
+	return Report{
+		AudioFile:   audioFile,
+		Title:       "Weather Report",
+		Artist:      "Myrcast",
+		Album:       "Honolulu",
+		Duration:    21500 * time.Millisecond,
+		GeneratedAt: time.Date(2026, 3, 14, 5, 55, 0, 0, time.UTC),
+	}
+}
+
+// TestRivendellAdapter tests the rdimport command line and the source cleanup
+func TestRivendellAdapter(t *testing.T) {
+	command, logPath := stubCommand(t, false)
+	adapter, err := NewRivendellAdapter(RivendellConfig{
+		Command:      command,
+		Group:        "WEATHER",
+		Cart:         10001,
+		Args:         []string{"--normalization-level=-13"},
+		DeleteSource: true,
+	})
+	if err != nil {
+		t.Fatalf("NewRivendellAdapter failed: %v", err)
+	}
+
+	report := testReport(t)
+	destination, err := adapter.Deliver(context.Background(), report)
+	if err != nil {
+		t.Fatalf("Deliver failed: %v", err)
+	}
+	if destination != "Rivendell cart 010001 (group WEATHER)" {
+		t.Errorf("Unexpected destination %q", destination)
+	}
+
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("Stub did not record its arguments: %v", err)
+	}
+	want := []string{
+		"--to-cart=10001",
+		"--delete-cuts",
+		"--set-string-title=Weather Report",
+		"--set-string-artist=Myrcast",
+		"--set-string-album=Honolulu",
+		"--normalization-level=-13",
+		"WEATHER",
+		report.AudioFile,
+	}
+	if got := strings.Split(string(data), "\n"); strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("Expected rdimport args %v, got %v", want, got)
+	}
+
+	if _, err := os.Stat(report.AudioFile); !os.IsNotExist(err) {
+		t.Errorf("Expected source file to be deleted after import, stat error %v", err)
+	}
+}
+
+// TestRivendellAdapterFailure tests that rdimport's output is reported and the source kept
+func TestRivendellAdapterFailure(t *testing.T) {
+	command, _ := stubCommand(t, true)
+	adapter, err := NewRivendellAdapter(RivendellConfig{Command: command, Group: "NOPE", Cart: 42, DeleteSource: true})
+	if err != nil {
+		t.Fatalf("NewRivendellAdapter failed: %v", err)
+	}
+
+	report := testReport(t)
+	_, err = adapter.Deliver(context.Background(), report)
+	if err == nil {
+		t.Fatal("Expected rdimport failure")
+	}
+	for _, want := range []string{"cart 000042", "no such group"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error containing %q, got %v", want, err)
+		}
+	}
+	if _, err := os.Stat(report.AudioFile); err != nil {
+		t.Errorf("Expected source file to be kept after a failed import: %v", err)
+	}
+}
+
+// TestNewRivendellAdapterValidation tests the required Rivendell settings
+func TestNewRivendellAdapterValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  RivendellConfig
+		wantErr string
+	}{
+		{"missing group", RivendellConfig{Cart: 1}, "group is required"},
+		{"missing cart", RivendellConfig{Group: "WEATHER"}, "cart must be between"},
+		{"cart too large", RivendellConfig{Group: "WEATHER", Cart: 1000000}, "cart must be between"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewRivendellAdapter(tt.config)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	adapter, err := NewRivendellAdapter(RivendellConfig{Group: "WEATHER", Cart: 1})
+	if err != nil {
+		t.Fatalf("NewRivendellAdapter failed: %v", err)
+	}
+	if adapter.config.Command[0] != "rdimport" || adapter.config.Timeout != defaultRDImportTimeout {
+		t.Errorf("Expected rdimport defaults, got %+v", adapter.config)
+	}
+}
+
+// TestRadioDJAdapter tests the metadata sidecar written next to the audio
+func TestRadioDJAdapter(t *testing.T) {
+	report := testReport(t)
+	if _, err := (&RadioDJAdapter{}).Deliver(context.Background(), report); err != nil {
+		t.Fatalf("Deliver failed: %v", err)
+	}
+
+	data, err := os.ReadFile(strings.TrimSuffix(report.AudioFile, ".mp3") + ".json")
+	if err != nil {
+		t.Fatalf("Sidecar not written: %v", err)
+	}
+	var sidecar Sidecar
+	if err := json.Unmarshal(data, &sidecar); err != nil {
+		t.Fatalf("Sidecar is not valid JSON: %v", err)
+	}
+	if sidecar.File != "weather_report.mp3" || sidecar.Title != "Weather Report" || sidecar.DurationMs != 21500 {
+		t.Errorf("Unexpected sidecar %+v", sidecar)
+	}
+}
+
+// TestNew tests adapter selection
+func TestNew(t *testing.T) {
+	for _, name := range []string{"", AdapterMyriad, AdapterRadioDJ} {
+		adapter, err := New(Config{Adapter: name})
+		if err != nil {
+			t.Fatalf("New(%q) failed: %v", name, err)
+		}
+		if name != "" && adapter.Name() != name {
+			t.Errorf("New(%q) returned %s adapter", name, adapter.Name())
+		}
+	}
+	if _, err := New(Config{Adapter: "zetta"}); err == nil {
+		t.Error("Expected error for unknown adapter")
+	}
+
+	// A sidecar format wraps the adapter so the sidecar is written before delivery
+	adapter, err := New(Config{Adapter: AdapterMyriad, SidecarFormat: SidecarXML})
+	if err != nil {
+		t.Fatalf("New with sidecar failed: %v", err)
+	}
+	report := testReport(t)
+	if _, err := adapter.Deliver(context.Background(), report); err != nil {
+		t.Fatalf("Deliver failed: %v", err)
+	}
+	if adapter.Name() != AdapterMyriad {
+		t.Errorf("Expected wrapped adapter to keep its name, got %s", adapter.Name())
+	}
+	if _, err := os.Stat(SidecarPath(report.AudioFile, SidecarXML)); err != nil {
+		t.Errorf("Expected XML sidecar: %v", err)
+	}
+	if _, err := New(Config{SidecarFormat: "yaml"}); err == nil {
+		t.Error("Expected error for unknown sidecar format")
+	}
+}
+
+// TestWriteSidecar tests the JSON and XML sidecar contents
+func TestWriteSidecar(t *testing.T) {
+	report := testReport(t)
+	report.Script = "Sunny & 72 <today>."
+	report.ValidUntil = report.GeneratedAt.Add(6 * time.Hour)
+	report.RunID = "20260314T055500-3f9a1c2e"
+	report.Voice = "voice123"
+	report.TTSModel = "eleven_multilingual_v2"
+	report.ScriptModel = "claude-3-5-sonnet-20241022"
+	report.Weather = &WeatherSnapshot{Units: "imperial", Conditions: "clear sky", CurrentTemp: 68, High: 72, Low: 61}
+	report.Alerts = []string{"High Surf Advisory"}
+
+	jsonPath, err := WriteSidecar(report, SidecarJSON)
+	if err != nil {
+		t.Fatalf("WriteSidecar(json) failed: %v", err)
+	}
+	data, err := os.ReadFile(jsonPath)
+	if err != nil {
+		t.Fatalf("Failed to read JSON sidecar: %v", err)
+	}
+	var sidecar Sidecar
+	if err := json.Unmarshal(data, &sidecar); err != nil {
+		t.Fatalf("JSON sidecar does not parse: %v", err)
+	}
+	if sidecar.Script != report.Script || !sidecar.ValidUntil.Equal(report.ValidUntil) || sidecar.RunID != report.RunID ||
+		sidecar.Weather == nil || sidecar.Weather.High != 72 || len(sidecar.Alerts) != 1 {
+		t.Errorf("Unexpected JSON sidecar %+v", sidecar)
+	}
+
+	xmlPath, err := WriteSidecar(report, SidecarXML)
+	if err != nil {
+		t.Fatalf("WriteSidecar(xml) failed: %v", err)
+	}
+	if filepath.Ext(xmlPath) != ".xml" {
+		t.Errorf("Expected .xml sidecar, got %s", xmlPath)
+	}
+	data, err = os.ReadFile(xmlPath)
+	if err != nil {
+		t.Fatalf("Failed to read XML sidecar: %v", err)
+	}
+	for _, want := range []string{
+		"<report>",
+		"<script>Sunny &amp; 72 &lt;today&gt;.</script>",
+		"<alerts>\n    <alert>High Surf Advisory</alert>",
+		"<tts_model>eleven_multilingual_v2</tts_model>",
+		"<run_id>20260314T055500-3f9a1c2e</run_id>",
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("XML sidecar missing %q:\n%s", want, data)
+		}
+	}
+}
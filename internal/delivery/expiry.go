@@ -0,0 +1,232 @@
+package delivery
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"myrcast/internal/logger"
+)
+
+// AIDEV-NOTE: Each delivered file is recorded in an expiry manifest with its
+// valid-until time and modification time. The sweep only touches files it
+// recorded and that are unchanged since, so evergreen spots, hand-placed files
+// and a newer report under the same name are never removed by mistake.
+//
+// Generate runs (e.g. from cron) and the sweep in 'myrcast serve' or
+// 'myrcast sweep --watch' update the manifest from separate processes, so each
+// read-modify-write holds a lock file next to it. An O_EXCL lock file works on
+// every platform the tool runs on; one left by a crashed process is taken over
+// once it is older than staleLockAge.
+
+// Expiry actions: what the sweep does with a report past its valid-until time
+const (
+	ExpiryDelete    = "delete"    // Remove the report so automation skips it
+	ExpiryEvergreen = "evergreen" // Replace it with the evergreen file
+)
+
+// ExpiryActions lists the supported expiry actions
+var ExpiryActions = []string{ExpiryDelete, ExpiryEvergreen}
+
+// ExpiryManifestName is the manifest file name in the stage directory
+const ExpiryManifestName = "expiry.json"
+
+// Manifest lock timing
+const (
+	lockTimeout  = 30 * time.Second // How long to wait for another process
+	lockRetry    = 50 * time.Millisecond
+	staleLockAge = 2 * time.Minute // Older locks were left by a crashed process
+)
+
+// expiryEntry is one tracked report in the manifest
+type expiryEntry struct {
+	File       string    `json:"file"`
+	ValidUntil time.Time `json:"valid_until"`
+	ModTime    time.Time `json:"mod_time"` // Identifies the version that was recorded
+}
+
+// SweepConfig configures what the sweep does with expired reports
+type SweepConfig struct {
+	Action        string // ExpiryDelete or ExpiryEvergreen
+	EvergreenFile string // Replacement for ExpiryEvergreen
+	DryRun        bool   // Report what would happen without changing anything
+}
+
+// SweptFile describes an expired report the sweep acted on
+type SweptFile struct {
+	Path       string    // The expired report
+	ValidUntil time.Time // When it stopped being valid
+	Action     string    // ExpiryDelete or ExpiryEvergreen
+}
+
+// RecordExpiry adds delivered files to the manifest, replacing any earlier
+// entries for the same paths. Files that no longer exist (e.g. removed after a
+// Rivendell import) are skipped.
+func RecordExpiry(manifestPath string, files []string, validUntil time.Time) error {
+	unlock, err := lockManifest(manifestPath)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	entries, err := readExpiryManifest(manifestPath)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		info, err := os.Lstat(file)
+		if err != nil {
+			logger.Debug("Not tracking expiry of %s: %v", file, err)
+			continue
+		}
+		entry := expiryEntry{File: file, ValidUntil: validUntil, ModTime: info.ModTime()}
+		replaced := false
+		for i := range entries {
+			if entries[i].File == file {
+				entries[i], replaced = entry, true
+			}
+		}
+		if !replaced {
+			entries = append(entries, entry)
+		}
+	}
+	return writeExpiryManifest(manifestPath, entries)
+}
+
+// Sweep deletes or replaces the recorded reports whose valid-until time has
+// passed. Failed actions are returned together and stay in the manifest, so
+// the next sweep tries again.
+func Sweep(manifestPath string, config SweepConfig, now time.Time) ([]SweptFile, error) {
+	unlock, err := lockManifest(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	entries, err := readExpiryManifest(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var swept []SweptFile
+	var kept []expiryEntry
+	var errs []error
+	for _, entry := range entries {
+		info, err := os.Lstat(entry.File)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			logger.Debug("Expired tracking of %s: file is gone", entry.File)
+			continue
+		case err != nil:
+			errs = append(errs, fmt.Errorf("failed to check %s: %w", entry.File, err))
+			kept = append(kept, entry)
+			continue
+		case !info.ModTime().Equal(entry.ModTime):
+			logger.Debug("Expired tracking of %s: file was replaced after it was recorded", entry.File)
+			continue
+		case now.Before(entry.ValidUntil):
+			kept = append(kept, entry)
+			continue
+		}
+
+		file := SweptFile{Path: entry.File, ValidUntil: entry.ValidUntil, Action: config.Action}
+		if config.DryRun {
+			swept = append(swept, file)
+			kept = append(kept, entry)
+			continue
+		}
+		var actionErr error
+		switch config.Action {
+		case ExpiryEvergreen:
+			_, actionErr = replaceWithEvergreen(FallbackResult{Path: entry.File}, config.EvergreenFile, entry.File)
+		default:
+			file.Action = ExpiryDelete
+			_, actionErr = removeSpot(FallbackResult{Path: entry.File}, entry.File)
+		}
+		if actionErr != nil {
+			errs = append(errs, actionErr)
+			kept = append(kept, entry)
+			continue
+		}
+		swept = append(swept, file)
+	}
+
+	if !config.DryRun {
+		if err := writeExpiryManifest(manifestPath, kept); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return swept, errors.Join(errs...)
+}
+
+// lockManifest takes the manifest's lock file, waiting up to lockTimeout for
+// another process to release it. The returned function releases the lock.
+func lockManifest(manifestPath string) (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(manifestPath), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create expiry manifest directory: %w", err)
+	}
+	lockPath := manifestPath + ".lock"
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			fmt.Fprintf(file, "%d\n", os.Getpid())
+			file.Close()
+			return func() { os.Remove(lockPath) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("failed to lock expiry manifest: %w", err)
+		}
+		if info, statErr := os.Stat(lockPath); statErr == nil && time.Since(info.ModTime()) > staleLockAge {
+			logger.Warn("Removing stale expiry manifest lock %s", lockPath)
+			os.Remove(lockPath)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("failed to lock expiry manifest: %s is held by another process", lockPath)
+		}
+		time.Sleep(lockRetry)
+	}
+}
+
+// readExpiryManifest loads the tracked reports. A missing manifest tracks nothing.
+func readExpiryManifest(path string) ([]expiryEntry, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read expiry manifest: %w", err)
+	}
+	var entries []expiryEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse expiry manifest %s: %w", path, err)
+	}
+	return entries, nil
+}
+
+// writeExpiryManifest saves the tracked reports, soonest to expire first
+func writeExpiryManifest(path string, entries []expiryEntry) error {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].ValidUntil.Before(entries[j].ValidUntil)
+	})
+	if entries == nil {
+		entries = []expiryEntry{}
+	}
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode expiry manifest: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create expiry manifest directory: %w", err)
+	}
+	if err := writeFileAtomic(path, append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write expiry manifest: %w", err)
+	}
+	return nil
+}
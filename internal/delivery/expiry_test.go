@@ -0,0 +1,168 @@
+package delivery
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestSweep tests that only recorded, unchanged, expired reports are swept
+func TestSweep(t *testing.T) {
+	dir := t.TempDir()
+	manifest := filepath.Join(t.TempDir(), ExpiryManifestName)
+	now := time.Date(2026, 3, 14, 13, 0, 0, 0, time.UTC)
+
+	morning := filepath.Join(dir, "weather_morning.mp3")
+	afternoon := filepath.Join(dir, "weather_afternoon.mp3")
+	replaced := filepath.Join(dir, "weather_report.mp3")
+	untracked := filepath.Join(dir, "station_id.mp3")
+	for _, file := range []string{morning, afternoon, replaced, untracked} {
+		writeTestFile(t, file, "report")
+	}
+	writeTestFile(t, SidecarPath(morning, SidecarJSON), "{}")
+
+	noon := time.Date(2026, 3, 14, 12, 0, 0, 0, time.UTC)
+	if err := RecordExpiry(manifest, []string{morning, replaced}, noon); err != nil {
+		t.Fatalf("RecordExpiry failed: %v", err)
+	}
+	if err := RecordExpiry(manifest, []string{afternoon, filepath.Join(dir, "imported.mp3")}, noon.Add(5*time.Hour)); err != nil {
+		t.Fatalf("RecordExpiry failed: %v", err)
+	}
+	// A newer version under the same name is no longer the recorded report
+	later := now.Add(-time.Minute)
+	if err := os.Chtimes(replaced, later, later); err != nil {
+		t.Fatalf("Failed to touch file: %v", err)
+	}
+
+	// A dry run reports without touching anything
+	swept, err := Sweep(manifest, SweepConfig{Action: ExpiryDelete, DryRun: true}, now)
+	if err != nil {
+		t.Fatalf("Sweep dry run failed: %v", err)
+	}
+	if len(swept) != 1 || swept[0].Path != morning {
+		t.Fatalf("Expected dry run to find only the morning report, got %+v", swept)
+	}
+	if _, err := os.Stat(morning); err != nil {
+		t.Fatalf("Dry run removed the report: %v", err)
+	}
+
+	swept, err = Sweep(manifest, SweepConfig{Action: ExpiryDelete}, now)
+	if err != nil {
+		t.Fatalf("Sweep failed: %v", err)
+	}
+	if len(swept) != 1 || swept[0].Path != morning || swept[0].Action != ExpiryDelete || !swept[0].ValidUntil.Equal(noon) {
+		t.Fatalf("Expected the morning report to be deleted, got %+v", swept)
+	}
+	for _, gone := range []string{morning, SidecarPath(morning, SidecarJSON)} {
+		if _, err := os.Stat(gone); !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be removed", gone)
+		}
+	}
+	for _, kept := range []string{afternoon, replaced, untracked} {
+		if _, err := os.Stat(kept); err != nil {
+			t.Errorf("Expected %s to be kept: %v", kept, err)
+		}
+	}
+
+	// Only the afternoon report is still tracked; it expires at 5 PM
+	entries, err := readExpiryManifest(manifest)
+	if err != nil {
+		t.Fatalf("Failed to read manifest: %v", err)
+	}
+	if len(entries) != 1 || entries[0].File != afternoon {
+		t.Fatalf("Expected only the afternoon report tracked, got %+v", entries)
+	}
+	evergreen := filepath.Join(t.TempDir(), "evergreen.mp3")
+	writeTestFile(t, evergreen, "evergreen")
+	swept, err = Sweep(manifest, SweepConfig{Action: ExpiryEvergreen, EvergreenFile: evergreen}, noon.Add(6*time.Hour))
+	if err != nil {
+		t.Fatalf("Sweep failed: %v", err)
+	}
+	if len(swept) != 1 || swept[0].Action != ExpiryEvergreen {
+		t.Fatalf("Expected the afternoon report to be replaced, got %+v", swept)
+	}
+	if data, _ := os.ReadFile(afternoon); string(data) != "evergreen" {
+		t.Errorf("Expected evergreen content, got %q", data)
+	}
+	if entries, _ := readExpiryManifest(manifest); len(entries) != 0 {
+		t.Errorf("Expected nothing tracked after the sweep, got %+v", entries)
+	}
+}
+
+// TestSweepKeepsFailedEntries tests that a failed replacement is retried next time
+func TestSweepKeepsFailedEntries(t *testing.T) {
+	report := filepath.Join(t.TempDir(), "weather_report.mp3")
+	manifest := filepath.Join(t.TempDir(), ExpiryManifestName)
+	writeTestFile(t, report, "report")
+	validUntil := time.Date(2026, 3, 14, 12, 0, 0, 0, time.UTC)
+	if err := RecordExpiry(manifest, []string{report}, validUntil); err != nil {
+		t.Fatalf("RecordExpiry failed: %v", err)
+	}
+
+	config := SweepConfig{Action: ExpiryEvergreen, EvergreenFile: filepath.Join(t.TempDir(), "missing.mp3")}
+	if _, err := Sweep(manifest, config, validUntil.Add(time.Hour)); err == nil {
+		t.Fatal("Expected an error for a missing evergreen file")
+	}
+	if entries, _ := readExpiryManifest(manifest); len(entries) != 1 {
+		t.Errorf("Expected the report to stay tracked, got %+v", entries)
+	}
+}
+
+// TestRecordExpiryConcurrent tests that concurrent updates don't lose entries
+func TestRecordExpiryConcurrent(t *testing.T) {
+	dir := t.TempDir()
+	manifest := filepath.Join(t.TempDir(), ExpiryManifestName)
+	validUntil := time.Date(2026, 3, 14, 12, 0, 0, 0, time.UTC)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		report := filepath.Join(dir, fmt.Sprintf("weather_%02d.mp3", i))
+		writeTestFile(t, report, "report")
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := RecordExpiry(manifest, []string{report}, validUntil); err != nil {
+				t.Errorf("RecordExpiry failed: %v", err)
+			}
+		}()
+		// Sweeps run alongside, as in 'myrcast serve'
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := Sweep(manifest, SweepConfig{Action: ExpiryDelete}, validUntil.Add(-time.Hour)); err != nil {
+				t.Errorf("Sweep failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if entries, _ := readExpiryManifest(manifest); len(entries) != 20 {
+		t.Errorf("Expected 20 tracked reports, got %d", len(entries))
+	}
+	if _, err := os.Stat(manifest + ".lock"); !os.IsNotExist(err) {
+		t.Error("Expected the lock to be released")
+	}
+}
+
+// TestLockManifestStale tests that a lock left by a crashed process is taken over
+func TestLockManifestStale(t *testing.T) {
+	manifest := filepath.Join(t.TempDir(), ExpiryManifestName)
+	lockPath := manifest + ".lock"
+	writeTestFile(t, lockPath, "12345\n")
+	old := time.Now().Add(-2 * staleLockAge)
+	if err := os.Chtimes(lockPath, old, old); err != nil {
+		t.Fatalf("Failed to age lock: %v", err)
+	}
+
+	unlock, err := lockManifest(manifest)
+	if err != nil {
+		t.Fatalf("lockManifest failed: %v", err)
+	}
+	unlock()
+	if _, err := os.Stat(lockPath); !os.IsNotExist(err) {
+		t.Error("Expected the lock to be released")
+	}
+}
@@ -0,0 +1,118 @@
+package delivery
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"myrcast/internal/logger"
+)
+
+// AIDEV-NOTE: When a run fails the import folder still holds whatever was there,
+// which may be a spot from days ago. The fallback policy decides what the
+// automation system plays instead. keep_last falls through to the evergreen file
+// (or removal) once the last good spot is too old, so a stale forecast never
+// airs just because it happens to be on disk.
+
+// Fallback policies
+const (
+	FallbackNone      = "none"      // Leave the import folder as it is
+	FallbackKeepLast  = "keep_last" // Keep the last good spot while it is younger than MaxAge
+	FallbackEvergreen = "evergreen" // Replace the spot with a station-produced generic file
+	FallbackRemove    = "remove"    // Remove the spot so automation skips it
+)
+
+// FallbackPolicies lists the supported fallback policies
+var FallbackPolicies = []string{FallbackNone, FallbackKeepLast, FallbackEvergreen, FallbackRemove}
+
+// FallbackConfig configures what happens to the spot when a run fails
+type FallbackConfig struct {
+	Policy        string        // One of FallbackPolicies
+	MaxAge        time.Duration // keep_last: oldest spot still worth airing
+	EvergreenFile string        // Generic spot used by evergreen, and by keep_last once the spot is too old
+}
+
+// FallbackResult reports what the fallback did to the spot
+type FallbackResult struct {
+	Action string // FallbackNone, FallbackKeepLast, FallbackEvergreen or FallbackRemove
+	Path   string // The spot in the import folder
+	Reason string // Why this action was taken
+}
+
+// ApplyFallback puts the configured fallback in place of the spot at path after
+// a failed run
+func ApplyFallback(config FallbackConfig, path string, now time.Time) (FallbackResult, error) {
+	result := FallbackResult{Action: FallbackNone, Path: path}
+	switch config.Policy {
+	case "", FallbackNone:
+		result.Reason = "fallback policy is none; the import folder was left as it is"
+		return result, nil
+
+	case FallbackKeepLast:
+		info, err := os.Stat(path)
+		switch {
+		case err == nil && now.Sub(info.ModTime()) <= config.MaxAge:
+			result.Action = FallbackKeepLast
+			result.Reason = fmt.Sprintf("last good report is %s old (limit %s)",
+				now.Sub(info.ModTime()).Round(time.Minute), config.MaxAge)
+			return result, nil
+		case err == nil:
+			result.Reason = fmt.Sprintf("last good report is %s old, over the %s limit",
+				now.Sub(info.ModTime()).Round(time.Minute), config.MaxAge)
+		case errors.Is(err, os.ErrNotExist):
+			result.Reason = "there is no previous report to keep"
+		default:
+			return result, fmt.Errorf("failed to check last good report %s: %w", path, err)
+		}
+		if config.EvergreenFile == "" {
+			return removeSpot(result, path)
+		}
+		return replaceWithEvergreen(result, config.EvergreenFile, path)
+
+	case FallbackEvergreen:
+		result.Reason = "fallback policy is evergreen"
+		return replaceWithEvergreen(result, config.EvergreenFile, path)
+
+	case FallbackRemove:
+		result.Reason = "fallback policy is remove"
+		return removeSpot(result, path)
+	}
+	return result, fmt.Errorf("unknown fallback policy %q", config.Policy)
+}
+
+// replaceWithEvergreen copies the evergreen file over the spot
+func replaceWithEvergreen(result FallbackResult, evergreenFile, path string) (FallbackResult, error) {
+	data, err := os.ReadFile(evergreenFile)
+	if err != nil {
+		return result, fmt.Errorf("failed to read evergreen file: %w", err)
+	}
+	if err := writeFileAtomic(path, data); err != nil {
+		return result, fmt.Errorf("failed to put evergreen file in place at %s: %w", path, err)
+	}
+	// Metadata for the failed report would describe the wrong audio
+	removeSidecars(path)
+	result.Action = FallbackEvergreen
+	logger.Debug("Evergreen file %s copied to %s", evergreenFile, path)
+	return result, nil
+}
+
+// removeSpot deletes the spot and its sidecars so automation skips the slot
+func removeSpot(result FallbackResult, path string) (FallbackResult, error) {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return result, fmt.Errorf("failed to remove report %s: %w", path, err)
+	}
+	removeSidecars(path)
+	result.Action = FallbackRemove
+	return result, nil
+}
+
+// removeSidecars deletes any metadata files written next to the audio
+func removeSidecars(audioPath string) {
+	for _, format := range []string{SidecarJSON, SidecarXML} {
+		sidecarPath := SidecarPath(audioPath, format)
+		if err := os.Remove(sidecarPath); err == nil {
+			logger.Debug("Removed sidecar %s", sidecarPath)
+		}
+	}
+}
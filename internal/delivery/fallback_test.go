@@ -0,0 +1,96 @@
+package delivery
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestApplyFallback tests each policy against fresh, stale and missing spots
+func TestApplyFallback(t *testing.T) {
+	now := time.Date(2026, 3, 14, 5, 55, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		policy       string
+		spotAge      time.Duration // 0 means there is no spot
+		evergreen    bool
+		wantAction   string
+		wantContent  string // Spot content afterwards; "" means removed
+		wantReason   string
+		wantSidecars bool
+	}{
+		{name: "none leaves the spot", policy: FallbackNone, spotAge: 72 * time.Hour, wantAction: FallbackNone, wantContent: "last good", wantReason: "left as it is", wantSidecars: true},
+		{name: "keep fresh spot", policy: FallbackKeepLast, spotAge: 3 * time.Hour, evergreen: true, wantAction: FallbackKeepLast, wantContent: "last good", wantReason: "3h0m0s old", wantSidecars: true},
+		{name: "stale spot becomes evergreen", policy: FallbackKeepLast, spotAge: 30 * time.Hour, evergreen: true, wantAction: FallbackEvergreen, wantContent: "evergreen", wantReason: "over the 12h0m0s limit"},
+		{name: "stale spot without evergreen is removed", policy: FallbackKeepLast, spotAge: 30 * time.Hour, wantAction: FallbackRemove, wantReason: "over the 12h0m0s limit"},
+		{name: "missing spot becomes evergreen", policy: FallbackKeepLast, evergreen: true, wantAction: FallbackEvergreen, wantContent: "evergreen", wantReason: "no previous report"},
+		{name: "evergreen", policy: FallbackEvergreen, spotAge: time.Hour, evergreen: true, wantAction: FallbackEvergreen, wantContent: "evergreen"},
+		{name: "remove", policy: FallbackRemove, spotAge: time.Hour, wantAction: FallbackRemove},
+		{name: "remove without a spot", policy: FallbackRemove, wantAction: FallbackRemove},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			spot := filepath.Join(dir, "weather_report.mp3")
+			if tt.spotAge > 0 {
+				writeTestFile(t, spot, "last good")
+				writeTestFile(t, SidecarPath(spot, SidecarJSON), "{}")
+				modTime := now.Add(-tt.spotAge)
+				if err := os.Chtimes(spot, modTime, modTime); err != nil {
+					t.Fatalf("Failed to age spot: %v", err)
+				}
+			}
+			config := FallbackConfig{Policy: tt.policy, MaxAge: 12 * time.Hour}
+			if tt.evergreen {
+				config.EvergreenFile = filepath.Join(dir, "evergreen.mp3")
+				writeTestFile(t, config.EvergreenFile, "evergreen")
+			}
+
+			result, err := ApplyFallback(config, spot, now)
+			if err != nil {
+				t.Fatalf("ApplyFallback failed: %v", err)
+			}
+			if result.Action != tt.wantAction {
+				t.Errorf("Action = %s, want %s", result.Action, tt.wantAction)
+			}
+			if !strings.Contains(result.Reason, tt.wantReason) {
+				t.Errorf("Reason = %q, want it to contain %q", result.Reason, tt.wantReason)
+			}
+
+			data, err := os.ReadFile(spot)
+			switch {
+			case tt.wantContent == "" && !os.IsNotExist(err):
+				t.Errorf("Expected the spot to be removed, got %q (%v)", data, err)
+			case tt.wantContent != "" && string(data) != tt.wantContent:
+				t.Errorf("Spot content = %q, want %q (%v)", data, tt.wantContent, err)
+			}
+			if _, err := os.Stat(SidecarPath(spot, SidecarJSON)); (err == nil) != tt.wantSidecars && tt.spotAge > 0 {
+				t.Errorf("Sidecar kept = %v, want %v", err == nil, tt.wantSidecars)
+			}
+		})
+	}
+}
+
+// TestApplyFallbackErrors tests that a missing evergreen file is reported
+func TestApplyFallbackErrors(t *testing.T) {
+	spot := filepath.Join(t.TempDir(), "weather_report.mp3")
+	config := FallbackConfig{Policy: FallbackEvergreen, EvergreenFile: filepath.Join(t.TempDir(), "missing.mp3")}
+	if _, err := ApplyFallback(config, spot, time.Now()); err == nil {
+		t.Error("Expected an error for a missing evergreen file")
+	}
+	if _, err := ApplyFallback(FallbackConfig{Policy: "shrug"}, spot, time.Now()); err == nil {
+		t.Error("Expected an error for an unknown policy")
+	}
+}
+
+// writeTestFile writes content to path or fails the test
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+}
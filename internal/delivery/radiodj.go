@@ -0,0 +1,30 @@
+package delivery
+
+import (
+	"context"
+	"fmt"
+)
+
+// RadioDJAdapter leaves the report in RadioDJ's watched folder with a metadata
+// sidecar next to it that import tools can read instead of probing the audio
+type RadioDJAdapter struct {
+	SidecarFormat string // Sidecar format (default: json)
+}
+
+// Name identifies the adapter
+func (a *RadioDJAdapter) Name() string {
+	return AdapterRadioDJ
+}
+
+// Deliver writes the sidecar next to the audio file
+func (a *RadioDJAdapter) Deliver(ctx context.Context, report Report) (string, error) {
+	format := a.SidecarFormat
+	if format == "" || format == SidecarNone {
+		format = SidecarJSON
+	}
+	sidecarPath, err := WriteSidecar(report, format)
+	if err != nil {
+		return "", fmt.Errorf("failed to write RadioDJ sidecar: %w", err)
+	}
+	return fmt.Sprintf("%s (sidecar %s)", report.AudioFile, sidecarPath), nil
+}
@@ -0,0 +1,136 @@
+package delivery
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"myrcast/internal/logger"
+)
+
+// Rivendell defaults
+const (
+	defaultRDImportCommand = "rdimport"
+	defaultRDImportTimeout = 2 * time.Minute
+	maxRivendellCart       = 999999
+	maxCommandOutput       = 500 // Characters of rdimport output kept in errors
+)
+
+// RivendellConfig configures loading reports into a Rivendell cart with rdimport
+type RivendellConfig struct {
+	Command      []string      // rdimport and any wrapper, e.g. ["sudo", "-u", "rivendell", "rdimport"]
+	Group        string        // Rivendell group the cart belongs to
+	Cart         int           // Cart number to replace (1-999999)
+	Args         []string      // Extra rdimport options, e.g. "--normalization-level=-13"
+	DeleteSource bool          // Remove the audio file after a successful import
+	Timeout      time.Duration // How long rdimport may run (default: 2 minutes)
+}
+
+// RivendellAdapter imports reports into a fixed cart, replacing its audio each run
+type RivendellAdapter struct {
+	config RivendellConfig
+}
+
+// NewRivendellAdapter checks the Rivendell settings and creates the adapter
+func NewRivendellAdapter(config RivendellConfig) (*RivendellAdapter, error) {
+	if len(config.Command) == 0 {
+		config.Command = []string{defaultRDImportCommand}
+	}
+	if strings.TrimSpace(config.Group) == "" {
+		return nil, fmt.Errorf("rivendell group is required")
+	}
+	if config.Cart < 1 || config.Cart > maxRivendellCart {
+		return nil, fmt.Errorf("rivendell cart must be between 1 and %d, got %d", maxRivendellCart, config.Cart)
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = defaultRDImportTimeout
+	}
+	return &RivendellAdapter{config: config}, nil
+}
+
+// Name identifies the adapter
+func (a *RivendellAdapter) Name() string {
+	return AdapterRivendell
+}
+
+// Deliver runs rdimport to replace the cart's audio with the report
+func (a *RivendellAdapter) Deliver(ctx context.Context, report Report) (string, error) {
+	args := a.importArgs(report)
+	complete := logger.LogOperationStart("rivendell_import", map[string]any{
+		"command": a.config.Command[0],
+		"group":   a.config.Group,
+		"cart":    a.config.Cart,
+		"file":    report.AudioFile,
+	})
+
+	ctx, cancel := context.WithTimeout(ctx, a.config.Timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, a.config.Command[0], args...)
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	logger.Debug("Running %s %s", a.config.Command[0], strings.Join(args, " "))
+
+	if err := cmd.Run(); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			err = fmt.Errorf("timed out after %v", a.config.Timeout)
+		}
+		err = fmt.Errorf("rdimport failed for cart %06d: %w%s", a.config.Cart, err, commandOutput(output.String()))
+		complete(err)
+		return "", err
+	}
+	logger.Debug("rdimport output: %s", strings.TrimSpace(output.String()))
+
+	if a.config.DeleteSource {
+		if err := os.Remove(report.AudioFile); err != nil {
+			logger.Warn("Imported into Rivendell but could not remove %s: %v", report.AudioFile, err)
+		}
+	}
+
+	complete(nil)
+	return fmt.Sprintf("Rivendell cart %06d (group %s)", a.config.Cart, a.config.Group), nil
+}
+
+// ConsumesSource reports whether Deliver removes the audio file after the import
+func (a *RivendellAdapter) ConsumesSource() bool {
+	return a.config.DeleteSource
+}
+
+// importArgs builds the rdimport arguments: options, then group and file
+func (a *RivendellAdapter) importArgs(report Report) []string {
+	args := append([]string{}, a.config.Command[1:]...)
+	args = append(args,
+		"--to-cart="+strconv.Itoa(a.config.Cart),
+		"--delete-cuts",
+	)
+	if report.Title != "" {
+		args = append(args, "--set-string-title="+report.Title)
+	}
+	if report.Artist != "" {
+		args = append(args, "--set-string-artist="+report.Artist)
+	}
+	if report.Album != "" {
+		args = append(args, "--set-string-album="+report.Album)
+	}
+	args = append(args, a.config.Args...)
+	return append(args, a.config.Group, report.AudioFile)
+}
+
+// commandOutput formats the end of a command's output for an error message
+func commandOutput(output string) string {
+	output = strings.TrimSpace(output)
+	if output == "" {
+		return ""
+	}
+	if len(output) > maxCommandOutput {
+		output = "..." + output[len(output)-maxCommandOutput:]
+	}
+	return ": " + output
+}
@@ -0,0 +1,144 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Sidecar formats for [output] sidecar
+const (
+	SidecarNone = "none"
+	SidecarJSON = "json"
+	SidecarXML  = "xml"
+)
+
+// SidecarFormats lists the supported sidecar formats
+var SidecarFormats = []string{SidecarNone, SidecarJSON, SidecarXML}
+
+// WeatherSnapshot holds the key weather values a report was written from
+type WeatherSnapshot struct {
+	Units       string  `json:"units" xml:"units"`
+	Conditions  string  `json:"conditions" xml:"conditions"`
+	CurrentTemp float64 `json:"current_temp" xml:"current_temp"`
+	High        float64 `json:"high" xml:"high"`
+	Low         float64 `json:"low" xml:"low"`
+	RainChance  float64 `json:"rain_chance" xml:"rain_chance"`
+	Wind        string  `json:"wind" xml:"wind"`
+}
+
+// Sidecar describes a generated spot for traffic, logging and web systems, so
+// they know what is in the file without listening to it
+type Sidecar struct {
+	XMLName     xml.Name         `json:"-" xml:"report"`
+	File        string           `json:"file" xml:"file"`
+	Title       string           `json:"title" xml:"title"`
+	Artist      string           `json:"artist" xml:"artist"`
+	Album       string           `json:"album,omitempty" xml:"album,omitempty"`
+	Location    string           `json:"location,omitempty" xml:"location,omitempty"`
+	DurationMs  int64            `json:"duration_ms" xml:"duration_ms"`
+	GeneratedAt time.Time        `json:"generated_at" xml:"generated_at"`
+	ValidUntil  time.Time        `json:"valid_until" xml:"valid_until"`
+	RunID       string           `json:"run_id,omitempty" xml:"run_id,omitempty"`
+	Voice       string           `json:"voice,omitempty" xml:"voice,omitempty"`
+	TTSModel    string           `json:"tts_model,omitempty" xml:"tts_model,omitempty"`
+	ScriptModel string           `json:"script_model,omitempty" xml:"script_model,omitempty"`
+	Weather     *WeatherSnapshot `json:"weather,omitempty" xml:"weather,omitempty"`
+	Alerts      []string         `json:"alerts" xml:"alerts>alert"`
+	Script      string           `json:"script" xml:"script"`
+}
+
+// NewSidecar builds the sidecar metadata for a report
+func NewSidecar(report Report) Sidecar {
+	alerts := report.Alerts
+	if alerts == nil {
+		alerts = []string{}
+	}
+	return Sidecar{
+		File:        filepath.Base(report.AudioFile),
+		Title:       report.Title,
+		Artist:      report.Artist,
+		Album:       report.Album,
+		Location:    report.Location,
+		DurationMs:  report.Duration.Milliseconds(),
+		GeneratedAt: report.GeneratedAt,
+		ValidUntil:  report.ValidUntil,
+		RunID:       report.RunID,
+		Voice:       report.Voice,
+		TTSModel:    report.TTSModel,
+		ScriptModel: report.ScriptModel,
+		Weather:     report.Weather,
+		Alerts:      alerts,
+		Script:      report.Script,
+	}
+}
+
+// SidecarPath returns the sidecar path for an audio file in a format:
+// weather_report.mp3 becomes weather_report.json or weather_report.xml
+func SidecarPath(audioFile, format string) string {
+	return strings.TrimSuffix(audioFile, filepath.Ext(audioFile)) + "." + format
+}
+
+// WriteSidecar writes the report's sidecar next to its audio file and returns its path
+func WriteSidecar(report Report, format string) (string, error) {
+	sidecar := NewSidecar(report)
+
+	var data []byte
+	var err error
+	switch format {
+	case SidecarJSON:
+		data, err = json.MarshalIndent(sidecar, "", "  ")
+	case SidecarXML:
+		data, err = xml.MarshalIndent(sidecar, "", "  ")
+		data = append([]byte(xml.Header), data...)
+	default:
+		return "", fmt.Errorf("unknown sidecar format %q", format)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to encode %s sidecar: %w", format, err)
+	}
+
+	path := SidecarPath(report.AudioFile, format)
+	if err := writeFileAtomic(path, append(data, '\n')); err != nil {
+		return "", fmt.Errorf("failed to write sidecar file: %w", err)
+	}
+	return path, nil
+}
+
+// sidecarAdapter writes a sidecar before handing the report to another adapter
+type sidecarAdapter struct {
+	Adapter
+	format string
+}
+
+// Deliver writes the sidecar, then delivers the report
+func (a *sidecarAdapter) Deliver(ctx context.Context, report Report) (string, error) {
+	if _, err := WriteSidecar(report, a.format); err != nil {
+		return "", err
+	}
+	return a.Adapter.Deliver(ctx, report)
+}
+
+// ConsumesSource forwards to the wrapped adapter
+func (a *sidecarAdapter) ConsumesSource() bool {
+	return ConsumesSource(a.Adapter)
+}
+
+// writeFileAtomic writes data to a temp file and renames it over path, so a
+// watcher never reads a partial sidecar
+func writeFileAtomic(path string, data []byte) error {
+	tempPath := path + ".tmp"
+	if err := os.WriteFile(tempPath, data, 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		os.Remove(tempPath)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,257 @@
+// Package feed keeps a podcast-style RSS feed of recent reports and serves the
+// latest one over HTTP.
+package feed
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"mime"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// AIDEV-NOTE: The feed file is its own history. Each run reads the existing
+// feed.xml, puts the new report first and trims it to max_items, so no other
+// state is kept. Enclosure URLs are the configured base URL plus the audio file
+// name, which is also where the built-in server serves each report.
+
+// DefaultMaxItems is how many reports the feed keeps when not configured
+const DefaultMaxItems = 10
+
+// Channel describes the feed as a whole
+type Channel struct {
+	Title       string // Feed title
+	Description string // Feed description
+	Link        string // Base URL the reports are served from, e.g. https://example.org/weather/
+}
+
+// Item is one report in the feed
+type Item struct {
+	Title       string    // Item title
+	Description string    // Report script
+	FileName    string    // Audio file name, relative to the feed's base URL
+	Length      int64     // Audio size in bytes
+	Type        string    // Audio MIME type
+	PubDate     time.Time // When the report was generated
+	GUID        string    // Unique ID (default: file name and generation time)
+}
+
+// rssDocument is the RSS 2.0 wire format
+type rssDocument struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Generator     string    `xml:"generator,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string       `xml:"title"`
+	Description string       `xml:"description,omitempty"`
+	PubDate     string       `xml:"pubDate"`
+	GUID        rssGUID      `xml:"guid"`
+	Enclosure   rssEnclosure `xml:"enclosure"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Length int64  `xml:"length,attr"`
+	Type   string `xml:"type,attr"`
+}
+
+// NewItem describes an audio file as a feed item, reading its size from disk
+func NewItem(audioFile, title, description string, pubDate time.Time) (Item, error) {
+	info, err := os.Stat(audioFile)
+	if err != nil {
+		return Item{}, fmt.Errorf("failed to read audio file for feed: %w", err)
+	}
+	fileName := filepath.Base(audioFile)
+	return Item{
+		Title:       title,
+		Description: description,
+		FileName:    fileName,
+		Length:      info.Size(),
+		Type:        AudioType(fileName),
+		PubDate:     pubDate,
+		GUID:        fmt.Sprintf("%s#%d", fileName, pubDate.Unix()),
+	}, nil
+}
+
+// audioTypes covers the formats ElevenLabs produces; the system MIME table
+// (which may not know .mp3 on a minimal server) is only a fallback
+var audioTypes = map[string]string{
+	".mp3":  "audio/mpeg",
+	".wav":  "audio/wav",
+	".pcm":  "audio/L16",
+	".ulaw": "audio/basic",
+	".opus": "audio/ogg",
+}
+
+// AudioType returns the MIME type of an audio file from its extension
+func AudioType(fileName string) string {
+	if contentType, ok := audioTypes[strings.ToLower(filepath.Ext(fileName))]; ok {
+		return contentType
+	}
+	if contentType := mime.TypeByExtension(filepath.Ext(fileName)); contentType != "" {
+		return contentType
+	}
+	return "application/octet-stream"
+}
+
+// Update adds item to the front of the feed at path, keeping at most maxItems
+func Update(path string, channel Channel, item Item, maxItems int) error {
+	if maxItems <= 0 {
+		maxItems = DefaultMaxItems
+	}
+
+	items, err := Read(path)
+	if err != nil {
+		return err
+	}
+	// A rerun for the same file and time replaces its entry instead of repeating it
+	kept := []Item{item}
+	for _, existing := range items {
+		if existing.GUID != item.GUID {
+			kept = append(kept, existing)
+		}
+	}
+	if len(kept) > maxItems {
+		kept = kept[:maxItems]
+	}
+	return write(path, channel, kept)
+}
+
+// Remove drops the items for the given audio file names from the feed at path,
+// e.g. after the sweep deleted them, and returns how many were removed
+func Remove(path string, channel Channel, fileNames []string) (int, error) {
+	items, err := Read(path)
+	if err != nil || len(items) == 0 {
+		return 0, err
+	}
+	var kept []Item
+	for _, item := range items {
+		if !slices.Contains(fileNames, item.FileName) {
+			kept = append(kept, item)
+		}
+	}
+	removed := len(items) - len(kept)
+	if removed == 0 {
+		return 0, nil
+	}
+	return removed, write(path, channel, kept)
+}
+
+// write renders the items and atomically replaces the feed at path
+func write(path string, channel Channel, items []Item) error {
+	data, err := Render(channel, items, time.Now())
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create feed directory: %w", err)
+	}
+	tempPath := path + ".tmp"
+	if err := os.WriteFile(tempPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write feed file: %w", err)
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		os.Remove(tempPath)
+		return fmt.Errorf("failed to write feed file: %w", err)
+	}
+	return nil
+}
+
+// Read returns the items of the feed at path, newest first. A missing feed has no items.
+func Read(path string) ([]Item, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read feed file: %w", err)
+	}
+
+	var document rssDocument
+	if err := xml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse feed file %s: %w", path, err)
+	}
+
+	var items []Item
+	for _, entry := range document.Channel.Items {
+		pubDate, _ := time.Parse(time.RFC1123Z, entry.PubDate)
+		items = append(items, Item{
+			Title:       entry.Title,
+			Description: entry.Description,
+			FileName:    fileNameFromURL(entry.Enclosure.URL),
+			Length:      entry.Enclosure.Length,
+			Type:        entry.Enclosure.Type,
+			PubDate:     pubDate,
+			GUID:        entry.GUID.Value,
+		})
+	}
+	return items, nil
+}
+
+// Render builds the RSS 2.0 document for the channel and items
+func Render(channel Channel, items []Item, built time.Time) ([]byte, error) {
+	document := rssDocument{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:         channel.Title,
+			Link:          channel.Link,
+			Description:   channel.Description,
+			LastBuildDate: built.Format(time.RFC1123Z),
+			Generator:     "Myrcast",
+		},
+	}
+	for _, item := range items {
+		document.Channel.Items = append(document.Channel.Items, rssItem{
+			Title:       item.Title,
+			Description: item.Description,
+			PubDate:     item.PubDate.Format(time.RFC1123Z),
+			GUID:        rssGUID{Value: item.GUID},
+			Enclosure: rssEnclosure{
+				URL:    enclosureURL(channel.Link, item.FileName),
+				Length: item.Length,
+				Type:   item.Type,
+			},
+		})
+	}
+
+	data, err := xml.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode feed: %w", err)
+	}
+	return append([]byte(xml.Header), append(data, '\n')...), nil
+}
+
+// enclosureURL joins the feed's base URL and an audio file name
+func enclosureURL(link, fileName string) string {
+	return strings.TrimRight(link, "/") + "/" + url.PathEscape(fileName)
+}
+
+// fileNameFromURL recovers the audio file name from an enclosure URL
+func fileNameFromURL(rawURL string) string {
+	name := rawURL[strings.LastIndex(rawURL, "/")+1:]
+	if unescaped, err := url.PathUnescape(name); err == nil {
+		return unescaped
+	}
+	return name
+}
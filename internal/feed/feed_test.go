@@ -0,0 +1,185 @@
+package feed
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeReport writes a fake report audio file and returns its feed item
+func writeReport(t *testing.T, dir, name string, pubDate time.Time) Item {
+	audioFile := filepath.Join(dir, name)
+	if err := os.WriteFile(audioFile, []byte("audio for "+name), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", name, err)
+	}
+	item, err := NewItem(audioFile, "Weather Report", "Script for "+name, pubDate)
+	if err != nil {
+		t.Fatalf("NewItem failed: %v", err)
+	}
+	return item
+}
+
+// TestUpdate tests that new reports go first, reruns replace their entry and the feed is trimmed
+func TestUpdate(t *testing.T) {
+	dir := t.TempDir()
+	feedPath := filepath.Join(dir, "feed.xml")
+	channel := Channel{Title: "KXYZ Weather", Description: "Latest weather", Link: "https://example.org/weather/"}
+	start := time.Date(2026, 3, 14, 6, 0, 0, 0, time.UTC)
+
+	var items []Item
+	for i := 0; i < 4; i++ {
+		items = append(items, writeReport(t, dir, fmt.Sprintf("wx_%02d.mp3", i), start.Add(time.Duration(i)*time.Hour)))
+		if err := Update(feedPath, channel, items[i], 3); err != nil {
+			t.Fatalf("Update failed: %v", err)
+		}
+	}
+	// Rerunning the newest report does not add a duplicate
+	if err := Update(feedPath, channel, items[3], 3); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	got, err := Read(feedPath)
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	var names []string
+	for _, item := range got {
+		names = append(names, item.FileName)
+	}
+	if strings.Join(names, ",") != "wx_03.mp3,wx_02.mp3,wx_01.mp3" {
+		t.Errorf("Unexpected feed items %v", names)
+	}
+	if !got[0].PubDate.Equal(items[3].PubDate) || got[0].Type != "audio/mpeg" || got[0].Length != items[3].Length {
+		t.Errorf("Item did not round-trip: %+v", got[0])
+	}
+
+	data, err := os.ReadFile(feedPath)
+	if err != nil {
+		t.Fatalf("Failed to read feed: %v", err)
+	}
+	for _, want := range []string{
+		`<rss version="2.0">`,
+		`<enclosure url="https://example.org/weather/wx_03.mp3" length="19" type="audio/mpeg"></enclosure>`,
+		`<pubDate>Sat, 14 Mar 2026 09:00:00 +0000</pubDate>`,
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("Feed missing %q:\n%s", want, data)
+		}
+	}
+}
+
+// TestRemove tests that swept reports drop out of the feed
+func TestRemove(t *testing.T) {
+	dir := t.TempDir()
+	feedPath := filepath.Join(dir, "feed.xml")
+	channel := Channel{Title: "KXYZ Weather", Link: "https://example.org/weather/"}
+	start := time.Date(2026, 3, 14, 6, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		item := writeReport(t, dir, fmt.Sprintf("wx_%02d.mp3", i), start.Add(time.Duration(i)*time.Hour))
+		if err := Update(feedPath, channel, item, 10); err != nil {
+			t.Fatalf("Update failed: %v", err)
+		}
+	}
+
+	removed, err := Remove(feedPath, channel, []string{"wx_00.mp3", "wx_01.mp3", "other.mp3"})
+	if err != nil || removed != 2 {
+		t.Fatalf("Remove = %d, %v; want 2 removed", removed, err)
+	}
+	got, err := Read(feedPath)
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if len(got) != 1 || got[0].FileName != "wx_02.mp3" {
+		t.Errorf("Unexpected feed items %+v", got)
+	}
+
+	// A missing feed has nothing to remove
+	if removed, err := Remove(filepath.Join(dir, "missing.xml"), channel, []string{"wx_02.mp3"}); err != nil || removed != 0 {
+		t.Errorf("Remove on a missing feed = %d, %v", removed, err)
+	}
+}
+
+// TestServer tests routes, content types and caching headers
+func TestServer(t *testing.T) {
+	dir := t.TempDir()
+	feedPath := filepath.Join(dir, "feed.xml")
+	channel := Channel{Title: "KXYZ Weather", Link: "http://localhost:8080/"}
+	older := writeReport(t, dir, "wx_morning.mp3", time.Date(2026, 3, 14, 6, 0, 0, 0, time.UTC))
+	latest := writeReport(t, dir, "wx_evening.mp3", time.Date(2026, 3, 14, 18, 0, 0, 0, time.UTC))
+	for _, item := range []Item{older, latest} {
+		if err := Update(feedPath, channel, item, 10); err != nil {
+			t.Fatalf("Update failed: %v", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "private.mp3"), []byte("not in the feed"), 0644); err != nil {
+		t.Fatalf("Failed to write unlisted file: %v", err)
+	}
+
+	server := NewServer(dir, feedPath, 30)
+	tests := []struct {
+		method      string
+		path        string
+		wantStatus  int
+		wantType    string
+		wantContain string
+	}{
+		{"GET", "/feed.xml", http.StatusOK, "application/rss+xml; charset=utf-8", "wx_evening.mp3"},
+		{"GET", "/latest.mp3", http.StatusOK, "audio/mpeg", "audio for wx_evening.mp3"},
+		{"GET", "/latest.json", http.StatusOK, "application/json", `"script": "Script for wx_evening.mp3"`},
+		{"GET", "/wx_morning.mp3", http.StatusOK, "audio/mpeg", "audio for wx_morning.mp3"},
+		{"GET", "/private.mp3", http.StatusNotFound, "", ""},
+		{"GET", "/../feed.xml", http.StatusNotFound, "", ""},
+		{"POST", "/latest.mp3", http.StatusMethodNotAllowed, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			server.ServeHTTP(recorder, httptest.NewRequest(tt.method, tt.path, nil))
+
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d", tt.wantStatus, recorder.Code)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if got := recorder.Header().Get("Content-Type"); got != tt.wantType {
+				t.Errorf("Expected Content-Type %q, got %q", tt.wantType, got)
+			}
+			if got := recorder.Header().Get("Cache-Control"); got != "public, max-age=30" {
+				t.Errorf("Unexpected Cache-Control %q", got)
+			}
+			if recorder.Header().Get("Last-Modified") == "" {
+				t.Error("Expected Last-Modified header")
+			}
+			if !strings.Contains(recorder.Body.String(), tt.wantContain) {
+				t.Errorf("Expected body containing %q, got %q", tt.wantContain, recorder.Body.String())
+			}
+		})
+	}
+
+	// A JSON sidecar, when there is one, is served as the latest metadata
+	if err := os.WriteFile(filepath.Join(dir, "wx_evening.json"), []byte(`{"from":"sidecar"}`), 0644); err != nil {
+		t.Fatalf("Failed to write sidecar: %v", err)
+	}
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, httptest.NewRequest("GET", "/latest.json", nil))
+	if !strings.Contains(recorder.Body.String(), "sidecar") {
+		t.Errorf("Expected sidecar contents, got %q", recorder.Body.String())
+	}
+
+	// Conditional requests are answered without a body
+	recorder = httptest.NewRecorder()
+	server.ServeHTTP(recorder, httptest.NewRequest("GET", "/latest.mp3", nil))
+	request := httptest.NewRequest("GET", "/latest.mp3", nil)
+	request.Header.Set("If-None-Match", recorder.Header().Get("ETag"))
+	recorder = httptest.NewRecorder()
+	server.ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusNotModified {
+		t.Errorf("Expected 304 for a matching ETag, got %d", recorder.Code)
+	}
+}
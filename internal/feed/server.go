@@ -0,0 +1,161 @@
+package feed
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"myrcast/internal/logger"
+)
+
+// DefaultCacheSeconds is how long clients may cache responses when not configured
+const DefaultCacheSeconds = 60
+
+// Server serves the latest report, its metadata and the feed over HTTP:
+//
+//	/latest.mp3   the newest report's audio
+//	/latest.json  its JSON sidecar, or a summary from the feed
+//	/feed.xml     the RSS feed
+//	/<file>       any report audio file listed in the feed (the enclosure URLs)
+type Server struct {
+	dir          string // Directory holding the report audio files
+	feedPath     string // RSS feed file
+	cacheSeconds int    // Cache-Control max-age
+}
+
+// NewServer creates a server for the reports in dir and the feed at feedPath
+func NewServer(dir, feedPath string, cacheSeconds int) *Server {
+	if cacheSeconds <= 0 {
+		cacheSeconds = DefaultCacheSeconds
+	}
+	return &Server{dir: dir, feedPath: feedPath, cacheSeconds: cacheSeconds}
+}
+
+// latestSummary is served as /latest.json when the report has no JSON sidecar
+type latestSummary struct {
+	File        string    `json:"file"`
+	URL         string    `json:"url"`
+	Title       string    `json:"title"`
+	GeneratedAt time.Time `json:"generated_at"`
+	Length      int64     `json:"length"`
+	Type        string    `json:"type"`
+	Script      string    `json:"script"`
+}
+
+// ServeHTTP routes requests to the feed, the latest report or a listed report
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	logger.Debug("HTTP %s %s from %s", r.Method, r.URL.Path, r.RemoteAddr)
+
+	switch r.URL.Path {
+	case "/feed.xml":
+		s.serveFile(w, r, s.feedPath, "application/rss+xml; charset=utf-8")
+		return
+	case "/latest.mp3", "/latest.json":
+		s.serveLatest(w, r)
+		return
+	}
+
+	// Only audio files listed in the feed are served, never the rest of the folder
+	name := strings.TrimPrefix(r.URL.Path, "/")
+	items, err := Read(s.feedPath)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	for _, item := range items {
+		if item.FileName == name && filepath.Base(name) == name {
+			s.serveFile(w, r, filepath.Join(s.dir, name), item.Type)
+			return
+		}
+	}
+	http.NotFound(w, r)
+}
+
+// serveLatest serves the newest report's audio or metadata
+func (s *Server) serveLatest(w http.ResponseWriter, r *http.Request) {
+	items, err := Read(s.feedPath)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	if len(items) == 0 {
+		http.Error(w, "no reports yet", http.StatusNotFound)
+		return
+	}
+	latest := items[0]
+	audioPath := filepath.Join(s.dir, latest.FileName)
+
+	if r.URL.Path == "/latest.mp3" {
+		s.serveFile(w, r, audioPath, latest.Type)
+		return
+	}
+
+	sidecarPath := strings.TrimSuffix(audioPath, filepath.Ext(audioPath)) + ".json"
+	if _, err := os.Stat(sidecarPath); err == nil {
+		s.serveFile(w, r, sidecarPath, "application/json")
+		return
+	}
+	data, err := json.MarshalIndent(latestSummary{
+		File:        latest.FileName,
+		URL:         "/" + latest.FileName,
+		Title:       latest.Title,
+		GeneratedAt: latest.PubDate,
+		Length:      latest.Length,
+		Type:        latest.Type,
+		Script:      latest.Description,
+	}, "", "  ")
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	s.setCacheHeaders(w)
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Last-Modified", latest.PubDate.UTC().Format(http.TimeFormat))
+	w.Write(append(data, '\n'))
+}
+
+// serveFile serves a file with the given content type and caching headers.
+// http.ServeContent handles conditional and range requests.
+func (s *Server) serveFile(w http.ResponseWriter, r *http.Request, path, contentType string) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			http.NotFound(w, r)
+			return
+		}
+		s.serverError(w, err)
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+	s.setCacheHeaders(w)
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("ETag", fmt.Sprintf(`"%x-%x"`, info.ModTime().UnixNano(), info.Size()))
+	http.ServeContent(w, r, filepath.Base(path), info.ModTime(), file)
+}
+
+// setCacheHeaders lets clients and proxies reuse a response briefly, so a
+// smart-speaker fleet doesn't hit the server on every request
+func (s *Server) setCacheHeaders(w http.ResponseWriter) {
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", s.cacheSeconds))
+}
+
+// serverError logs an unexpected error and reports it without details
+func (s *Server) serverError(w http.ResponseWriter, err error) {
+	logger.Error("HTTP request failed: %v", err)
+	http.Error(w, "internal server error", http.StatusInternalServerError)
+}
@@ -0,0 +1,304 @@
+// Package hooks notifies other systems when a run succeeds, fails or finds
+// active weather alerts, by posting JSON to a webhook or running a command.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"myrcast/internal/logger"
+)
+
+// AIDEV-NOTE: Hooks exist so a failed 5:55 AM run is noticed before the dead air
+// is. They run after the execution summary is logged and never change the exit
+// code: a broken webhook must not turn a good report into a failed run. Each
+// attempt gets its own timeout, and only errors that might clear up (network
+// failures, timeouts, 5xx, 408 and 429) are retried.
+
+// Hook events
+const (
+	EventSuccess = "success" // The run finished and the report was delivered
+	EventFailure = "failure" // The run failed; the payload carries the error
+	EventAlert   = "alert"   // The forecast has active weather alerts
+)
+
+// Events lists every event a hook can subscribe to
+var Events = []string{EventSuccess, EventFailure, EventAlert}
+
+// Defaults for hooks that leave timing unset
+const (
+	DefaultTimeout    = 10 * time.Second
+	DefaultRetries    = 2
+	DefaultRetryDelay = 2 * time.Second
+	maxCommandOutput  = 500 // Characters of command or response output kept in errors
+	userAgent         = "Myrcast"
+)
+
+// Hook is one webhook or command to run on the events it subscribes to
+type Hook struct {
+	Name       string            // Label used in the log (default: the URL or command)
+	Events     []string          // Events that fire the hook
+	URL        string            // Webhook to POST the JSON payload to
+	Headers    map[string]string // Extra request headers, e.g. Authorization
+	Command    []string          // Program and arguments to run instead of a webhook
+	Timeout    time.Duration     // Per-attempt time limit (default: 10s)
+	Retries    int               // Further attempts after a failure
+	RetryDelay time.Duration     // Wait before the first retry, doubled each time (default: 2s)
+}
+
+// Payload describes a run. It is the webhook body and, as environment
+// variables, the input of command hooks.
+type Payload struct {
+	Event      string    `json:"event"`
+	RunID      string    `json:"run_id"`
+	Mode       string    `json:"mode"`
+	ConfigFile string    `json:"config_file"`
+	StartTime  time.Time `json:"start_time"`
+	DurationMs int64     `json:"duration_ms"`
+	ExitCode   int       `json:"exit_code"`
+	Results    []string  `json:"results"`
+	Error      string    `json:"error,omitempty"`
+	Location   string    `json:"location,omitempty"`
+	AudioFile  string    `json:"audio_file,omitempty"`
+	Alerts     []string  `json:"alerts,omitempty"`
+	Host       string    `json:"host,omitempty"`
+}
+
+// NewPayload builds the payload from the same data as the execution summary
+func NewPayload(event string, startTime time.Time, configFile, mode string, results []string, exitCode int) Payload {
+	host, _ := os.Hostname()
+	return Payload{
+		Event:      event,
+		RunID:      logger.RunID(),
+		Mode:       mode,
+		ConfigFile: configFile,
+		StartTime:  startTime,
+		DurationMs: time.Since(startTime).Milliseconds(),
+		ExitCode:   exitCode,
+		Results:    results,
+		Host:       host,
+	}
+}
+
+// Subscribes reports whether the hook fires on event
+func (h Hook) Subscribes(event string) bool {
+	for _, subscribed := range h.Events {
+		if subscribed == event {
+			return true
+		}
+	}
+	return false
+}
+
+// label names the hook in log messages
+func (h Hook) label() string {
+	switch {
+	case h.Name != "":
+		return h.Name
+	case h.URL != "":
+		// Webhook URLs often carry their token in the path
+		if u, err := url.Parse(h.URL); err == nil && u.Host != "" {
+			return u.Scheme + "://" + u.Host
+		}
+		return "webhook"
+	case len(h.Command) > 0:
+		return h.Command[0]
+	}
+	return "hook"
+}
+
+// Fire runs every hook subscribed to the payload's event and returns how many
+// failed after all their retries. Outcomes are logged, not returned, since a
+// hook failure never fails the run.
+func Fire(ctx context.Context, hooks []Hook, payload Payload) int {
+	failed := 0
+	for _, hook := range hooks {
+		if !hook.Subscribes(payload.Event) {
+			continue
+		}
+		if err := run(ctx, hook, payload); err != nil {
+			logger.Warn("Hook %s failed for %s event: %v", hook.label(), payload.Event, err)
+			failed++
+		}
+	}
+	return failed
+}
+
+// run tries a hook until it succeeds, the error is permanent or retries run out
+func run(ctx context.Context, hook Hook, payload Payload) error {
+	if hook.Timeout <= 0 {
+		hook.Timeout = DefaultTimeout
+	}
+	if hook.RetryDelay <= 0 {
+		hook.RetryDelay = DefaultRetryDelay
+	}
+	kind := "command"
+	if hook.URL != "" {
+		kind = "webhook"
+	}
+
+	complete := logger.LogOperationStart("hook", map[string]any{
+		"hook":    hook.label(),
+		"type":    kind,
+		"event":   payload.Event,
+		"retries": hook.Retries,
+	})
+
+	delay := hook.RetryDelay
+	var err error
+	for attempt := 1; attempt <= hook.Retries+1; attempt++ {
+		if attempt > 1 {
+			logger.Info("Retrying hook %s in %v (attempt %d of %d): %v", hook.label(), delay, attempt, hook.Retries+1, err)
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				err = fmt.Errorf("%w (gave up waiting to retry: %v)", err, ctx.Err())
+				complete(err)
+				return err
+			}
+			delay *= 2
+		}
+
+		if hook.URL != "" {
+			err = post(ctx, hook, payload)
+		} else {
+			err = execute(ctx, hook, payload)
+		}
+		if err == nil {
+			logger.Info("Hook %s sent %s event", hook.label(), payload.Event)
+			complete(nil)
+			return nil
+		}
+		var permanent *permanentError
+		if errors.As(err, &permanent) {
+			break
+		}
+	}
+
+	complete(err)
+	return err
+}
+
+// permanentError marks a failure that retrying will not fix, such as a 4xx response
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// post sends the payload to the hook's URL as JSON
+func post(ctx context.Context, hook Hook, payload Payload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return &permanentError{fmt.Errorf("failed to encode hook payload: %w", err)}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, hook.Timeout)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return &permanentError{fmt.Errorf("failed to create hook request: %w", err)}
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", userAgent)
+	request.Header.Set(logger.RunIDHeader, payload.RunID)
+	for name, value := range hook.Headers {
+		request.Header.Set(name, value)
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("webhook timed out after %v", hook.Timeout)
+		}
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer response.Body.Close()
+	responseBody, _ := io.ReadAll(io.LimitReader(response.Body, maxCommandOutput))
+
+	if response.StatusCode >= 200 && response.StatusCode < 300 {
+		return nil
+	}
+	err = fmt.Errorf("webhook returned %s%s", response.Status, commandOutput(string(responseBody)))
+	if response.StatusCode >= 500 || response.StatusCode == http.StatusRequestTimeout || response.StatusCode == http.StatusTooManyRequests {
+		return err
+	}
+	return &permanentError{err}
+}
+
+// execute runs the hook's command with the run described in its environment
+func execute(ctx context.Context, hook Hook, payload Payload) error {
+	if len(hook.Command) == 0 {
+		return &permanentError{fmt.Errorf("hook has neither a url nor a command")}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, hook.Timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, hook.Command[0], hook.Command[1:]...)
+	cmd.Env = append(os.Environ(), Environment(payload)...)
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	logger.Debug("Running hook command %s", strings.Join(hook.Command, " "))
+
+	if err := cmd.Run(); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			err = fmt.Errorf("timed out after %v", hook.Timeout)
+		}
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) && !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			// The program could not be started; another attempt won't find it either
+			return &permanentError{fmt.Errorf("hook command failed: %w", err)}
+		}
+		return fmt.Errorf("hook command failed: %w%s", err, commandOutput(output.String()))
+	}
+	logger.Debug("Hook command output: %s", strings.TrimSpace(output.String()))
+	return nil
+}
+
+// Environment describes the run as MYRCAST_* variables for command hooks. The
+// whole payload is also passed as JSON in MYRCAST_PAYLOAD.
+func Environment(payload Payload) []string {
+	payloadJSON, _ := json.Marshal(payload)
+	return []string{
+		"MYRCAST_EVENT=" + payload.Event,
+		"MYRCAST_RUN_ID=" + payload.RunID,
+		"MYRCAST_MODE=" + payload.Mode,
+		"MYRCAST_CONFIG_FILE=" + payload.ConfigFile,
+		"MYRCAST_START_TIME=" + payload.StartTime.Format(time.RFC3339),
+		"MYRCAST_DURATION_MS=" + strconv.FormatInt(payload.DurationMs, 10),
+		"MYRCAST_EXIT_CODE=" + strconv.Itoa(payload.ExitCode),
+		"MYRCAST_ERROR=" + payload.Error,
+		"MYRCAST_LOCATION=" + payload.Location,
+		"MYRCAST_AUDIO_FILE=" + payload.AudioFile,
+		"MYRCAST_ALERTS=" + strings.Join(payload.Alerts, "; "),
+		"MYRCAST_RESULTS=" + strings.Join(payload.Results, "\n"),
+		"MYRCAST_PAYLOAD=" + string(payloadJSON),
+	}
+}
+
+// commandOutput formats the end of a command's output or response body for an error message
+func commandOutput(output string) string {
+	output = strings.TrimSpace(output)
+	if output == "" {
+		return ""
+	}
+	if len(output) > maxCommandOutput {
+		output = "..." + output[len(output)-maxCommandOutput:]
+	}
+	return ": " + output
+}
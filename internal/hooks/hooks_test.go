@@ -0,0 +1,254 @@
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"myrcast/internal/logger"
+)
+
+// TestHookCommandStub stands in for a hook command when run as a subprocess by
+// the command tests. It records its MYRCAST_* environment and fails when asked to.
+func TestHookCommandStub(t *testing.T) {
+	if os.Getenv("MYRCAST_HOOK_STUB") != "1" {
+		return
+	}
+	var env []string
+	for _, variable := range os.Environ() {
+		if strings.HasPrefix(variable, "MYRCAST_") && !strings.HasPrefix(variable, "MYRCAST_HOOK_") {
+			env = append(env, variable)
+		}
+	}
+	logFile, err := os.OpenFile(os.Getenv("MYRCAST_HOOK_LOG"), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		os.Exit(3)
+	}
+	fmt.Fprintln(logFile, strings.Join(env, "\x00"))
+	logFile.Close()
+	if os.Getenv("MYRCAST_HOOK_FAIL") == "1" {
+		fmt.Fprintln(os.Stderr, "notify: channel not found")
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+// stubCommand runs TestHookCommandStub as the hook command and returns its log
+func stubCommand(t *testing.T, fail bool) ([]string, string) {
+	logPath := filepath.Join(t.TempDir(), "hook.log")
+	t.Setenv("MYRCAST_HOOK_STUB", "1")
+	t.Setenv("MYRCAST_HOOK_LOG", logPath)
+	if fail {
+		t.Setenv("MYRCAST_HOOK_FAIL", "1")
+	} else {
+		t.Setenv("MYRCAST_HOOK_FAIL", "")
+	}
+	return []string{os.Args[0], "-test.run=^TestHookCommandStub$"}, logPath
+}
+
+// testPayload returns the payload of a failed run
+func testPayload(event string) Payload {
+	return Payload{
+		Event:      event,
+		RunID:      "20260314T055500-3f9a1c2e",
+		Mode:       "weather-report",
+		ConfigFile: "config.toml",
+		StartTime:  time.Date(2026, 3, 14, 5, 55, 0, 0, time.UTC),
+		DurationMs: 1500,
+		ExitCode:   2,
+		Results:    []string{"Weather report generation failed: timeout"},
+		Error:      "timeout",
+		Alerts:     []string{"Flood Watch", "Wind Advisory"},
+	}
+}
+
+// TestFireWebhook tests the posted payload, headers and event filtering
+func TestFireWebhook(t *testing.T) {
+	var requests atomic.Int32
+	var received Payload
+	var authorization, contentType, runID string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		authorization = r.Header.Get("Authorization")
+		contentType = r.Header.Get("Content-Type")
+		runID = r.Header.Get(logger.RunIDHeader)
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("Failed to decode payload: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	hooks := []Hook{
+		{Name: "pager", Events: []string{EventFailure}, URL: server.URL, Headers: map[string]string{"Authorization": "Bearer secret"}},
+		{Name: "success only", Events: []string{EventSuccess}, URL: server.URL},
+	}
+	if failed := Fire(context.Background(), hooks, testPayload(EventFailure)); failed != 0 {
+		t.Fatalf("Fire reported %d failed hooks", failed)
+	}
+
+	if requests.Load() != 1 {
+		t.Fatalf("Expected 1 request, got %d", requests.Load())
+	}
+	if authorization != "Bearer secret" {
+		t.Errorf("Authorization header = %q", authorization)
+	}
+	if contentType != "application/json" {
+		t.Errorf("Content-Type = %q", contentType)
+	}
+	if runID != "20260314T055500-3f9a1c2e" || received.RunID != runID {
+		t.Errorf("Run ID header = %q, payload = %q", runID, received.RunID)
+	}
+	if received.Event != EventFailure || received.ExitCode != 2 || received.Error != "timeout" {
+		t.Errorf("Unexpected payload: %+v", received)
+	}
+	if len(received.Results) != 1 || len(received.Alerts) != 2 {
+		t.Errorf("Payload lost results or alerts: %+v", received)
+	}
+}
+
+// TestFireWebhookRetries tests which responses are retried
+func TestFireWebhookRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int // Response status for each attempt; the last repeats
+		retries      int
+		wantRequests int32
+		wantFailed   int
+	}{
+		{"recovers after server error", []int{http.StatusBadGateway, http.StatusOK}, 2, 2, 0},
+		{"gives up after retries", []int{http.StatusServiceUnavailable}, 2, 3, 1},
+		{"rate limit is retried", []int{http.StatusTooManyRequests, http.StatusAccepted}, 1, 2, 0},
+		{"client error is not retried", []int{http.StatusNotFound}, 3, 1, 1},
+		{"no retries", []int{http.StatusInternalServerError}, 0, 1, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := int(requests.Add(1))
+				w.WriteHeader(tt.statuses[min(n, len(tt.statuses))-1])
+			}))
+			defer server.Close()
+
+			hooks := []Hook{{Events: []string{EventFailure}, URL: server.URL, Retries: tt.retries, RetryDelay: time.Millisecond}}
+			if failed := Fire(context.Background(), hooks, testPayload(EventFailure)); failed != tt.wantFailed {
+				t.Errorf("Fire reported %d failed hooks, want %d", failed, tt.wantFailed)
+			}
+			if requests.Load() != tt.wantRequests {
+				t.Errorf("Expected %d requests, got %d", tt.wantRequests, requests.Load())
+			}
+		})
+	}
+}
+
+// TestFireWebhookTimeout tests that a hung webhook is abandoned
+func TestFireWebhookTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	hooks := []Hook{{Events: []string{EventFailure}, URL: server.URL, Timeout: 50 * time.Millisecond}}
+	start := time.Now()
+	if failed := Fire(context.Background(), hooks, testPayload(EventFailure)); failed != 1 {
+		t.Errorf("Expected the hook to fail, got %d failures", failed)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Timed-out hook took %v", elapsed)
+	}
+}
+
+// TestFireCommand tests the environment passed to command hooks
+func TestFireCommand(t *testing.T) {
+	command, logPath := stubCommand(t, false)
+	hooks := []Hook{{Name: "notify", Events: []string{EventAlert}, Command: command}}
+	if failed := Fire(context.Background(), hooks, testPayload(EventAlert)); failed != 0 {
+		t.Fatalf("Fire reported %d failed hooks", failed)
+	}
+
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("Hook command did not run: %v", err)
+	}
+	env := map[string]string{}
+	for _, variable := range strings.Split(strings.TrimSpace(string(data)), "\x00") {
+		name, value, _ := strings.Cut(variable, "=")
+		env[name] = value
+	}
+	expected := map[string]string{
+		"MYRCAST_EVENT":       EventAlert,
+		"MYRCAST_RUN_ID":      "20260314T055500-3f9a1c2e",
+		"MYRCAST_EXIT_CODE":   "2",
+		"MYRCAST_ERROR":       "timeout",
+		"MYRCAST_ALERTS":      "Flood Watch; Wind Advisory",
+		"MYRCAST_DURATION_MS": "1500",
+		"MYRCAST_START_TIME":  "2026-03-14T05:55:00Z",
+	}
+	for name, want := range expected {
+		if env[name] != want {
+			t.Errorf("%s = %q, want %q", name, env[name], want)
+		}
+	}
+	var payload Payload
+	if err := json.Unmarshal([]byte(env["MYRCAST_PAYLOAD"]), &payload); err != nil || payload.Event != EventAlert {
+		t.Errorf("MYRCAST_PAYLOAD = %q (%v)", env["MYRCAST_PAYLOAD"], err)
+	}
+}
+
+// TestFireCommandFailure tests that failing commands are retried and missing ones are not
+func TestFireCommandFailure(t *testing.T) {
+	command, logPath := stubCommand(t, true)
+	hooks := []Hook{{Events: []string{EventFailure}, Command: command, Retries: 1, RetryDelay: time.Millisecond}}
+	if failed := Fire(context.Background(), hooks, testPayload(EventFailure)); failed != 1 {
+		t.Errorf("Expected the hook to fail, got %d failures", failed)
+	}
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("Hook command did not run: %v", err)
+	}
+	if runs := strings.Count(string(data), "\n"); runs != 2 {
+		t.Errorf("Expected 2 attempts, got %d", runs)
+	}
+
+	missing := []Hook{{Events: []string{EventFailure}, Command: []string{filepath.Join(t.TempDir(), "missing")}, Retries: 3}}
+	start := time.Now()
+	if failed := Fire(context.Background(), missing, testPayload(EventFailure)); failed != 1 {
+		t.Errorf("Expected the missing command to fail, got %d failures", failed)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Missing command was retried for %v", elapsed)
+	}
+}
+
+// TestLabel tests that webhook URLs are logged by host only
+func TestLabel(t *testing.T) {
+	tests := []struct {
+		hook Hook
+		want string
+	}{
+		{Hook{Name: "pager", URL: "https://hooks.example.org/x"}, "pager"},
+		{Hook{URL: "https://hooks.slack.com/services/T000/B000/secret-token"}, "https://hooks.slack.com"},
+		{Hook{URL: "not a url"}, "webhook"},
+		{Hook{Command: []string{"/usr/local/bin/studio-sign", "--flash"}}, "/usr/local/bin/studio-sign"},
+	}
+	for _, tt := range tests {
+		if got := tt.hook.label(); got != tt.want {
+			t.Errorf("label() = %q, want %q", got, tt.want)
+		}
+	}
+}
@@ -2,6 +2,8 @@ package logger
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"log/slog"
@@ -24,15 +26,37 @@ const (
 	FatalLevel Level = Level(slog.LevelError + 4) // Custom level above ERROR
 )
 
+// Log record formats
+const (
+	FormatText = "text" // key=value lines (default)
+	FormatJSON = "json" // One JSON object per line, for log aggregators
+)
+
+// Formats lists the supported log record formats
+var Formats = []string{FormatText, FormatJSON}
+
+// RunIDHeader carries the run ID on outgoing HTTP requests
+const RunIDHeader = "X-Myrcast-Run-ID"
+
 // Config represents logging configuration compatible with main config package
 type Config struct {
 	Enabled         bool   `toml:"enabled"`
 	Directory       string `toml:"directory"`
 	FilenamePattern string `toml:"filename_pattern"`
 	Level           string `toml:"level"`
+	Format          string `toml:"format"` // text (default) or json
 	MaxFiles        int    `toml:"max_files"`
 	MaxSizeMB       int    `toml:"max_size_mb"`
 	ConsoleOutput   bool   `toml:"console_output"`
+	ConsoleStderr   bool   `toml:"-"` // Write console output to stderr, keeping stdout for results
+}
+
+// consoleWriter is where console output goes
+func (c Config) consoleWriter() io.Writer {
+	if c.ConsoleStderr {
+		return os.Stderr
+	}
+	return os.Stdout
 }
 
 // EnhancedLogger wraps slog.Logger with rotation and file management capabilities
@@ -50,8 +74,30 @@ var (
 	// Global enhanced logger instance
 	globalLogger *EnhancedLogger
 	globalMu     sync.Mutex
+
+	// runID identifies this process's records in logs, results and requests
+	runID = newRunID()
 )
 
+// AIDEV-NOTE: The run ID is generated once at startup and added to every record
+// by newHandler, so one run's events can be picked out of an aggregator when
+// several locations log to the same place. It is also sent in RunIDHeader and
+// written to results, hook payloads and sidecars.
+
+// RunID returns the ID of this run
+func RunID() string {
+	return runID
+}
+
+// newRunID returns a time-ordered ID with a random suffix, e.g. 20260314T055500-3f9a1c2e
+func newRunID() string {
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return time.Now().Format("20060102T150405.000000")
+	}
+	return time.Now().Format("20060102T150405") + "-" + hex.EncodeToString(suffix)
+}
+
 // Initialize creates and configures the global logger instance with the given configuration
 func Initialize(config Config) error {
 	globalMu.Lock()
@@ -66,9 +112,7 @@ func Initialize(config Config) error {
 func Get() *EnhancedLogger {
 	if globalLogger == nil {
 		// Fallback to console-only logger if not initialized
-		consoleLogger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
-			Level: slog.LevelInfo,
-		}))
+		consoleLogger := slog.New(newHandler(os.Stdout, slog.LevelInfo, FormatText))
 		globalLogger = &EnhancedLogger{Logger: consoleLogger}
 	}
 	return globalLogger
@@ -94,7 +138,7 @@ func NewEnhancedLogger(config Config) (*EnhancedLogger, error) {
 	writers := []io.Writer{}
 
 	if config.ConsoleOutput {
-		writers = append(writers, os.Stdout)
+		writers = append(writers, config.consoleWriter())
 	}
 
 	if config.Enabled {
@@ -115,13 +159,28 @@ func NewEnhancedLogger(config Config) (*EnhancedLogger, error) {
 
 	// Create multi-writer
 	if len(writers) == 0 {
-		// Fallback to stdout if no writers configured
-		writers = append(writers, os.Stdout)
+		// Fallback to the console if no writers configured
+		writers = append(writers, config.consoleWriter())
 	}
 	logger.multiWriter = io.MultiWriter(writers...)
 
 	// Create slog handler with custom formatting - use logger as writer for rotation
-	handler := slog.NewTextHandler(logger, &slog.HandlerOptions{
+	logger.Logger = slog.New(newHandler(logger, level, config.Format))
+
+	// Log initialization
+	logger.Debug("Enhanced logger initialized",
+		slog.String("log_file", logger.fileName),
+		slog.String("level", config.Level),
+		slog.String("format", config.Format),
+		slog.Bool("console", config.ConsoleOutput))
+
+	return logger, nil
+}
+
+// newHandler creates the slog handler for a format, tagging every record with
+// the run ID and redacting secrets
+func newHandler(w io.Writer, level slog.Level, format string) slog.Handler {
+	options := &slog.HandlerOptions{
 		Level: level,
 		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
 			// Custom time format
@@ -136,17 +195,15 @@ func NewEnhancedLogger(config Config) (*EnhancedLogger, error) {
 			}
 			return a
 		},
-	})
-
-	logger.Logger = slog.New(handler)
-
-	// Log initialization
-	logger.Debug("Enhanced logger initialized",
-		slog.String("log_file", logger.fileName),
-		slog.String("level", config.Level),
-		slog.Bool("console", config.ConsoleOutput))
+	}
 
-	return logger, nil
+	var handler slog.Handler
+	if strings.EqualFold(format, FormatJSON) {
+		handler = slog.NewJSONHandler(w, options)
+	} else {
+		handler = slog.NewTextHandler(w, options)
+	}
+	return redactHandler{handler.WithAttrs([]slog.Attr{slog.String("run_id", runID)})}
 }
 
 // openLogFile creates or opens the current log file
@@ -249,6 +306,8 @@ func parseLogLevel(level string) slog.Level {
 		return slog.LevelWarn
 	case "error":
 		return slog.LevelError
+	case "fatal":
+		return slog.Level(FatalLevel)
 	default:
 		return slog.LevelInfo
 	}
@@ -326,29 +385,13 @@ func (l *EnhancedLogger) rotateUnsafe() error {
 	// Update multi-writer
 	writers := []io.Writer{}
 	if l.config.ConsoleOutput {
-		writers = append(writers, os.Stdout)
+		writers = append(writers, l.config.consoleWriter())
 	}
 	writers = append(writers, l.file)
 	l.multiWriter = io.MultiWriter(writers...)
 
 	// Recreate handler with new writer - use logger as writer for rotation
-	handler := slog.NewTextHandler(l, &slog.HandlerOptions{
-		Level: parseLogLevel(l.config.Level),
-		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
-			// Custom time format
-			if a.Key == slog.TimeKey {
-				return slog.String(slog.TimeKey, a.Value.Time().Format("2006-01-02T15:04:05.000-07:00"))
-			}
-			// Shorten source paths for readability
-			if a.Key == slog.SourceKey {
-				if source, ok := a.Value.Any().(*slog.Source); ok {
-					return slog.String(slog.SourceKey, fmt.Sprintf("%s:%d", filepath.Base(source.File), source.Line))
-				}
-			}
-			return a
-		},
-	})
-	l.Logger = slog.New(handler)
+	l.Logger = slog.New(newHandler(l, parseLogLevel(l.config.Level), l.config.Format))
 
 	// Clean old files if needed
 	if l.config.MaxFiles > 0 {
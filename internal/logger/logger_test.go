@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"strings"
@@ -386,6 +387,59 @@ func TestLogWithFields(t *testing.T) {
 	}
 }
 
+// TestJSONFormat tests that json records parse and carry the run ID
+func TestJSONFormat(t *testing.T) {
+	tmpDir := t.TempDir()
+	config := Config{
+		Enabled:         true,
+		Directory:       tmpDir,
+		FilenamePattern: "json.log",
+		Level:           "info",
+		Format:          FormatJSON,
+	}
+	if err := Initialize(config); err != nil {
+		t.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer Get().Close()
+
+	LogWithFields(InfoLevel, "Report delivered", map[string]any{"location": "Honolulu"})
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "json.log"))
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	var record map[string]any
+	if err := json.Unmarshal([]byte(lines[len(lines)-1]), &record); err != nil {
+		t.Fatalf("Expected a JSON record, got %q: %v", lines[len(lines)-1], err)
+	}
+	if record["msg"] != "Report delivered" || record["location"] != "Honolulu" {
+		t.Errorf("Unexpected record %v", record)
+	}
+	if RunID() == "" || record["run_id"] != RunID() {
+		t.Errorf("Expected run_id %q, got %v", RunID(), record["run_id"])
+	}
+}
+
+// TestTextFormatRunID tests that text records carry the run ID too
+func TestTextFormatRunID(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := Initialize(Config{Enabled: true, Directory: tmpDir, FilenamePattern: "text.log", Level: "info"}); err != nil {
+		t.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer Get().Close()
+
+	Info("Weather fetched")
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "text.log"))
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+	if !strings.Contains(string(content), "run_id="+RunID()) {
+		t.Errorf("Expected run_id=%s in %q", RunID(), content)
+	}
+}
+
 // TestExecutionSummary tests the execution summary logging
 func TestExecutionSummary(t *testing.T) {
 	tmpDir := t.TempDir()
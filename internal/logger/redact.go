@@ -0,0 +1,152 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// AIDEV-NOTE: Every handler made by newHandler is wrapped in redactHandler, so
+// no record reaches a log file or the console without passing through Redact:
+// the message, every attribute (inside groups too) and errors, which are logged
+// as their full wrapped text. Secrets are the configured key values registered
+// with SetSecrets; the patterns below catch keys the config doesn't know about,
+// e.g. in URLs echoed by an API client.
+
+// Redacted replaces secret values in log output
+const Redacted = "[REDACTED]"
+
+// minSecretLength keeps short values (e.g. an empty or placeholder header)
+// from blanking out ordinary words
+const minSecretLength = 4
+
+var (
+	secretsMu sync.RWMutex
+	secrets   []string
+
+	// sensitiveParam matches query parameters that carry credentials, e.g. ?appid=...
+	sensitiveParam = regexp.MustCompile(`(?i)([?&](?:appid|api_?key|access_token|token|key|xi-api-key|password|secret)=)[^&#\s"'<>]+`)
+
+	// sensitiveHeader matches credential headers written as "Name: value" or "Name=value"
+	sensitiveHeader = regexp.MustCompile(`(?i)\b((?:proxy-)?(?:authorization|x-api-key|xi-api-key)["']?\s*[:=]\s*["']?(?:(?:bearer|basic|token)\s+)?)[^\s"',;&}\]]+`)
+)
+
+// sensitiveKeys are structured field names whose values are always masked
+var sensitiveKeys = map[string]bool{
+	"appid":               true,
+	"api_key":             true,
+	"apikey":              true,
+	"x_api_key":           true,
+	"xi_api_key":          true,
+	"authorization":       true,
+	"proxy_authorization": true,
+	"access_token":        true,
+	"token":               true,
+	"password":            true,
+	"secret":              true,
+}
+
+// SetSecrets replaces the values masked wherever they appear in log output,
+// typically the configured API keys and webhook credentials
+func SetSecrets(values ...string) {
+	var masked []string
+	seen := map[string]bool{}
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if len(value) < minSecretLength {
+			continue
+		}
+		// URLs carry keys query-escaped
+		for _, form := range []string{value, url.QueryEscape(value)} {
+			if !seen[form] {
+				seen[form] = true
+				masked = append(masked, form)
+			}
+		}
+	}
+	// Longest first, so a key containing another is masked whole
+	sort.Slice(masked, func(i, j int) bool { return len(masked[i]) > len(masked[j]) })
+
+	secretsMu.Lock()
+	defer secretsMu.Unlock()
+	secrets = masked
+}
+
+// Redact masks registered secrets, credential query parameters and credential
+// headers in text
+func Redact(text string) string {
+	secretsMu.RLock()
+	values := secrets
+	secretsMu.RUnlock()
+
+	for _, secret := range values {
+		text = strings.ReplaceAll(text, secret, Redacted)
+	}
+	text = sensitiveParam.ReplaceAllString(text, "${1}"+Redacted)
+	text = sensitiveHeader.ReplaceAllString(text, "${1}"+Redacted)
+	return text
+}
+
+// redactHandler redacts records before passing them to the wrapped handler
+type redactHandler struct {
+	slog.Handler
+}
+
+// Handle redacts the message and attributes of a record
+func (h redactHandler) Handle(ctx context.Context, record slog.Record) error {
+	redacted := slog.NewRecord(record.Time, record.Level, Redact(record.Message), record.PC)
+	record.Attrs(func(a slog.Attr) bool {
+		redacted.AddAttrs(redactAttr(a))
+		return true
+	})
+	return h.Handler.Handle(ctx, redacted)
+}
+
+// WithAttrs redacts attributes added to every record
+func (h redactHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		redacted[i] = redactAttr(a)
+	}
+	return redactHandler{h.Handler.WithAttrs(redacted)}
+}
+
+// WithGroup keeps redaction for the grouped handler
+func (h redactHandler) WithGroup(name string) slog.Handler {
+	return redactHandler{h.Handler.WithGroup(name)}
+}
+
+// redactAttr masks a sensitive field outright and redacts the text of any other
+func redactAttr(a slog.Attr) slog.Attr {
+	if sensitiveKeys[strings.ReplaceAll(strings.ToLower(a.Key), "-", "_")] {
+		return slog.String(a.Key, Redacted)
+	}
+
+	value := a.Value.Resolve()
+	switch value.Kind() {
+	case slog.KindString:
+		return slog.String(a.Key, Redact(value.String()))
+	case slog.KindGroup:
+		group := value.Group()
+		redacted := make([]slog.Attr, len(group))
+		for i, member := range group {
+			redacted[i] = redactAttr(member)
+		}
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(redacted...)}
+	case slog.KindAny:
+		// Errors are logged as their full text, wrapped causes included
+		if err, ok := value.Any().(error); ok {
+			return slog.String(a.Key, Redact(err.Error()))
+		}
+		text := fmt.Sprintf("%+v", value.Any())
+		if redacted := Redact(text); redacted != text {
+			return slog.String(a.Key, redacted)
+		}
+	}
+	return slog.Attr{Key: a.Key, Value: value}
+}
@@ -0,0 +1,128 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Key values as they would come from the config file
+var testSecrets = []string{
+	"0123456789abcdef0123456789abcdef", // OpenWeather
+	"sk-ant-api03-Zx9_yW8+vU7/tS6==",   // Anthropic
+	"xi_4f7c2a9e1b3d5f60",              // ElevenLabs
+	"hook-token-8d2f",                  // Webhook Authorization header
+}
+
+// TestRedact tests masking of registered secrets and well-known credential patterns
+func TestRedact(t *testing.T) {
+	SetSecrets(testSecrets...)
+	t.Cleanup(func() { SetSecrets() })
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "registered key",
+			input: "OpenWeather rejected key 0123456789abcdef0123456789abcdef",
+			want:  "OpenWeather rejected key [REDACTED]",
+		},
+		{
+			name:  "query-escaped key",
+			input: "GET https://api.example.org/v1?k=sk-ant-api03-Zx9_yW8%2BvU7%2FtS6%3D%3D",
+			want:  "GET https://api.example.org/v1?k=[REDACTED]",
+		},
+		{
+			name:  "unregistered appid",
+			input: `Get "https://api.openweathermap.org/data/3.0/onecall?appid=unknown-key&lat=21.3": no such host`,
+			want:  `Get "https://api.openweathermap.org/data/3.0/onecall?appid=[REDACTED]&lat=21.3": no such host`,
+		},
+		{
+			name:  "api_key parameter",
+			input: "https://example.org/tts?voice=abc&api_key=other-key",
+			want:  "https://example.org/tts?voice=abc&api_key=[REDACTED]",
+		},
+		{
+			name:  "authorization header",
+			input: "request headers: map[Authorization:Bearer some-token Content-Type:application/json]",
+			want:  "request headers: map[Authorization:Bearer [REDACTED] Content-Type:application/json]",
+		},
+		{
+			name:  "api key header",
+			input: "xi-api-key: abc123def",
+			want:  "xi-api-key: [REDACTED]",
+		},
+		{
+			name:  "ordinary text",
+			input: "Cache key weather_2026-03-14 is valid; monkey=banana",
+			want:  "Cache key weather_2026-03-14 is valid; monkey=banana",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Redact(tt.input); got != tt.want {
+				t.Errorf("Redact(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSecretsNeverLogged logs each key through every logging path, in both
+// formats, and checks that none of them reaches the log file
+func TestSecretsNeverLogged(t *testing.T) {
+	SetSecrets(testSecrets...)
+	t.Cleanup(func() { SetSecrets() })
+
+	for _, format := range Formats {
+		t.Run(format, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			if err := Initialize(Config{Enabled: true, Directory: tmpDir, FilenamePattern: "redact.log", Level: "debug", Format: format}); err != nil {
+				t.Fatalf("Failed to initialize logger: %v", err)
+			}
+			defer Get().Close()
+
+			for _, secret := range testSecrets {
+				url := "https://api.openweathermap.org/data/3.0/onecall?appid=" + secret + "&lat=21.3"
+				wrapped := fmt.Errorf("failed to fetch weather: %w", fmt.Errorf("Get %q: %w", url, errors.New("timeout")))
+
+				Info("Using key %s", secret)
+				Error("Weather fetch failed: %v", wrapped)
+				LogWithFields(WarnLevel, "Request failed", map[string]any{
+					"error":   wrapped,
+					"url":     url,
+					"api_key": "shown-nowhere",
+					"headers": map[string]string{"Authorization": "Bearer " + secret},
+				})
+				LogAPIRequest("GET", url, map[string]string{"User-Agent": secret})
+				LogAPIResponse("GET", url, 401, "12ms", 0)
+				LogStructuredError(wrapped, map[string]any{"key": secret})
+				LogOperationStart("fetch", map[string]any{"url": url})(wrapped)
+				Get().Warn("Direct slog call", "error", wrapped, "detail", secret)
+				Get().WithGroup("request").With("token_value", secret).Info("Grouped " + secret)
+			}
+
+			content, err := os.ReadFile(filepath.Join(tmpDir, "redact.log"))
+			if err != nil {
+				t.Fatalf("Failed to read log file: %v", err)
+			}
+			logContent := string(content)
+			for _, secret := range append(testSecrets, "shown-nowhere") {
+				if strings.Contains(logContent, secret) {
+					t.Errorf("Secret %q appears in the %s log:\n%s", secret, format, logContent)
+				}
+			}
+			if !strings.Contains(logContent, Redacted) {
+				t.Errorf("Expected %s in the %s log", Redacted, format)
+			}
+			if !strings.Contains(logContent, "failed to fetch weather") {
+				t.Errorf("Expected the error text to survive redaction:\n%s", logContent)
+			}
+		})
+	}
+}
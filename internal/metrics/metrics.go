@@ -0,0 +1,179 @@
+// Package metrics writes run health in the Prometheus text format for the
+// node_exporter textfile collector.
+package metrics
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AIDEV-NOTE: The textfile is rewritten in full after every run and replaced
+// atomically, so node_exporter never reads half a file. Only the last success
+// time outlives a run: a failed run copies it over from the previous file, which
+// is what "no successful report in 2 hours" alerts are built on.
+
+// lastSuccessMetric is carried over from the previous file on failed runs
+const lastSuccessMetric = "myrcast_last_success_timestamp_seconds"
+
+// Run describes a finished run
+type Run struct {
+	Profile       string                    // Output profile, used as the profile label
+	Finished      time.Time                 // When the run ended
+	Success       bool                      // Whether the report was delivered
+	ExitCode      int                       // Process exit code
+	Duration      time.Duration             // Whole run
+	Stages        []Stage                   // Workflow stages in the order they ran
+	Retries       map[string]int            // Retried requests per provider
+	HTTPStatus    map[string]map[string]int // Responses per provider and status code
+	InputTokens   int                       // Claude prompt tokens
+	OutputTokens  int                       // Claude completion tokens
+	Characters    int                       // Characters sent for speech synthesis
+	AudioDuration time.Duration             // Length of the generated report
+	CacheHits     int                       // Weather forecasts served from the daily cache
+	CacheMisses   int                       // Weather forecasts fetched fresh
+}
+
+// Stage is how long one workflow stage took
+type Stage struct {
+	Name     string
+	Duration time.Duration
+	Status   string // ok or failed
+}
+
+// Write replaces the textfile at path with the metrics for run
+func Write(path string, run Run) error {
+	lastSuccess, haveLastSuccess := previousSuccess(path)
+	if run.Success {
+		lastSuccess, haveLastSuccess = float64(run.Finished.Unix()), true
+	}
+
+	w := &writer{profile: run.Profile}
+	w.gauge("myrcast_last_run_timestamp_seconds", "When the last run finished.", float64(run.Finished.Unix()))
+	if haveLastSuccess {
+		w.gauge(lastSuccessMetric, "When the last successful run finished.", lastSuccess)
+	}
+	w.gauge("myrcast_last_run_success", "Whether the last run delivered a report (1) or failed (0).", boolValue(run.Success))
+	w.gauge("myrcast_last_run_exit_code", "Exit code of the last run.", float64(run.ExitCode))
+	w.gauge("myrcast_last_run_duration_seconds", "How long the last run took.", run.Duration.Seconds())
+
+	w.help("myrcast_last_run_stage_duration_seconds", "How long each stage of the last run took.")
+	for _, stage := range run.Stages {
+		w.sample("myrcast_last_run_stage_duration_seconds", stage.Duration.Seconds(), "stage", stage.Name, "status", stage.Status)
+	}
+
+	w.help("myrcast_last_run_api_retries", "Retried API requests in the last run, per provider.")
+	for _, provider := range sortedKeys(run.Retries) {
+		w.sample("myrcast_last_run_api_retries", float64(run.Retries[provider]), "provider", provider)
+	}
+
+	w.help("myrcast_last_run_http_responses", "API responses in the last run, per provider and status code.")
+	for _, provider := range sortedKeys(run.HTTPStatus) {
+		for _, code := range sortedKeys(run.HTTPStatus[provider]) {
+			w.sample("myrcast_last_run_http_responses", float64(run.HTTPStatus[provider][code]), "provider", provider, "code", code)
+		}
+	}
+
+	w.help("myrcast_last_run_tokens", "Claude tokens used by the last run.")
+	w.sample("myrcast_last_run_tokens", float64(run.InputTokens), "type", "input")
+	w.sample("myrcast_last_run_tokens", float64(run.OutputTokens), "type", "output")
+
+	w.gauge("myrcast_last_run_characters_synthesized", "Characters sent for speech synthesis in the last run.", float64(run.Characters))
+	w.gauge("myrcast_last_run_audio_duration_seconds", "Length of the report generated by the last run.", run.AudioDuration.Seconds())
+
+	w.help("myrcast_last_run_weather_cache_lookups", "Weather cache hits and misses in the last run.")
+	w.sample("myrcast_last_run_weather_cache_lookups", float64(run.CacheHits), "result", "hit")
+	w.sample("myrcast_last_run_weather_cache_lookups", float64(run.CacheMisses), "result", "miss")
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create metrics directory: %w", err)
+	}
+	// node_exporter only reads *.prom files, so it skips the temporary file
+	tempPath := path + ".tmp"
+	if err := os.WriteFile(tempPath, []byte(w.String()), 0644); err != nil {
+		return fmt.Errorf("failed to write metrics: %w", err)
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		os.Remove(tempPath)
+		return fmt.Errorf("failed to replace metrics file: %w", err)
+	}
+	return nil
+}
+
+// previousSuccess reads the last success time from an earlier textfile
+func previousSuccess(path string) (float64, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, false
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		rest, found := strings.CutPrefix(line, lastSuccessMetric)
+		if !found || (!strings.HasPrefix(rest, "{") && !strings.HasPrefix(rest, " ")) {
+			continue
+		}
+		fields := strings.Fields(rest)
+		if len(fields) == 0 {
+			continue
+		}
+		value, err := strconv.ParseFloat(fields[len(fields)-1], 64)
+		if err == nil {
+			return value, true
+		}
+	}
+	return 0, false
+}
+
+// writer builds the text exposition format, adding the profile label to
+// every sample
+type writer struct {
+	strings.Builder
+	profile string
+}
+
+// help writes the HELP and TYPE lines of a gauge
+func (w *writer) help(name, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+}
+
+// gauge writes a gauge with a single sample
+func (w *writer) gauge(name, help string, value float64) {
+	w.help(name, help)
+	w.sample(name, value)
+}
+
+// sample writes one sample; labels are name, value pairs
+func (w *writer) sample(name string, value float64, labels ...string) {
+	labels = append([]string{"profile", w.profile}, labels...)
+	pairs := make([]string, 0, len(labels)/2)
+	for i := 0; i+1 < len(labels); i += 2 {
+		pairs = append(pairs, fmt.Sprintf("%s=\"%s\"", labels[i], escapeLabel(labels[i+1])))
+	}
+	fmt.Fprintf(w, "%s{%s} %s\n", name, strings.Join(pairs, ","), strconv.FormatFloat(value, 'f', -1, 64))
+}
+
+// escapeLabel escapes a label value for the text format
+func escapeLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+// boolValue is 1 for true and 0 for false
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// sortedKeys returns the keys of m in order, so the file is stable between runs
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
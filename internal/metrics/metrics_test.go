@@ -0,0 +1,103 @@
+package metrics
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestWrite tests the textfile contents of a successful run
+func TestWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "textfile", "myrcast.prom")
+	finished := time.Date(2026, 3, 14, 6, 0, 0, 0, time.UTC)
+	run := Run{
+		Profile:  "morning",
+		Finished: finished,
+		Success:  true,
+		Duration: 12500 * time.Millisecond,
+		Stages: []Stage{
+			{Name: "weather", Duration: 800 * time.Millisecond, Status: "ok"},
+			{Name: "script", Duration: 4 * time.Second, Status: "ok"},
+		},
+		Retries:       map[string]int{"claude": 1},
+		HTTPStatus:    map[string]map[string]int{"openweather": {"200": 2}, "claude": {"529": 1, "200": 1}},
+		InputTokens:   900,
+		OutputTokens:  250,
+		Characters:    1200,
+		AudioDuration: 45 * time.Second,
+		CacheHits:     1,
+	}
+	if err := Write(path, run); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read metrics: %v", err)
+	}
+	text := string(data)
+	for _, want := range []string{
+		`myrcast_last_run_timestamp_seconds{profile="morning"} 1773468000`,
+		`myrcast_last_success_timestamp_seconds{profile="morning"} 1773468000`,
+		`myrcast_last_run_success{profile="morning"} 1`,
+		`myrcast_last_run_duration_seconds{profile="morning"} 12.5`,
+		`myrcast_last_run_stage_duration_seconds{profile="morning",stage="weather",status="ok"} 0.8`,
+		`myrcast_last_run_api_retries{profile="morning",provider="claude"} 1`,
+		`myrcast_last_run_http_responses{profile="morning",provider="claude",code="200"} 1`,
+		`myrcast_last_run_http_responses{profile="morning",provider="claude",code="529"} 1`,
+		`myrcast_last_run_http_responses{profile="morning",provider="openweather",code="200"} 2`,
+		`myrcast_last_run_tokens{profile="morning",type="input"} 900`,
+		`myrcast_last_run_characters_synthesized{profile="morning"} 1200`,
+		`myrcast_last_run_audio_duration_seconds{profile="morning"} 45`,
+		`myrcast_last_run_weather_cache_lookups{profile="morning",result="hit"} 1`,
+		"# TYPE myrcast_last_run_success gauge",
+	} {
+		if !strings.Contains(text, want+"\n") {
+			t.Errorf("Expected metrics to contain %q, got:\n%s", want, text)
+		}
+	}
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Error("Expected the temporary file to be gone")
+	}
+}
+
+// TestWriteKeepsLastSuccess tests that a failed run keeps the previous success time
+func TestWriteKeepsLastSuccess(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "myrcast.prom")
+	success := time.Date(2026, 3, 14, 6, 0, 0, 0, time.UTC)
+
+	// No success yet: the metric is left out so absent() alerts fire
+	if err := Write(path, Run{Finished: success.Add(-time.Hour), ExitCode: 3}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if data, _ := os.ReadFile(path); strings.Contains(string(data), lastSuccessMetric+"{") {
+		t.Errorf("Expected no last success time before the first success, got:\n%s", data)
+	}
+
+	if err := Write(path, Run{Finished: success, Success: true}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if err := Write(path, Run{Finished: success.Add(2 * time.Hour), ExitCode: 3}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	for _, want := range []string{
+		`myrcast_last_success_timestamp_seconds{profile=""} 1773468000`,
+		`myrcast_last_run_timestamp_seconds{profile=""} 1773475200`,
+		`myrcast_last_run_success{profile=""} 0`,
+		`myrcast_last_run_exit_code{profile=""} 3`,
+	} {
+		if !strings.Contains(string(data), want+"\n") {
+			t.Errorf("Expected metrics to contain %q, got:\n%s", want, data)
+		}
+	}
+}
+
+// TestEscapeLabel tests label value escaping
+func TestEscapeLabel(t *testing.T) {
+	if got := escapeLabel("a \"b\"\\c\nd"); got != `a \"b\"\\c\nd` {
+		t.Errorf("escapeLabel = %s", got)
+	}
+}
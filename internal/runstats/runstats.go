@@ -0,0 +1,108 @@
+// Package runstats counts the retries, responses and tokens a run used, so they
+// can be reported without threading counters through every API client.
+package runstats
+
+import (
+	"strconv"
+	"sync"
+)
+
+// Providers whose requests are counted
+const (
+	ProviderOpenWeather = "openweather"
+	ProviderClaude      = "claude"
+	ProviderElevenLabs  = "elevenlabs"
+)
+
+// Stats is a snapshot of the counters for the current run
+type Stats struct {
+	Retries      map[string]int `json:"retries"`       // Retried requests per provider
+	InputTokens  int            `json:"input_tokens"`  // Claude prompt tokens
+	OutputTokens int            `json:"output_tokens"` // Claude completion tokens
+
+	// HTTPStatus counts responses per provider and status code, e.g.
+	// HTTPStatus["openweather"]["200"]
+	HTTPStatus  map[string]map[string]int `json:"http_status"`
+	Characters  int                       `json:"characters"`   // Characters sent for synthesis
+	CacheHits   int                       `json:"cache_hits"`   // Weather forecast served from the daily cache
+	CacheMisses int                       `json:"cache_misses"` // Weather forecast fetched because the cache was unusable
+}
+
+var (
+	mu      sync.Mutex
+	current = newStats()
+)
+
+// newStats returns empty counters
+func newStats() Stats {
+	return Stats{Retries: map[string]int{}, HTTPStatus: map[string]map[string]int{}}
+}
+
+// Retry counts a retried request to provider
+func Retry(provider string) {
+	mu.Lock()
+	defer mu.Unlock()
+	current.Retries[provider]++
+}
+
+// AddTokens counts the tokens of a Claude request
+func AddTokens(input, output int) {
+	mu.Lock()
+	defer mu.Unlock()
+	current.InputTokens += input
+	current.OutputTokens += output
+}
+
+// HTTPStatus counts a response from provider with the given status code
+func HTTPStatus(provider string, code int) {
+	mu.Lock()
+	defer mu.Unlock()
+	if current.HTTPStatus[provider] == nil {
+		current.HTTPStatus[provider] = map[string]int{}
+	}
+	current.HTTPStatus[provider][strconv.Itoa(code)]++
+}
+
+// AddCharacters counts text sent for speech synthesis
+func AddCharacters(n int) {
+	mu.Lock()
+	defer mu.Unlock()
+	current.Characters += n
+}
+
+// CacheLookup counts a weather cache hit or miss
+func CacheLookup(hit bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	if hit {
+		current.CacheHits++
+	} else {
+		current.CacheMisses++
+	}
+}
+
+// Snapshot returns a copy of the counters
+func Snapshot() Stats {
+	mu.Lock()
+	defer mu.Unlock()
+	snapshot := current
+	snapshot.Retries = make(map[string]int, len(current.Retries))
+	for provider, count := range current.Retries {
+		snapshot.Retries[provider] = count
+	}
+	snapshot.HTTPStatus = make(map[string]map[string]int, len(current.HTTPStatus))
+	for provider, codes := range current.HTTPStatus {
+		snapshot.HTTPStatus[provider] = make(map[string]int, len(codes))
+		for code, count := range codes {
+			snapshot.HTTPStatus[provider][code] = count
+		}
+	}
+	return snapshot
+}
+
+// Reset clears the counters
+func Reset() {
+	mu.Lock()
+	defer mu.Unlock()
+	current = newStats()
+}
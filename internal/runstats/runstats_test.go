@@ -0,0 +1,50 @@
+package runstats
+
+import "testing"
+
+// TestCounters tests counting, snapshots and reset
+func TestCounters(t *testing.T) {
+	Reset()
+	Retry(ProviderClaude)
+	Retry(ProviderClaude)
+	Retry(ProviderOpenWeather)
+	AddTokens(900, 250)
+	AddTokens(100, 50)
+	HTTPStatus(ProviderOpenWeather, 200)
+	HTTPStatus(ProviderOpenWeather, 200)
+	HTTPStatus(ProviderClaude, 529)
+	AddCharacters(1200)
+	CacheLookup(true)
+	CacheLookup(false)
+	CacheLookup(true)
+
+	snapshot := Snapshot()
+	if snapshot.Retries[ProviderClaude] != 2 || snapshot.Retries[ProviderOpenWeather] != 1 || snapshot.Retries[ProviderElevenLabs] != 0 {
+		t.Errorf("Unexpected retries %v", snapshot.Retries)
+	}
+	if snapshot.InputTokens != 1000 || snapshot.OutputTokens != 300 {
+		t.Errorf("Unexpected tokens %d/%d", snapshot.InputTokens, snapshot.OutputTokens)
+	}
+
+	if snapshot.HTTPStatus[ProviderOpenWeather]["200"] != 2 || snapshot.HTTPStatus[ProviderClaude]["529"] != 1 {
+		t.Errorf("Unexpected status counts %v", snapshot.HTTPStatus)
+	}
+	if snapshot.Characters != 1200 || snapshot.CacheHits != 2 || snapshot.CacheMisses != 1 {
+		t.Errorf("Unexpected characters/cache counts %+v", snapshot)
+	}
+
+	// Snapshots don't change with later counts
+	Retry(ProviderClaude)
+	HTTPStatus(ProviderOpenWeather, 200)
+	if snapshot.Retries[ProviderClaude] != 2 {
+		t.Error("Snapshot shares its retry map with the counters")
+	}
+	if snapshot.HTTPStatus[ProviderOpenWeather]["200"] != 2 {
+		t.Error("Snapshot shares its status map with the counters")
+	}
+
+	Reset()
+	if snapshot := Snapshot(); len(snapshot.Retries) != 0 || len(snapshot.HTTPStatus) != 0 || snapshot.InputTokens != 0 || snapshot.CacheHits != 0 {
+		t.Errorf("Expected empty counters after Reset, got %+v", snapshot)
+	}
+}
@@ -1,19 +1,12 @@
 package main
 
 import (
-	"context"
-	"errors"
-	"flag"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"strings"
-	"time"
-
-	"myrcast/api"
-	"myrcast/config"
-	"myrcast/internal/logger"
+	_ "time/tzdata" // Location time zones must resolve on hosts without a zone database
 )
 
 const (
@@ -30,210 +23,66 @@ const (
 	ExitAPIError        = 4 // API call failures
 	ExitFileSystemError = 5 // File system operation errors
 	ExitNetworkError    = 6 // Network connectivity errors
+	ExitUsageError      = 7 // Invalid command-line usage
+
+	// A failed run whose [fallback] policy acted exits with the action taken,
+	// so the scheduler can tell what is on air
+	ExitFallbackKept      = 8  // Run failed; the last good report was kept
+	ExitFallbackEvergreen = 9  // Run failed; the evergreen report was put in place
+	ExitFallbackRemoved   = 10 // Run failed; the report was removed
 )
 
 func main() {
-	startTime := time.Now()
-
-	// Define command-line flags
-	configPath := flag.String("config", getDefaultConfigPath(), "Path to TOML configuration file")
-	logLevel := flag.String("log-level", "info", "Logging level (debug, info, warn, error)")
-	logFile := flag.String("log-file", "", "Log output file (default: stdout)")
-	generateConfig := flag.Bool("generate-config", false, "Generate a sample configuration file and exit")
-	showVersion := flag.Bool("version", false, "Show version information and exit")
-	showHelp := flag.Bool("help", false, "Show help information and exit")
-	dryRun := flag.Bool("dry-run", false, "Validate configuration and show what would happen without executing")
-	verbose := flag.Bool("verbose", false, "Enable verbose output (equivalent to --log-level=debug)")
-
-	// Override default usage function
-	flag.Usage = func() {
-		showUsage()
-	}
-
-	flag.Parse()
-
-	// Handle help flag
-	if *showHelp {
-		showUsage()
-		os.Exit(0)
-	}
-
-	// Handle version flag
-	if *showVersion {
-		fmt.Printf("%s version %s\n", AppName, Version)
-		os.Exit(0)
-	}
-
-	// Validate log level
-	if *logLevel != "" {
-		validLevels := []string{"debug", "info", "warn", "error"}
-		if !contains(validLevels, strings.ToLower(*logLevel)) {
-			fmt.Fprintf(os.Stderr, "Error: Invalid log level '%s'. Valid levels: %s\n",
-				*logLevel, strings.Join(validLevels, ", "))
-			os.Exit(ExitGeneralError)
-		}
-	}
-
-	// Handle verbose flag
-	if *verbose {
-		*logLevel = "debug"
-	}
-
-	// Validate config path exists (unless generating config)
-	if !*generateConfig && *configPath != "" {
-		if err := validateConfigPath(*configPath); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(ExitConfigError)
-		}
-	}
-
-	flag.Parse()
-
-	// Handle config generation
-	if *generateConfig {
-		if err := config.GenerateSampleConfig(*configPath); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: Failed to generate sample config: %v\n", err)
-			os.Exit(ExitFileSystemError)
-		}
-		fmt.Printf("Sample configuration file created at: %s\n", *configPath)
-		fmt.Printf("Please edit the file to add your API keys and customize settings\n")
-		os.Exit(ExitSuccess)
-	}
-
-	// Configure enhanced logging before loading config
-	// Use basic console logging for config loading phase
-	tempLogConfig := logger.Config{
-		Enabled:         false, // Console only during startup
-		ConsoleOutput:   true,
-		Level:           *logLevel,
-		FilenamePattern: "myrcast-startup.log",
-		Directory:       "logs",
-		MaxFiles:        7,
-		MaxSizeMB:       10,
-	}
-
-	// Override with log file if specified
-	if *logFile != "" {
-		tempLogConfig.Enabled = true
-		tempLogConfig.Directory = filepath.Dir(*logFile)
-		tempLogConfig.FilenamePattern = filepath.Base(*logFile)
-	}
-
-	if err := logger.Initialize(tempLogConfig); err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: Failed to initialize logging: %v\n", err)
-		// Continue with fallback logging
-	}
-
-	// Application startup
-	logger.Info("=== MYRCAST SESSION STARTED ===")
-	logger.Info("Myrcast - Weather Report Generator")
-	logger.Debug("Starting with config: %s", *configPath)
+	os.Exit(run(os.Args[1:]))
+}
 
-	// Load configuration
-	cfg, err := config.LoadConfig(*configPath)
-	if err != nil {
-		var configNotFound *config.ConfigNotFoundError
-		if errors.As(err, &configNotFound) {
-			logger.Error("%v", err)
-			os.Exit(ExitConfigError)
-		} else {
-			logger.Error("Failed to load configuration: %v", err)
-			os.Exit(ExitConfigError)
+// run dispatches to a subcommand and returns the process exit code
+func run(args []string) int {
+	// AIDEV-NOTE: Bare flags without a subcommand run generate so existing
+	// cron entries like "myrcast --config station.toml" keep working
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		if len(args) > 0 {
+			switch args[0] {
+			case "-h", "-help", "--help":
+				showUsage(os.Stdout)
+				return ExitSuccess
+			case "-version", "--version":
+				return runVersionCommand(nil)
+			}
 		}
+		return runGenerateCommand(args)
 	}
 
-	// Validate configuration
-	if err := cfg.Validate(); err != nil {
-		logger.Error("Configuration validation failed: %v", err)
-		os.Exit(ExitValidationError)
-	}
-
-	logger.Debug("Configuration loaded and validated from: %s", *configPath)
-
-	// Reinitialize logging with configuration settings (unless overridden by command line)
-	finalLogConfig := logger.Config{
-		Enabled:         cfg.Logging.Enabled,
-		Directory:       cfg.Logging.Directory,
-		FilenamePattern: cfg.Logging.FilenamePattern,
-		Level:           cfg.Logging.Level,
-		MaxFiles:        cfg.Logging.MaxFiles,
-		MaxSizeMB:       cfg.Logging.MaxSizeMB,
-		ConsoleOutput:   cfg.Logging.ConsoleOutput,
-	}
-
-	// Override config settings with command line flags
-	if *logLevel != "info" { // info is default, so only override if different
-		finalLogConfig.Level = *logLevel
-	}
-	if *logFile != "" {
-		finalLogConfig.Enabled = true
-		finalLogConfig.Directory = filepath.Dir(*logFile)
-		finalLogConfig.FilenamePattern = filepath.Base(*logFile)
-	}
-
-	// Reinitialize logger with final configuration
-	if err := logger.Initialize(finalLogConfig); err != nil {
-		logger.Warn("Failed to reinitialize logging with config settings: %v", err)
-		// Continue with current logging setup
-	} else {
-		logger.Debug("Enhanced logging initialized from configuration")
-	}
-
-	// Log START message after final logger initialization
-	logger.Info("START")
-
-	logger.Debug("Weather coordinates: %.4f, %.4f", cfg.Weather.Latitude, cfg.Weather.Longitude)
-	logger.Debug("Units: %s", cfg.Weather.Units)
-
-	// Handle dry-run mode
-	if *dryRun {
-		logger.Info("DRY RUN MODE - Showing what would happen without executing")
-		logger.Info("Weather API: Would fetch weather for lat=%.4f, lon=%.4f using %s units",
-			cfg.Weather.Latitude, cfg.Weather.Longitude, cfg.Weather.Units)
-		logger.Info("Claude API: Would generate weather report using model %s", cfg.Claude.Model)
-		logger.Info("ElevenLabs API: Would synthesize speech using voice %s", cfg.ElevenLabs.VoiceID)
-		logger.Info("Output: Would save WAV file to %s", cfg.Output.ImportPath)
-		logger.Info("All configuration checks passed - ready for production run")
-		logger.Info("Remove --dry-run flag to execute actual weather report generation")
-		return
+	cmd, ok := findCommand(commandList(), args[0])
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Error: unknown command %q\n\n", args[0])
+		showUsage(os.Stderr)
+		return ExitUsageError
 	}
+	return cmd.run(args[1:])
+}
 
-	// Run the main weather report generation workflow
-	locationName, err := runWeatherReportWorkflow(cfg)
-	if err != nil {
-		logger.Error("Weather report generation failed: %v", err)
-
-		// Log execution summary for failed run
-		results := []string{
-			fmt.Sprintf("Weather report generation failed: %v", err),
-		}
-
-		var exitCode int
-		if isAPIError(err) {
-			exitCode = ExitAPIError
-		} else if isNetworkError(err) {
-			exitCode = ExitNetworkError
-		} else if isFileSystemError(err) {
-			exitCode = ExitFileSystemError
-		} else {
-			exitCode = ExitGeneralError
-		}
-
-		logger.Get().LogExecutionSummary(startTime, *configPath, "weather-report", results, exitCode)
-		os.Exit(exitCode)
+// runHelpCommand shows general help, or a command's own help
+func runHelpCommand(args []string) int {
+	if len(args) == 0 {
+		showUsage(os.Stdout)
+		return ExitSuccess
 	}
 
-	logger.Info("Weather report generation completed successfully")
-
-	// Log execution summary for successful run
-	results := []string{
-		"Weather report generation completed successfully",
-		fmt.Sprintf("Weather location: %s", locationName),
-		fmt.Sprintf("Output directory: %s", cfg.Output.ImportPath),
+	cmd, ok := findCommand(commandList(), args[0])
+	if !ok || cmd.name == "help" {
+		fmt.Fprintf(os.Stderr, "Error: unknown command %q\n\n", args[0])
+		showUsage(os.Stderr)
+		return ExitUsageError
 	}
-	logger.Get().LogExecutionSummary(startTime, *configPath, "weather-report", results, ExitSuccess)
+	cmd.run(append(args[1:], "--help"))
+	return ExitSuccess
+}
 
-	os.Exit(ExitSuccess)
+// runVersionCommand prints the version
+func runVersionCommand(args []string) int {
+	fmt.Printf("%s version %s\n", AppName, Version)
+	return ExitSuccess
 }
 
 // getDefaultConfigPath returns a cross-platform default config path
@@ -243,45 +92,70 @@ func getDefaultConfigPath() string {
 }
 
 // showUsage displays comprehensive help information
-func showUsage() {
-	fmt.Printf("%s - Weather Report Generator for Radio Broadcast\n\n", AppName)
-	fmt.Printf("USAGE:\n")
-	fmt.Printf("  %s [options]\n\n", strings.ToLower(AppName))
-
-	fmt.Printf("DESCRIPTION:\n")
-	fmt.Printf("  Generates AI-voiced weather reports for Myriad radio automation.\n")
-	fmt.Printf("  Creates WAV files with weather information from OpenWeather API,\n")
-	fmt.Printf("  processed through Anthropic Claude AI and ElevenLabs text-to-speech.\n\n")
-
-	fmt.Printf("OPTIONS:\n")
-	flag.PrintDefaults()
-
-	fmt.Printf("\nEXAMPLES:\n")
-	fmt.Printf("  # Generate a weather report using default config\n")
-	fmt.Printf("  %s\n\n", strings.ToLower(AppName))
-	fmt.Printf("  # Use custom config file\n")
-	fmt.Printf("  %s --config /path/to/custom.toml\n\n", strings.ToLower(AppName))
-	fmt.Printf("  # Generate sample config file\n")
-	fmt.Printf("  %s --generate-config --config example.toml\n\n", strings.ToLower(AppName))
-	fmt.Printf("  # Run with verbose logging\n")
-	fmt.Printf("  %s --verbose\n\n", strings.ToLower(AppName))
-	fmt.Printf("  # Validate configuration without executing\n")
-	fmt.Printf("  %s --dry-run\n\n", strings.ToLower(AppName))
-
-	fmt.Printf("CONFIGURATION:\n")
-	fmt.Printf("  Configuration file should contain API keys for:\n")
-	fmt.Printf("  - OpenWeather API (weather data)\n")
-	fmt.Printf("  - Anthropic Claude API (text generation)\n")
-	fmt.Printf("  - ElevenLabs API (text-to-speech)\n\n")
-
-	fmt.Printf("  Use --generate-config to create a sample configuration file.\n\n")
-
-	fmt.Printf("OUTPUT:\n")
-	fmt.Printf("  Generated WAV files are saved to the configured import directory\n")
-	fmt.Printf("  for use with Myriad radio automation software.\n\n")
-
-	fmt.Printf("VERSION:\n")
-	fmt.Printf("  %s version %s\n\n", AppName, Version)
+func showUsage(w io.Writer) {
+	app := appCommand()
+	fmt.Fprintf(w, "%s - Weather Report Generator for Radio Broadcast\n\n", AppName)
+	fmt.Fprintf(w, "USAGE:\n")
+	fmt.Fprintf(w, "  %s <command> [options]\n", app)
+	fmt.Fprintf(w, "  %s [options]              (same as '%s generate')\n\n", app, app)
+
+	fmt.Fprintf(w, "DESCRIPTION:\n")
+	fmt.Fprintf(w, "  Generates AI-voiced weather reports for Myriad radio automation.\n")
+	fmt.Fprintf(w, "  Creates audio files with weather information from OpenWeather API,\n")
+	fmt.Fprintf(w, "  processed through Anthropic Claude AI and ElevenLabs text-to-speech.\n\n")
+
+	fmt.Fprintf(w, "COMMANDS:\n")
+	for _, cmd := range commandList() {
+		fmt.Fprintf(w, "  %-10s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintf(w, "\n  Run '%s <command> --help' for the options of a command.\n\n", app)
+
+	fmt.Fprintf(w, "EXAMPLES:\n")
+	fmt.Fprintf(w, "  # Generate a weather report using default config\n")
+	fmt.Fprintf(w, "  %s\n\n", app)
+	fmt.Fprintf(w, "  # Use custom config file\n")
+	fmt.Fprintf(w, "  %s generate --config /path/to/custom.toml\n\n", app)
+	fmt.Fprintf(w, "  # Generate sample config file\n")
+	fmt.Fprintf(w, "  %s config init --config example.toml\n\n", app)
+	fmt.Fprintf(w, "  # Validate configuration without calling any API\n")
+	fmt.Fprintf(w, "  %s config validate\n\n", app)
+	fmt.Fprintf(w, "  # Verify API keys, subscriptions, model and voice IDs (nothing is generated)\n")
+	fmt.Fprintf(w, "  %s generate --check\n\n", app)
+	fmt.Fprintf(w, "  # Check each stage on its own\n")
+	fmt.Fprintf(w, "  %s weather\n", app)
+	fmt.Fprintf(w, "  %s script\n", app)
+	fmt.Fprintf(w, "  %s speak --text \"Testing, one two three\"\n\n", app)
+	fmt.Fprintf(w, "  # Preview which broadcast notes fire at a given time\n")
+	fmt.Fprintf(w, "  %s notes --at \"2025-12-24 07:30\" --all\n\n", app)
+
+	fmt.Fprintf(w, "CONFIGURATION:\n")
+	fmt.Fprintf(w, "  Configuration file should contain API keys for:\n")
+	fmt.Fprintf(w, "  - OpenWeather API (weather data)\n")
+	fmt.Fprintf(w, "  - Anthropic Claude API (text generation)\n")
+	fmt.Fprintf(w, "  - ElevenLabs API (text-to-speech)\n\n")
+
+	fmt.Fprintf(w, "  Use '%s config init' to create a sample configuration file.\n\n", app)
+
+	printExitCodes(w)
+
+	fmt.Fprintf(w, "OUTPUT:\n")
+	fmt.Fprintf(w, "  Generated audio files are saved to the configured import directory\n")
+	fmt.Fprintf(w, "  for use with Myriad radio automation software.\n\n")
+
+	fmt.Fprintf(w, "VERSION:\n")
+	fmt.Fprintf(w, "  %s version %s\n\n", AppName, Version)
+}
+
+// printExitCodes writes the EXIT CODES help section
+func printExitCodes(w io.Writer) {
+	fmt.Fprintf(w, "EXIT CODES:\n")
+	fmt.Fprintf(w, "  %d success, %d general error, %d config file error, %d config validation error,\n",
+		ExitSuccess, ExitGeneralError, ExitConfigError, ExitValidationError)
+	fmt.Fprintf(w, "  %d API error, %d file system error, %d network error, %d usage error\n",
+		ExitAPIError, ExitFileSystemError, ExitNetworkError, ExitUsageError)
+	fmt.Fprintf(w, "  A failed run handled by the [fallback] policy exits with %d when the last good\n", ExitFallbackKept)
+	fmt.Fprintf(w, "  report was kept, %d when the evergreen report is in place, %d when it was removed\n\n",
+		ExitFallbackEvergreen, ExitFallbackRemoved)
 }
 
 // contains checks if a slice contains a specific string
@@ -366,127 +240,6 @@ func validateWindowsPath(path string) error {
 	return nil
 }
 
-// runWeatherReportWorkflow orchestrates the complete weather report generation process
-func runWeatherReportWorkflow(cfg *config.Config) (string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
-	defer cancel()
-
-	logger.Debug("Starting weather report generation workflow")
-
-	// Step 1: Initialize API clients
-	logger.Debug("Initializing API clients...")
-
-	// Initialize Weather client
-	weatherClient := api.NewWeatherClientWithRateLimit(cfg.APIs.OpenWeather)
-	logger.Debug("Weather client initialized")
-
-	// Initialize Claude client
-	claudeConfig := api.ClaudeConfig{
-		APIKey:      cfg.APIs.Anthropic,
-		Model:       cfg.Claude.Model,
-		MaxTokens:   cfg.Claude.MaxTokens,
-		Temperature: cfg.Claude.Temperature,
-		MaxRetries:  cfg.Claude.MaxRetries,
-		BaseDelay:   time.Duration(cfg.Claude.BaseDelayMs) * time.Millisecond,
-		MaxDelay:    time.Duration(cfg.Claude.MaxDelayMs) * time.Millisecond,
-		RateLimit:   cfg.Claude.RateLimit,
-	}
-	claudeClient, err := api.NewClaudeClient(claudeConfig)
-	if err != nil {
-		return "", fmt.Errorf("failed to initialize Claude client: %w", err)
-	}
-	logger.Debug("Claude client initialized")
-
-	// Initialize ElevenLabs client
-	elevenLabsConfig := api.ElevenLabsConfig{
-		APIKey:     cfg.APIs.ElevenLabs,
-		VoiceID:    cfg.ElevenLabs.VoiceID,
-		Model:      cfg.ElevenLabs.Model,
-		Stability:  cfg.ElevenLabs.Stability,
-		Similarity: cfg.ElevenLabs.Similarity,
-		Style:      cfg.ElevenLabs.Style,
-		Speed:      cfg.ElevenLabs.Speed,
-		Format:     cfg.ElevenLabs.Format,
-		MaxRetries: cfg.ElevenLabs.MaxRetries,
-		BaseDelay:  time.Duration(cfg.ElevenLabs.BaseDelayMs) * time.Millisecond,
-		MaxDelay:   time.Duration(cfg.ElevenLabs.MaxDelayMs) * time.Millisecond,
-		RateLimit:  cfg.ElevenLabs.RateLimit,
-	}
-	elevenLabsClient, err := api.NewElevenLabsClient(elevenLabsConfig)
-	if err != nil {
-		return "", fmt.Errorf("failed to initialize ElevenLabs client: %w", err)
-	}
-	logger.Debug("ElevenLabs client initialized")
-
-	// Step 2: Initialize cache manager
-	cacheManager := api.NewCacheManager(cfg.Cache.FilePath)
-	logger.Debug("Cache manager initialized with file: %s", cfg.Cache.FilePath)
-
-	// Step 3: Fetch weather data using One Call API (with caching)
-	logger.Info("Fetching weather data using One Call API...")
-	forecastParams := api.ForecastParams{
-		Latitude:  cfg.Weather.Latitude,
-		Longitude: cfg.Weather.Longitude,
-		Units:     cfg.Weather.Units,
-	}
-
-	// Use the new One Call API with cache support
-	todayWeather, _, err := weatherClient.GetTodayWeatherWithOneCallCache(ctx, forecastParams, cfg.Weather.Units, cacheManager)
-	if err != nil {
-		return "", fmt.Errorf("failed to fetch weather data: %w", err)
-	}
-	logger.Debug("Weather data fetched successfully for location: %s", todayWeather.Location)
-	logger.Debug("Current conditions: %s, %.1f%s",
-		todayWeather.CurrentConditions, todayWeather.CurrentTemp,
-		api.GetUnitSuffix("temperature", cfg.Weather.Units))
-	logger.Debug("Daily forecast: High=%.1f%s, Low=%.1f%s (from One Call API)",
-		todayWeather.TempHigh, api.GetUnitSuffix("temperature", cfg.Weather.Units),
-		todayWeather.TempLow, api.GetUnitSuffix("temperature", cfg.Weather.Units))
-
-	// Step 4: Generate weather report script using Claude
-	logger.Info("Generating weather report script...")
-
-	reportRequest := api.WeatherReportRequest{
-		PromptTemplate: cfg.Prompt.Template,
-		TodayData:      todayWeather, // Direct use of One Call API extracted data
-		Location:       fmt.Sprintf("%.4f, %.4f", cfg.Weather.Latitude, cfg.Weather.Longitude),
-		OutputPath:     cfg.Output.ImportPath,
-	}
-
-	reportResponse, err := claudeClient.GenerateWeatherReport(ctx, reportRequest)
-	if err != nil {
-		return todayWeather.Location, fmt.Errorf("failed to generate weather report script: %w", err)
-	}
-	logger.Debug("Weather report script generated successfully (%d characters)", len(reportResponse.Script))
-
-	// Step 5: Convert script to speech using ElevenLabs
-	logger.Info("Converting script to speech...")
-
-	// Ensure import directory exists
-	if err := os.MkdirAll(cfg.Output.ImportPath, 0755); err != nil {
-		return todayWeather.Location, fmt.Errorf("failed to create import directory: %w", err)
-	}
-
-	speechRequest := api.TextToSpeechRequest{
-		Text:      reportResponse.Script,
-		OutputDir: cfg.Output.ImportPath, // Output directly to final location
-		FileName:  cfg.Output.MediaID,
-	}
-
-	speechResponse, err := elevenLabsClient.GenerateTextToSpeech(ctx, speechRequest)
-	if err != nil {
-		return todayWeather.Location, fmt.Errorf("failed to convert script to speech: %w", err)
-	}
-	logger.Debug("Speech generation completed successfully")
-	logger.Debug("Audio file created: %s (%d ms)", speechResponse.AudioFilePath, speechResponse.DurationMs)
-
-	// File is already saved to final location
-	logger.Debug("Weather report saved successfully: %s", speechResponse.AudioFilePath)
-	logger.Debug("Ready for import into Myriad radio automation")
-
-	return todayWeather.Location, nil
-}
-
 // Helper functions for error type checking
 func isAPIError(err error) bool {
 	return strings.Contains(err.Error(), "API") ||
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"myrcast/api"
+	"myrcast/config"
+	"myrcast/internal/logger"
+)
+
+// AIDEV-NOTE: The workflow hands data between stages through files so a run can
+// stop after the script (--script-only) or resume from an edited one (--from-script)
+// without paying for the stages it skips.
+
+// stageFiles are the handoff files written between workflow stages
+type stageFiles struct {
+	Weather string // Normalized weather data as JSON
+	Script  string // Generated script as plain text
+}
+
+// newStageFiles names the handoff files for a media ID in dir
+func newStageFiles(dir, mediaID string) stageFiles {
+	return stageFiles{
+		Weather: filepath.Join(dir, mediaID+".weather.json"),
+		Script:  filepath.Join(dir, mediaID+".script.txt"),
+	}
+}
+
+// writeWeatherStage saves the normalized weather data for later inspection
+func writeWeatherStage(path string, todayWeather *api.TodayWeatherData) error {
+	data, err := json.MarshalIndent(todayWeather, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode weather stage file: %w", err)
+	}
+	return writeStageFile(path, append(data, '\n'))
+}
+
+// readWeatherStage loads the saved weather data, or returns nil if there is none
+func readWeatherStage(path string) *api.TodayWeatherData {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var todayWeather api.TodayWeatherData
+	if err := json.Unmarshal(data, &todayWeather); err != nil {
+		return nil
+	}
+	return &todayWeather
+}
+
+// resumeWeather returns the saved weather for a --from-script run, or nil when it
+// does not belong to the script or is out of date: the script must be this media
+// ID's stage script, the weather stage must be written before it (editing only
+// makes the script newer), and a report made from that weather must still be
+// valid now. A stale forecast would otherwise reach the sidecar and the filename.
+func resumeWeather(cfg *config.Config, stage stageFiles, scriptPath string, now time.Time) *api.TodayWeatherData {
+	weatherInfo, err := os.Stat(stage.Weather)
+	if err != nil {
+		return nil
+	}
+	scriptInfo, err := os.Stat(scriptPath)
+	if err != nil {
+		return nil
+	}
+	stageScript, err := os.Stat(stage.Script)
+	if err != nil || !os.SameFile(scriptInfo, stageScript) {
+		logger.Info("Leaving out the saved weather: %s is not the stage script it was written with", scriptPath)
+		return nil
+	}
+	if weatherInfo.ModTime().After(scriptInfo.ModTime()) {
+		logger.Info("Leaving out the saved weather: %s is newer than the script", stage.Weather)
+		return nil
+	}
+
+	todayWeather := readWeatherStage(stage.Weather)
+	if todayWeather == nil {
+		return nil
+	}
+	if validUntil := reportValidUntil(cfg, todayWeather.LocalTime(todayWeather.LastUpdated)); !now.Before(validUntil) {
+		logger.Info("Leaving out the saved weather: it is from %s, and reports made from it expired at %s",
+			todayWeather.LastUpdated.Format(time.RFC3339), validUntil.Format(time.RFC3339))
+		return nil
+	}
+	return todayWeather
+}
+
+// writeScriptStage saves the script so it can be edited and resynthesized
+func writeScriptStage(path, script string) error {
+	return writeStageFile(path, []byte(strings.TrimSpace(script)+"\n"))
+}
+
+// readScriptStage loads an edited script for synthesis
+func readScriptStage(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read script file: %w", err)
+	}
+	script := strings.TrimSpace(string(data))
+	if script == "" {
+		return "", fmt.Errorf("script file is empty: %s", path)
+	}
+	return script, nil
+}
+
+// writeStageFile writes a handoff file, creating the stage directory as needed
+func writeStageFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create stage directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write stage file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"myrcast/api"
+	"myrcast/config"
+)
+
+// TestResumeWeather tests that --from-script runs only use saved weather that
+// belongs to the script and is still current
+func TestResumeWeather(t *testing.T) {
+	now := time.Date(2026, 3, 14, 9, 0, 0, 0, time.UTC)
+	cfg := &config.Config{Expiry: config.Expiry{LeadMinutes: 30}}
+
+	tests := []struct {
+		name        string
+		fetched     time.Time     // Weather LastUpdated
+		weatherAge  time.Duration // How long before now the weather stage was written
+		scriptAge   time.Duration // How long before now the script was last written
+		otherScript bool          // Resume from a copy outside the stage directory
+		noWeather   bool
+		want        bool
+	}{
+		{name: "same run", fetched: now.Add(-10 * time.Minute), weatherAge: 10 * time.Minute, scriptAge: 9 * time.Minute, want: true},
+		{name: "script edited later", fetched: now.Add(-time.Hour), weatherAge: time.Hour, scriptAge: time.Minute, want: true},
+		{name: "weather from a later run", fetched: now.Add(-5 * time.Minute), weatherAge: 5 * time.Minute, scriptAge: time.Hour},
+		{name: "weather from an earlier daypart", fetched: now.Add(-20 * time.Hour), weatherAge: 20 * time.Hour, scriptAge: 20 * time.Hour},
+		{name: "script outside the stage directory", fetched: now.Add(-10 * time.Minute), weatherAge: 10 * time.Minute, scriptAge: 9 * time.Minute, otherScript: true},
+		{name: "no saved weather", scriptAge: time.Minute, noWeather: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stage := newStageFiles(t.TempDir(), "weather_report")
+			if !tt.noWeather {
+				if err := writeWeatherStage(stage.Weather, &api.TodayWeatherData{Location: "Hilo", LastUpdated: tt.fetched}); err != nil {
+					t.Fatalf("Failed to write weather stage: %v", err)
+				}
+				touch(t, stage.Weather, now.Add(-tt.weatherAge))
+			}
+			if err := writeScriptStage(stage.Script, "Good morning, Hilo."); err != nil {
+				t.Fatalf("Failed to write script stage: %v", err)
+			}
+			touch(t, stage.Script, now.Add(-tt.scriptAge))
+			scriptPath := stage.Script
+			if tt.otherScript {
+				scriptPath = filepath.Join(t.TempDir(), "edited.txt")
+				if err := writeScriptStage(scriptPath, "Good morning, Hilo."); err != nil {
+					t.Fatalf("Failed to write script: %v", err)
+				}
+				touch(t, scriptPath, now.Add(-tt.scriptAge))
+			}
+
+			got := resumeWeather(cfg, stage, scriptPath, now)
+			if (got != nil) != tt.want {
+				t.Errorf("Expected saved weather used: %v, got %+v", tt.want, got)
+			}
+			if got != nil && got.Location != "Hilo" {
+				t.Errorf("Expected the saved location, got %q", got.Location)
+			}
+		})
+	}
+}
+
+// touch sets a file's modification time
+func touch(t *testing.T, path string, modTime time.Time) {
+	t.Helper()
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("Failed to set file time: %v", err)
+	}
+}
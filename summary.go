@@ -0,0 +1,147 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"myrcast/config"
+	"myrcast/internal/delivery"
+	"myrcast/internal/logger"
+	"myrcast/internal/metrics"
+	"myrcast/internal/runstats"
+)
+
+// Output formats for the generate command's result
+const (
+	outputText = "text" // Log lines only (default)
+	outputJSON = "json" // One JSON result object on stdout; logs go to stderr
+)
+
+// outputFormats lists the accepted --output values
+var outputFormats = []string{outputText, outputJSON}
+
+// stageTiming is how long one workflow stage took
+type stageTiming struct {
+	Name       string `json:"name"`
+	DurationMs int64  `json:"duration_ms"`
+	Status     string `json:"status"` // ok or failed
+}
+
+// tokenUsage is the Claude tokens a run used
+type tokenUsage struct {
+	Input  int `json:"input"`
+	Output int `json:"output"`
+}
+
+// runSummary is the machine-readable result of a generate run, printed by
+// --output json so schedulers parse one document instead of log text
+type runSummary struct {
+	Status          string                    `json:"status"` // success or failure
+	RunID           string                    `json:"run_id"`
+	ExitCode        int                       `json:"exit_code"`
+	Mode            string                    `json:"mode"`
+	ConfigFile      string                    `json:"config_file"`
+	StartTime       time.Time                 `json:"start_time"`
+	DurationMs      int64                     `json:"duration_ms"`
+	Stages          []stageTiming             `json:"stages"`
+	Location        string                    `json:"location,omitempty"`
+	Weather         *delivery.WeatherSnapshot `json:"weather,omitempty"`
+	Alerts          []string                  `json:"alerts,omitempty"`
+	Script          string                    `json:"script,omitempty"`
+	AudioFile       string                    `json:"audio_file,omitempty"`
+	AudioDurationMs int64                     `json:"audio_duration_ms,omitempty"`
+	Destination     string                    `json:"destination,omitempty"`
+	Tokens          tokenUsage                `json:"tokens"`
+	Retries         map[string]int            `json:"retries"`
+	Errors          []string                  `json:"errors"`
+	Results         []string                  `json:"results"`
+}
+
+// newRunSummary describes a finished run from its workflow result and the
+// lines of the execution summary. result may be nil when the run stopped
+// before the workflow started.
+func newRunSummary(startTime time.Time, configFile string, result *workflowResult, results []string, exitCode int, runErr error) runSummary {
+	stats := runstats.Snapshot()
+	summary := runSummary{
+		Status:     "success",
+		RunID:      logger.RunID(),
+		ExitCode:   exitCode,
+		Mode:       "weather-report",
+		ConfigFile: configFile,
+		StartTime:  startTime,
+		DurationMs: time.Since(startTime).Milliseconds(),
+		Stages:     []stageTiming{},
+		Tokens:     tokenUsage{Input: stats.InputTokens, Output: stats.OutputTokens},
+		Retries:    stats.Retries,
+		Errors:     []string{},
+		Results:    results,
+	}
+	if runErr != nil {
+		summary.Status = "failure"
+		summary.Errors = append(summary.Errors, logger.Redact(runErr.Error()))
+	}
+	if result != nil {
+		summary.Stages = append(summary.Stages, result.Stages...)
+		summary.Location = result.Location
+		summary.Weather = result.Weather
+		summary.Alerts = result.Alerts
+		summary.Script = result.Script
+		summary.AudioFile = result.AudioFile
+		summary.AudioDurationMs = result.AudioDuration.Milliseconds()
+		summary.Destination = result.Destination
+	}
+	return summary
+}
+
+// printRunSummary writes the summary as a single JSON document
+func printRunSummary(w io.Writer, summary runSummary) error {
+	data, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode run result: %w", err)
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+// writeMetrics writes the [metrics] textfile for a finished run. result may be
+// nil when the run stopped before the workflow started. Failures are logged and
+// never change the exit code.
+func writeMetrics(cfg *config.Config, startTime time.Time, result *workflowResult, exitCode int, runErr error) {
+	if cfg.Metrics.Textfile == "" {
+		return
+	}
+
+	stats := runstats.Snapshot()
+	run := metrics.Run{
+		Profile:      cfg.Output.Profile,
+		Finished:     time.Now(),
+		Success:      runErr == nil,
+		ExitCode:     exitCode,
+		Duration:     time.Since(startTime),
+		Retries:      stats.Retries,
+		HTTPStatus:   stats.HTTPStatus,
+		InputTokens:  stats.InputTokens,
+		OutputTokens: stats.OutputTokens,
+		Characters:   stats.Characters,
+		CacheHits:    stats.CacheHits,
+		CacheMisses:  stats.CacheMisses,
+	}
+	if result != nil {
+		for _, stage := range result.Stages {
+			run.Stages = append(run.Stages, metrics.Stage{
+				Name:     stage.Name,
+				Duration: time.Duration(stage.DurationMs) * time.Millisecond,
+				Status:   stage.Status,
+			})
+		}
+		run.AudioDuration = result.AudioDuration
+	}
+
+	if err := metrics.Write(cfg.Metrics.Textfile, run); err != nil {
+		logger.Warn("Failed to write metrics: %v", err)
+		return
+	}
+	logger.Debug("Wrote metrics to %s", cfg.Metrics.Textfile)
+}
@@ -0,0 +1,549 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"myrcast/api"
+	"myrcast/config"
+	"myrcast/internal/logger"
+)
+
+// AIDEV-NOTE: The wizard checks each answer against the live services as it goes
+// (a bad key is caught at the prompt, not on the first scheduled run) but always
+// lets the engineer keep an answer that cannot be verified, since new OpenWeather
+// keys take hours to activate and studio PCs may be offline during setup.
+
+// errInputClosed reports that stdin ended before the wizard finished
+var errInputClosed = errors.New("input ended before the wizard finished")
+
+// wizardTimeout bounds each live check so a dead network does not hang the prompt
+const wizardTimeout = 20 * time.Second
+
+// wizardServices are the live lookups behind the wizard's questions
+type wizardServices struct {
+	searchLocations func(ctx context.Context, key, query string, limit int) ([]api.GeocodingResponse, error)
+	checkAnthropic  func(ctx context.Context, key string) error
+	listVoices      func(ctx context.Context, key string) ([]api.Voice, error)
+	importFolders   func() []string
+}
+
+// wizardLive is what the wizard checks answers against; tests replace it with stubs
+var wizardLive = wizardServices{
+	searchLocations: func(ctx context.Context, key, query string, limit int) ([]api.GeocodingResponse, error) {
+		return api.NewWeatherClient(key).SearchLocations(ctx, query, limit)
+	},
+	checkAnthropic: func(ctx context.Context, key string) error {
+		client, err := api.NewClaudeClient(api.ClaudeConfig{APIKey: key})
+		if err != nil {
+			return err
+		}
+		for _, result := range client.CheckModel(ctx) {
+			if result.Status == api.CheckFail {
+				return fmt.Errorf("%s: %s", result.Check, result.Detail)
+			}
+		}
+		return nil
+	},
+	listVoices: func(ctx context.Context, key string) ([]api.Voice, error) {
+		client, err := api.NewElevenLabsClient(api.ElevenLabsConfig{APIKey: key})
+		if err != nil {
+			return nil, err
+		}
+		return client.ListVoices(ctx)
+	},
+	importFolders: detectImportFolders,
+}
+
+// prompter reads answers to wizard questions
+type prompter struct {
+	in  *bufio.Scanner
+	out io.Writer
+}
+
+// ask prints a question with its default and returns the trimmed answer
+func (p *prompter) ask(question, defaultValue string) (string, error) {
+	if defaultValue != "" {
+		fmt.Fprintf(p.out, "%s [%s]: ", question, defaultValue)
+	} else {
+		fmt.Fprintf(p.out, "%s: ", question)
+	}
+	if !p.in.Scan() {
+		fmt.Fprintln(p.out)
+		if err := p.in.Err(); err != nil {
+			return "", err
+		}
+		return "", errInputClosed
+	}
+	answer := strings.TrimSpace(p.in.Text())
+	if answer == "" {
+		return defaultValue, nil
+	}
+	return answer, nil
+}
+
+// askValid repeats a question until validate accepts the answer
+func (p *prompter) askValid(question, defaultValue string, validate func(string) error) (string, error) {
+	for {
+		answer, err := p.ask(question, defaultValue)
+		if err != nil {
+			return "", err
+		}
+		if err := validate(answer); err != nil {
+			fmt.Fprintf(p.out, "  %v\n", err)
+			continue
+		}
+		return answer, nil
+	}
+}
+
+// choose lists numbered options and returns the index picked (defaultIndex on Enter)
+func (p *prompter) choose(question string, options []string, defaultIndex int) (int, error) {
+	fmt.Fprintln(p.out, question)
+	for i, option := range options {
+		fmt.Fprintf(p.out, "  %2d) %s\n", i+1, option)
+	}
+	answer, err := p.askValid("Choose a number", strconv.Itoa(defaultIndex+1), func(answer string) error {
+		n, err := strconv.Atoi(answer)
+		if err != nil || n < 1 || n > len(options) {
+			return fmt.Errorf("enter a number from 1 to %d", len(options))
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	n, _ := strconv.Atoi(answer)
+	return n - 1, nil
+}
+
+// confirm asks a yes/no question
+func (p *prompter) confirm(question string, defaultYes bool) (bool, error) {
+	defaultValue := "y/N"
+	if defaultYes {
+		defaultValue = "Y/n"
+	}
+	answer, err := p.askValid(question, defaultValue, func(answer string) error {
+		switch strings.ToLower(answer) {
+		case "y", "yes", "n", "no", "y/n":
+			return nil
+		}
+		return fmt.Errorf("answer y or n")
+	})
+	if err != nil {
+		return false, err
+	}
+	switch strings.ToLower(answer) {
+	case "y", "yes":
+		return true, nil
+	case "n", "no":
+		return false, nil
+	}
+	return defaultYes, nil
+}
+
+// runConfigWizard asks for each station setting, checking answers against the live
+// APIs, and writes a commented configuration file
+func runConfigWizard(configPath string, in io.Reader, out io.Writer) int {
+	// Keep API client logging out of the conversation and off disk; failures are
+	// shown inline
+	if err := logger.Initialize(logger.Config{Level: "fatal", ConsoleStderr: true}); err != nil {
+		printWarning("Failed to initialize logging: %v", err)
+	}
+
+	p := &prompter{in: bufio.NewScanner(in), out: out}
+	settings := config.DefaultSampleSettings()
+	configDir := filepath.Dir(configPath)
+
+	fmt.Fprintf(out, "Myrcast setup - writes %s\n", configPath)
+	fmt.Fprintln(out, "Press Enter to accept the value in brackets. API keys can be pasted directly,")
+	fmt.Fprintln(out, "or given as env:VAR_NAME or file:/path/to/key.txt to keep them out of the file.")
+
+	steps := []func(*prompter, *config.SampleSettings, string) error{
+		wizardOpenWeather,
+		wizardAnthropic,
+		wizardElevenLabs,
+		wizardPrompt,
+		wizardOutput,
+	}
+	for _, step := range steps {
+		fmt.Fprintln(out)
+		if err := step(p, &settings, configDir); err != nil {
+			printError("%v", err)
+			return ExitGeneralError
+		}
+	}
+
+	if err := config.WriteSampleConfig(configPath, settings); err != nil {
+		printError("%v", err)
+		return ExitFileSystemError
+	}
+
+	fmt.Fprintf(out, "\nConfiguration written to: %s\n", configPath)
+	cfg, err := config.LoadConfig(configPath)
+	if err == nil {
+		err = cfg.Validate()
+	}
+	if err != nil {
+		fmt.Fprintf(out, "The file needs attention before the first run: %v\n", err)
+		fmt.Fprintf(out, "Edit it, then run '%s config validate --config %s'\n", appCommand(), configPath)
+		return ExitValidationError
+	}
+	fmt.Fprintf(out, "Next: run '%s --check --config %s' to verify everything end to end,\n", appCommand(), configPath)
+	fmt.Fprintf(out, "then '%s --config %s' to produce the first report.\n", appCommand(), configPath)
+	return ExitSuccess
+}
+
+// askAPIKey asks for a key or reference, retrying until check accepts it or the
+// engineer chooses to keep an unverified key. It returns the answer as typed
+// (so references stay references) and whether the key was verified.
+func askAPIKey(p *prompter, question, configDir string, check func(key string) error) (string, bool, error) {
+	for {
+		answer, err := p.askValid(question, "", func(answer string) error {
+			if answer == "" {
+				return fmt.Errorf("a key is required")
+			}
+			return nil
+		})
+		if err != nil {
+			return "", false, err
+		}
+
+		key, source := config.ResolveKeyReference(answer, configDir)
+		if source.Problem != "" {
+			fmt.Fprintf(p.out, "  %s: %s\n", source.Origin, source.Problem)
+			continue
+		}
+
+		fmt.Fprintln(p.out, "  Checking...")
+		checkErr := check(key)
+		if checkErr == nil {
+			fmt.Fprintln(p.out, "  OK")
+			return answer, true, nil
+		}
+		fmt.Fprintf(p.out, "  %s\n", wizardErrorDetail(checkErr))
+		keep, err := p.confirm("  Keep this key anyway?", false)
+		if err != nil {
+			return "", false, err
+		}
+		if keep {
+			return answer, false, nil
+		}
+	}
+}
+
+// wizardOpenWeather asks for the OpenWeather key, looks up the station location by
+// name and picks units and the holiday calendar from its country
+func wizardOpenWeather(p *prompter, settings *config.SampleSettings, configDir string) error {
+	fmt.Fprintln(p.out, "OpenWeather (weather data) - get a key at https://openweathermap.org/api")
+
+	var weatherKey string
+	answer, verified, err := askAPIKey(p, "OpenWeather API key", configDir, func(key string) error {
+		weatherKey = key
+		ctx, cancel := context.WithTimeout(context.Background(), wizardTimeout)
+		defer cancel()
+		_, err := wizardLive.searchLocations(ctx, key, "London", 1)
+		if openWeatherUnauthorized(err) {
+			return fmt.Errorf("OpenWeather rejected the key (new keys can take up to two hours to activate)")
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	settings.OpenWeatherKey = answer
+
+	country := ""
+	for {
+		query, err := p.askValid("Station city, or latitude,longitude (e.g. \"Springfield, IL, US\")", "", func(answer string) error {
+			if answer == "" {
+				return fmt.Errorf("a location is required")
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if lat, lon, ok := parseCoordinates(query); ok {
+			settings.Latitude, settings.Longitude = lat, lon
+			settings.LocationName = query
+			break
+		}
+		if !verified {
+			fmt.Fprintln(p.out, "  Place names need a working OpenWeather key; enter latitude,longitude instead")
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), wizardTimeout)
+		matches, err := wizardLive.searchLocations(ctx, weatherKey, query, 5)
+		cancel()
+		if err != nil {
+			fmt.Fprintf(p.out, "  Location lookup failed: %s\n", wizardErrorDetail(err))
+			continue
+		}
+		if len(matches) == 0 {
+			fmt.Fprintln(p.out, "  No places found; try adding the state or country code, or enter latitude,longitude")
+			continue
+		}
+
+		options := make([]string, len(matches))
+		for i, match := range matches {
+			options[i] = fmt.Sprintf("%s (%.4f, %.4f)", placeName(match), match.Lat, match.Lon)
+		}
+		pick, err := p.choose("Which one?", options, 0)
+		if err != nil {
+			return err
+		}
+		settings.Latitude, settings.Longitude = matches[pick].Lat, matches[pick].Lon
+		settings.LocationName = placeName(matches[pick])
+		country = matches[pick].Country
+		break
+	}
+
+	unitOptions := []string{"imperial", "metric"}
+	defaultUnits := 1
+	if country == "" || country == "US" || country == "LR" || country == "MM" {
+		defaultUnits = 0
+	}
+	pick, err := p.choose("Temperature units", []string{"imperial (°F, mph)", "metric (°C, m/s)"}, defaultUnits)
+	if err != nil {
+		return err
+	}
+	settings.Units = unitOptions[pick]
+
+	switch country {
+	case "CA", "AU", "US":
+		settings.Country = country
+	case "GB":
+		settings.Country = "UK"
+	}
+	return nil
+}
+
+// wizardAnthropic asks for the Claude API key and checks it on the models endpoint
+func wizardAnthropic(p *prompter, settings *config.SampleSettings, configDir string) error {
+	fmt.Fprintln(p.out, "Anthropic Claude (script writing) - get a key at https://console.anthropic.com/")
+
+	answer, _, err := askAPIKey(p, "Anthropic API key", configDir, func(key string) error {
+		ctx, cancel := context.WithTimeout(context.Background(), wizardTimeout)
+		defer cancel()
+		return wizardLive.checkAnthropic(ctx, key)
+	})
+	if err != nil {
+		return err
+	}
+	settings.AnthropicKey = answer
+	return nil
+}
+
+// wizardElevenLabs asks for the ElevenLabs key and offers the account's voices
+func wizardElevenLabs(p *prompter, settings *config.SampleSettings, configDir string) error {
+	fmt.Fprintln(p.out, "ElevenLabs (text-to-speech) - get a key at https://elevenlabs.io/")
+
+	var voices []api.Voice
+	answer, verified, err := askAPIKey(p, "ElevenLabs API key", configDir, func(key string) error {
+		ctx, cancel := context.WithTimeout(context.Background(), wizardTimeout)
+		defer cancel()
+		var err error
+		voices, err = wizardLive.listVoices(ctx, key)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	settings.ElevenLabsKey = answer
+
+	if !verified || len(voices) == 0 {
+		voiceID, err := p.ask("Voice ID", settings.VoiceID)
+		if err != nil {
+			return err
+		}
+		settings.VoiceID = voiceID
+		return nil
+	}
+
+	sort.Slice(voices, func(i, j int) bool {
+		return strings.ToLower(voices[i].Name) < strings.ToLower(voices[j].Name)
+	})
+	options := make([]string, len(voices))
+	defaultIndex := 0
+	for i, voice := range voices {
+		options[i] = voice.Name
+		if voice.Category != "" {
+			options[i] += " (" + voice.Category + ")"
+		}
+		if voice.ID == settings.VoiceID {
+			defaultIndex = i
+		}
+	}
+	pick, err := p.choose("Voice for the reports (audition them at https://elevenlabs.io/app/voice-lab)", options, defaultIndex)
+	if err != nil {
+		return err
+	}
+	settings.VoiceID = voices[pick].ID
+	settings.VoiceName = voices[pick].Name
+	return nil
+}
+
+// wizardPrompt suggests a report style for the daypart the report airs in
+func wizardPrompt(p *prompter, settings *config.SampleSettings, _ string) error {
+	options := make([]string, len(config.PromptPresets))
+	for i, preset := range config.PromptPresets {
+		options[i] = preset.Name
+	}
+	pick, err := p.choose("When will the report air? (sets the style; edit [prompt] template later to fine-tune)", options, 0)
+	if err != nil {
+		return err
+	}
+	settings.PromptTemplate = config.PromptPresets[pick].Template
+	return nil
+}
+
+// wizardOutput asks where the automation system imports audio and the file name
+func wizardOutput(p *prompter, settings *config.SampleSettings, _ string) error {
+	candidates := wizardLive.importFolders()
+	defaultPath := ""
+	if len(candidates) > 0 {
+		defaultPath = candidates[0]
+		fmt.Fprintf(p.out, "Found likely import folders: %s\n", strings.Join(candidates, ", "))
+	}
+
+	importPath, err := p.askValid("Folder your automation system imports audio from", defaultPath, func(answer string) error {
+		if answer == "" {
+			return fmt.Errorf("an import folder is required")
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := checkImportFolder(p, importPath); err != nil {
+		return err
+	}
+	settings.ImportPath = importPath
+
+	mediaID, err := p.askValid("Audio file name, without extension (the cart/media ID your log expects)", settings.MediaID, func(answer string) error {
+		if answer == "" || strings.ContainsAny(answer, `/\:*?"<>|`) {
+			return fmt.Errorf("use letters, numbers, dashes and underscores")
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	settings.MediaID = mediaID
+	return nil
+}
+
+// checkImportFolder confirms the folder is writable, offering to create it
+func checkImportFolder(p *prompter, dir string) error {
+	info, err := os.Stat(dir)
+	if os.IsNotExist(err) {
+		create, err := p.confirm(fmt.Sprintf("  %s does not exist. Create it?", dir), true)
+		if err != nil || !create {
+			return err
+		}
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			fmt.Fprintf(p.out, "  Could not create it (%v); create it before the first run\n", err)
+			return nil
+		}
+		fmt.Fprintln(p.out, "  Created")
+		return nil
+	}
+	if err != nil || !info.IsDir() {
+		fmt.Fprintf(p.out, "  %s is not a folder; fix import_path before the first run\n", dir)
+		return nil
+	}
+
+	probe, err := os.CreateTemp(dir, ".myrcast-write-test-*")
+	if err != nil {
+		fmt.Fprintf(p.out, "  Myrcast cannot write to %s (%v); check its permissions\n", dir, err)
+		return nil
+	}
+	probe.Close()
+	os.Remove(probe.Name())
+	return nil
+}
+
+// detectImportFolders lists existing folders that look like automation imports
+func detectImportFolders() []string {
+	var candidates []string
+	if runtime.GOOS == "windows" {
+		for _, drive := range []string{`C:\`, `D:\`} {
+			candidates = append(candidates,
+				filepath.Join(drive, "Myriad", "Import"),
+				filepath.Join(drive, "PSquared", "Myriad", "Import"),
+				filepath.Join(drive, "RadioDJ", "Import"),
+			)
+		}
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		candidates = append(candidates,
+			filepath.Join(home, "Myriad", "Import"),
+			filepath.Join(home, "Documents", "Myriad", "Import"),
+			filepath.Join(home, "Documents", "Myrcast"),
+		)
+	}
+
+	var found []string
+	for _, candidate := range candidates {
+		if info, err := os.Stat(candidate); err == nil && info.IsDir() {
+			found = append(found, candidate)
+		}
+	}
+	return found
+}
+
+// parseCoordinates reads "lat,lon" answers
+func parseCoordinates(answer string) (float64, float64, bool) {
+	parts := strings.Split(answer, ",")
+	if len(parts) != 2 {
+		return 0, 0, false
+	}
+	lat, err1 := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	lon, err2 := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err1 != nil || err2 != nil || lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+		return 0, 0, false
+	}
+	return lat, lon, true
+}
+
+// placeName formats a geocoding match as "City, State, Country"
+func placeName(match api.GeocodingResponse) string {
+	parts := []string{match.Name}
+	if match.State != "" {
+		parts = append(parts, match.State)
+	}
+	if match.Country != "" {
+		parts = append(parts, match.Country)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// wizardErrorDetail drops request URLs from transport errors, since OpenWeather
+// URLs carry the key as a query parameter
+func wizardErrorDetail(err error) string {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err.Error()
+	}
+	return err.Error()
+}
+
+// openWeatherUnauthorized reports whether OpenWeather rejected the API key
+func openWeatherUnauthorized(err error) bool {
+	var apiErr *api.OpenWeatherAPIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == 401
+}
@@ -0,0 +1,382 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"myrcast/api"
+)
+
+// newTestPrompter answers questions from input, one line per answer
+func newTestPrompter(input string) (*prompter, *strings.Builder) {
+	var out strings.Builder
+	return &prompter{in: bufio.NewScanner(strings.NewReader(input)), out: &out}, &out
+}
+
+// stubWizard replaces the live checks for the length of the test
+func stubWizard(t *testing.T, services wizardServices) {
+	t.Helper()
+	previous := wizardLive
+	wizardLive = services
+	t.Cleanup(func() { wizardLive = previous })
+}
+
+// TestPrompter tests the ask, choose and confirm answers, defaults and retries
+func TestPrompter(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		run        func(p *prompter) (any, error)
+		want       any
+		wantErr    error
+		wantOutput string
+	}{
+		{
+			name:  "ask returns the trimmed answer",
+			input: "  Hilo  \n",
+			run:   func(p *prompter) (any, error) { return p.ask("City", "Honolulu") },
+			want:  "Hilo",
+		},
+		{
+			name:       "ask returns the default on Enter",
+			input:      "\n",
+			run:        func(p *prompter) (any, error) { return p.ask("City", "Honolulu") },
+			want:       "Honolulu",
+			wantOutput: "City [Honolulu]: ",
+		},
+		{
+			name:    "ask reports closed input",
+			input:   "",
+			run:     func(p *prompter) (any, error) { return p.ask("City", "") },
+			wantErr: errInputClosed,
+		},
+		{
+			name:       "choose repeats until the number is in range",
+			input:      "0\nthree\n3\n",
+			run:        func(p *prompter) (any, error) { return p.choose("Voice", []string{"Adam", "Bella", "Charlie"}, 0) },
+			want:       2,
+			wantOutput: "enter a number from 1 to 3",
+		},
+		{
+			name:  "choose returns the default on Enter",
+			input: "\n",
+			run:   func(p *prompter) (any, error) { return p.choose("Voice", []string{"Adam", "Bella"}, 1) },
+			want:  1,
+		},
+		{
+			name:       "confirm repeats until y or n",
+			input:      "maybe\nYES\n",
+			run:        func(p *prompter) (any, error) { return p.confirm("Keep it?", false) },
+			want:       true,
+			wantOutput: "answer y or n",
+		},
+		{
+			name:  "confirm returns the default on Enter",
+			input: "\n",
+			run:   func(p *prompter) (any, error) { return p.confirm("Create it?", true) },
+			want:  true,
+		},
+		{
+			name:  "confirm no",
+			input: "n\n",
+			run:   func(p *prompter) (any, error) { return p.confirm("Create it?", true) },
+			want:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, out := newTestPrompter(tt.input)
+			got, err := tt.run(p)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+			if !strings.Contains(out.String(), tt.wantOutput) {
+				t.Errorf("Expected output containing %q, got:\n%s", tt.wantOutput, out.String())
+			}
+		})
+	}
+}
+
+// TestParseCoordinates tests "lat,lon" answers
+func TestParseCoordinates(t *testing.T) {
+	tests := []struct {
+		answer string
+		lat    float64
+		lon    float64
+		wantOK bool
+	}{
+		{answer: "19.7297,-155.09", lat: 19.7297, lon: -155.09, wantOK: true},
+		{answer: " 21.3 , -157.8 ", lat: 21.3, lon: -157.8, wantOK: true},
+		{answer: "Springfield, IL"},
+		{answer: "91,0"},
+		{answer: "0,-181"},
+		{answer: "1,2,3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.answer, func(t *testing.T) {
+			lat, lon, ok := parseCoordinates(tt.answer)
+			if ok != tt.wantOK || lat != tt.lat || lon != tt.lon {
+				t.Errorf("parseCoordinates(%q) = %v, %v, %v; want %v, %v, %v", tt.answer, lat, lon, ok, tt.lat, tt.lon, tt.wantOK)
+			}
+		})
+	}
+}
+
+// TestAskAPIKey tests retrying a rejected key and keeping one anyway
+func TestAskAPIKey(t *testing.T) {
+	rejectAll := func(key string) error { return errors.New("key rejected") }
+
+	tests := []struct {
+		name         string
+		input        string
+		check        func(key string) error
+		wantAnswer   string
+		wantVerified bool
+		wantChecks   int
+	}{
+		{
+			name:         "accepted",
+			input:        "good-key\n",
+			check:        func(key string) error { return nil },
+			wantAnswer:   "good-key",
+			wantVerified: true,
+			wantChecks:   1,
+		},
+		{
+			name:       "rejected, then kept anyway",
+			input:      "\nbad-key\nn\nnew-key\ny\n",
+			check:      rejectAll,
+			wantAnswer: "new-key",
+			wantChecks: 2,
+		},
+		{
+			name:         "rejected, then a good key",
+			input:        "bad-key\n\nsecond-key\n",
+			check:        func(key string) error { return map[string]error{"bad-key": errors.New("no")}[key] },
+			wantAnswer:   "second-key",
+			wantVerified: true,
+			wantChecks:   2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, out := newTestPrompter(tt.input)
+			checks := 0
+			answer, verified, err := askAPIKey(p, "API key", t.TempDir(), func(key string) error {
+				checks++
+				return tt.check(key)
+			})
+			if err != nil {
+				t.Fatalf("askAPIKey failed: %v\n%s", err, out.String())
+			}
+			if answer != tt.wantAnswer || verified != tt.wantVerified || checks != tt.wantChecks {
+				t.Errorf("Got answer %q verified %v after %d checks; want %q, %v, %d\n%s",
+					answer, verified, checks, tt.wantAnswer, tt.wantVerified, tt.wantChecks, out.String())
+			}
+		})
+	}
+}
+
+// TestCheckImportFolder tests the existing, missing and invalid folder answers
+func TestCheckImportFolder(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "not-a-folder.txt")
+	if err := os.WriteFile(file, []byte("x"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		path        string
+		input       string
+		wantOutput  string
+		wantCreated bool
+	}{
+		{name: "existing folder", path: dir},
+		{name: "missing folder created", path: filepath.Join(dir, "import"), input: "\n", wantOutput: "Created", wantCreated: true},
+		{name: "missing folder declined", path: filepath.Join(dir, "declined"), input: "n\n", wantOutput: "does not exist"},
+		{name: "file instead of folder", path: file, wantOutput: "is not a folder"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, out := newTestPrompter(tt.input)
+			if err := checkImportFolder(p, tt.path); err != nil {
+				t.Fatalf("checkImportFolder failed: %v", err)
+			}
+			if !strings.Contains(out.String(), tt.wantOutput) {
+				t.Errorf("Expected output containing %q, got:\n%s", tt.wantOutput, out.String())
+			}
+			if tt.wantCreated {
+				if info, err := os.Stat(tt.path); err != nil || !info.IsDir() {
+					t.Errorf("Expected %s to be created", tt.path)
+				}
+			}
+		})
+	}
+}
+
+// TestRunConfigWizard drives the whole wizard with stubbed live checks
+func TestRunConfigWizard(t *testing.T) {
+	springfields := []api.GeocodingResponse{
+		{Name: "Springfield", State: "Illinois", Country: "US", Lat: 39.7817, Lon: -89.6501},
+		{Name: "Springfield", State: "Missouri", Country: "US", Lat: 37.2090, Lon: -93.2923},
+	}
+	voices := []api.Voice{{ID: "voice-bella", Name: "Bella", Category: "premade"}, {ID: "voice-adam", Name: "Adam"}}
+	unauthorized := &api.OpenWeatherAPIError{StatusCode: 401, Message: "Invalid API key"}
+	offline := errors.New("dial tcp: no such host")
+
+	tests := []struct {
+		name         string
+		services     wizardServices
+		answers      []string
+		wantCode     int
+		wantConfig   []string
+		wantOutput   string
+		wantError    string
+		importFolder bool // Answer with a folder that does not exist yet
+	}{
+		{
+			name: "verified keys and place search",
+			services: wizardServices{
+				searchLocations: func(ctx context.Context, key, query string, limit int) ([]api.GeocodingResponse, error) {
+					return springfields, nil
+				},
+				checkAnthropic: func(ctx context.Context, key string) error { return nil },
+				listVoices:     func(ctx context.Context, key string) ([]api.Voice, error) { return voices, nil },
+			},
+			answers: []string{
+				"owm-key",        // OpenWeather key
+				"Springfield",    // Station city
+				"2",              // Springfield, Missouri
+				"",               // Imperial units (US default)
+				"sk-ant-key",     // Anthropic key
+				"xi-key",         // ElevenLabs key
+				"2",              // Bella (sorted by name)
+				"",               // First prompt preset
+				"{import}",       // Import folder
+				"weather_report", // Media ID
+			},
+			wantCode: ExitSuccess,
+			wantConfig: []string{
+				`openweather = "owm-key"`,
+				"latitude = 37.2090",
+				`units = "imperial"`,
+				`voice_id = "voice-bella"`,
+			},
+			wantOutput: "Springfield, Missouri, US (37.2090, -93.2923)",
+		},
+		{
+			name: "unverified keys kept anyway",
+			services: wizardServices{
+				searchLocations: func(ctx context.Context, key, query string, limit int) ([]api.GeocodingResponse, error) {
+					return nil, unauthorized
+				},
+				checkAnthropic: func(ctx context.Context, key string) error { return offline },
+				listVoices:     func(ctx context.Context, key string) ([]api.Voice, error) { return nil, offline },
+			},
+			answers: []string{
+				"new-owm-key", "y", // Rejected, kept anyway
+				"Hilo",            // Place names need a verified key
+				"19.7297,-155.09", // Coordinates instead
+				"2",               // Metric units
+				"sk-ant-key", "y", // Offline, kept anyway
+				"xi-key", "y", // Offline, kept anyway
+				"voice-typed",  // Voice ID typed in
+				"",             // First prompt preset
+				"{import}", "", // Import folder, created
+				"weather_am", // Media ID
+			},
+			importFolder: true,
+			wantCode:     ExitSuccess,
+			wantConfig: []string{
+				`openweather = "new-owm-key"`,
+				"longitude = -155.0900",
+				`units = "metric"`,
+				`voice_id = "voice-typed"`,
+				`media_id = "weather_am"`,
+			},
+			wantOutput: "new keys can take up to two hours to activate",
+		},
+		{
+			name: "input ends early",
+			services: wizardServices{
+				searchLocations: func(ctx context.Context, key, query string, limit int) ([]api.GeocodingResponse, error) {
+					return springfields, nil
+				},
+			},
+			answers:   []string{"owm-key"},
+			wantCode:  ExitGeneralError,
+			wantError: "input ended before the wizard finished",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.services.importFolders = func() []string { return nil }
+			stubWizard(t, tt.services)
+			errs := captureErrors(t)
+
+			dir := t.TempDir()
+			importPath := filepath.Join(dir, "import")
+			if !tt.importFolder {
+				if err := os.Mkdir(importPath, 0755); err != nil {
+					t.Fatalf("Failed to create import folder: %v", err)
+				}
+			}
+			input := strings.ReplaceAll(strings.Join(tt.answers, "\n")+"\n", "{import}", importPath)
+			configPath := filepath.Join(dir, "config.toml")
+
+			var out strings.Builder
+			code := runConfigWizard(configPath, strings.NewReader(input), &out)
+			if code != tt.wantCode {
+				t.Fatalf("Expected exit code %d, got %d\n%s%s", tt.wantCode, code, out.String(), errs.String())
+			}
+			if !strings.Contains(out.String(), tt.wantOutput) {
+				t.Errorf("Expected output containing %q, got:\n%s", tt.wantOutput, out.String())
+			}
+			if !strings.Contains(errs.String(), tt.wantError) {
+				t.Errorf("Expected error containing %q, got %q", tt.wantError, errs.String())
+			}
+			if len(tt.wantConfig) == 0 {
+				if _, err := os.Stat(configPath); !os.IsNotExist(err) {
+					t.Error("Expected no config file to be written")
+				}
+				return
+			}
+
+			data, err := os.ReadFile(configPath)
+			if err != nil {
+				t.Fatalf("Failed to read config: %v", err)
+			}
+			for _, want := range append(tt.wantConfig, `import_path = "`+importPath+`"`) {
+				if !strings.Contains(string(data), want) {
+					t.Errorf("Expected config to contain %q:\n%s", want, data)
+				}
+			}
+			// Logging stays off: nothing is written next to the config or the working directory
+			for _, logs := range []string{filepath.Join(dir, "logs"), "logs/myrcast-wizard.log"} {
+				if _, err := os.Stat(logs); !os.IsNotExist(err) {
+					t.Errorf("Expected no wizard log at %s", logs)
+				}
+			}
+		})
+	}
+}